@@ -0,0 +1,258 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Appender writes rows to new row groups added at the end of an existing
+// parquet file.
+//
+// Appender values are created by calling OpenFileForAppend. The new row groups
+// are buffered in a temporary file, so the file is left unchanged until Close
+// is called; Close then writes the new row groups over the previous footer,
+// followed by a new footer referencing both the previous and the new row groups.
+type Appender struct {
+	file   *os.File
+	buffer *os.File
+	offset int64
+	writer *Writer
+	rowbuf []Row
+	// Cache of schema compatibility checks of the Go types passed to Write.
+	rowTypes map[reflect.Type]appenderRowType
+}
+
+type appenderRowType struct {
+	schema *Schema
+	err    error
+}
+
+// OpenFileForAppend opens the parquet file at path to append row groups to it.
+//
+// The schema and key/value metadata of the file are retained in the new
+// footer. The options configure how the new row groups are written; if they
+// contain a schema, it must be compatible with the schema of the file, and
+// key/value metadata given as options take precedence over the values found
+// in the file.
+//
+// Encrypted parquet files are not supported.
+func OpenFileForAppend(path string, options ...WriterOption) (*Appender, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	a, err := newAppender(file, options)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("opening parquet file %s for append: %w", path, err)
+	}
+	return a, nil
+}
+
+func newAppender(file *os.File, options []WriterOption) (*Appender, error) {
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+
+	f, err := OpenFile(file, size, SkipBloomFilters(true))
+	if err != nil {
+		return nil, err
+	}
+	metadata := f.Metadata()
+	if metadata.EncryptionAlgorithm != (format.EncryptionAlgorithm{}) {
+		return nil, fmt.Errorf("appending to encrypted parquet files is not supported")
+	}
+
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+	schema := f.Schema()
+	if config.Schema != nil {
+		if err := schema.Compatible(config.Schema); err != nil {
+			return nil, err
+		}
+	}
+	keyValueMetadata := make(map[string]string, len(metadata.KeyValueMetadata)+len(config.KeyValueMetadata))
+	for _, kv := range metadata.KeyValueMetadata {
+		keyValueMetadata[kv.Key] = kv.Value
+	}
+	for k, v := range config.KeyValueMetadata {
+		keyValueMetadata[k] = v
+	}
+	config.KeyValueMetadata = keyValueMetadata
+
+	// The footer is made of the thrift-encoded file metadata, followed by its
+	// length and the magic bytes. Everything before it is retained, the footer
+	// is overwritten when the appender is closed.
+	var b [8]byte
+	if _, err := file.ReadAt(b[:], size-8); err != nil {
+		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
+	}
+	offset := size - 8 - int64(binary.LittleEndian.Uint32(b[:4]))
+
+	buffer, err := os.CreateTemp("", "parquet-append-*")
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{output: buffer, config: config}
+	w.configure(schema)
+	w.writer.resume(offset, metadata, f.ColumnIndexes(), f.OffsetIndexes())
+	return &Appender{file: file, buffer: buffer, offset: offset, writer: w}, nil
+}
+
+// resume configures w to continue writing a parquet file of which the content
+// up to offset was already written, including the row groups of metadata.
+func (w *writer) resume(offset int64, metadata *format.FileMetaData, columnIndexes []format.ColumnIndex, offsetIndexes []format.OffsetIndex) {
+	w.writer.offset = offset
+	w.rowGroups = append(w.rowGroups[:0], metadata.RowGroups...)
+	w.schemaElements = metadata.Schema
+	if len(metadata.ColumnOrders) == len(w.columnOrders) {
+		w.columnOrders = metadata.ColumnOrders
+	}
+
+	// Readers expect the page index to be a contiguous section of the file,
+	// the page indexes of the existing row groups are written again with the
+	// new ones. Row groups which have a partial page index lose it.
+	numColumns := len(w.columns)
+	w.columnIndexes = make([][]format.ColumnIndex, len(w.rowGroups))
+	w.offsetIndexes = make([][]format.OffsetIndex, len(w.rowGroups))
+	numColumnChunks := len(w.rowGroups) * numColumns
+
+	for i := range w.rowGroups {
+		columns := make([]format.ColumnChunk, len(w.rowGroups[i].Columns))
+		copy(columns, w.rowGroups[i].Columns)
+		w.rowGroups[i].Columns = columns
+
+		hasColumnIndex := len(columnIndexes) == numColumnChunks
+		hasOffsetIndex := len(offsetIndexes) == numColumnChunks
+		for j := range columns {
+			hasColumnIndex = hasColumnIndex && columns[j].ColumnIndexOffset > 0
+			hasOffsetIndex = hasOffsetIndex && columns[j].OffsetIndexOffset > 0
+		}
+		for j := range columns {
+			columns[j].ColumnIndexOffset, columns[j].ColumnIndexLength = 0, 0
+			columns[j].OffsetIndexOffset, columns[j].OffsetIndexLength = 0, 0
+		}
+		if hasColumnIndex {
+			w.columnIndexes[i] = columnIndexes[i*numColumns : (i+1)*numColumns]
+		}
+		if hasOffsetIndex {
+			w.offsetIndexes[i] = offsetIndexes[i*numColumns : (i+1)*numColumns]
+		}
+	}
+}
+
+// Schema returns the schema of the parquet file that a writes to.
+func (a *Appender) Schema() *Schema { return a.writer.Schema() }
+
+// Write writes a row to the file. The schema of the Go value must be
+// compatible with the schema of the file, otherwise an error wrapping
+// ErrSchemaMismatch is returned.
+func (a *Appender) Write(row interface{}) error {
+	t := dereference(reflect.TypeOf(row))
+	rowType, ok := a.rowTypes[t]
+	if !ok {
+		if t.Kind() != reflect.Struct {
+			rowType.err = fmt.Errorf("cannot append values of type %s to a parquet file", t)
+		} else {
+			rowType.schema = schemaOf(t)
+			rowType.err = a.Schema().Compatible(rowType.schema)
+		}
+		if a.rowTypes == nil {
+			a.rowTypes = make(map[reflect.Type]appenderRowType)
+		}
+		a.rowTypes[t] = rowType
+	}
+	if rowType.err != nil {
+		return rowType.err
+	}
+	// The schema of the file is made of columns which do not know how to
+	// deconstruct Go values, the schema of the Go type is used instead; it
+	// was verified to have the same columns.
+	if cap(a.rowbuf) == 0 {
+		a.rowbuf = make([]Row, 1)
+	}
+	defer clearRows(a.rowbuf)
+	a.rowbuf[0] = rowType.schema.Deconstruct(a.rowbuf[0][:0], row)
+	_, err := a.writer.WriteRows(a.rowbuf)
+	return err
+}
+
+// WriteRows writes rows to the file. The rows are expected to contain values
+// for each column of the file's schema, in the order produced by the
+// parquet.(*Schema).Deconstruct method.
+func (a *Appender) WriteRows(rows []Row) (int, error) {
+	return a.writer.WriteRows(rows)
+}
+
+// WriteRowGroup writes a row group to the file. The schema of the row group
+// must be compatible with the schema of the file, otherwise an error wrapping
+// ErrSchemaMismatch is returned. Row groups of compatible schemas are converted
+// to the schema of the file when needed, and their values are written with the
+// encodings and compression codecs of the file.
+func (a *Appender) WriteRowGroup(rowGroup RowGroup) (int64, error) {
+	schema := a.Schema()
+	rowGroupSchema := rowGroup.Schema()
+	if rowGroupSchema == nil {
+		return 0, ErrRowGroupSchemaMissing
+	}
+	if err := schema.Compatible(rowGroupSchema); err != nil {
+		return 0, err
+	}
+	if !nodesAreEqual(schema, rowGroupSchema) {
+		conv, err := Convert(schema, rowGroupSchema)
+		if err != nil {
+			return 0, err
+		}
+		rowGroup = ConvertRowGroup(rowGroup, conv)
+	}
+	return a.writer.WriteRowGroup(rowGroup)
+}
+
+// Flush writes the buffered rows to a new row group of the file.
+func (a *Appender) Flush() error { return a.writer.Flush() }
+
+// Close flushes the buffered rows, writes the new row groups and footer of the
+// file and closes it. The file is left unchanged if an error occurs before the
+// new row groups are written to it.
+func (a *Appender) Close() error {
+	err := a.writer.Close()
+	if err == nil {
+		err = a.commit()
+	}
+	if closeErr := a.file.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := a.buffer.Close(); err == nil {
+		err = closeErr
+	}
+	if removeErr := os.Remove(a.buffer.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// commit copies the content of the temporary buffer over the footer of the
+// file, and truncates what remains of the previous footer.
+func (a *Appender) commit() error {
+	if _, err := a.buffer.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := io.Copy(io.NewOffsetWriter(a.file, a.offset), a.buffer)
+	if err != nil {
+		return err
+	}
+	if err := a.file.Truncate(a.offset + n); err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
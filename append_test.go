@@ -0,0 +1,159 @@
+package parquet_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type appendRow struct {
+	ID   int64  `parquet:"id"`
+	Name string `parquet:"name,dict"`
+}
+
+func TestOpenFileForAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "append.parquet")
+
+	rows := []appendRow{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}
+	output, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := parquet.NewGenericWriter[appendRow](output, parquet.KeyValueMetadata("hello", "world"))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := parquet.OpenFileForAppend(path, parquet.KeyValueMetadata("append", "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows = append(rows, appendRow{ID: 3, Name: "three"})
+	if err := a.Write(&rows[2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := parquet.NewGenericBuffer[appendRow]()
+	rows = append(rows, appendRow{ID: 4, Name: "four"}, appendRow{ID: 5, Name: "five"})
+	if _, err := buffer.Write(rows[3:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.WriteRowGroup(buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	// Row groups of compatible schemas are converted to the schema of the file.
+	type plainRow struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,plain"`
+	}
+	plainBuffer := parquet.NewGenericBuffer[plainRow]()
+	rows = append(rows, appendRow{ID: 6, Name: "six"})
+	if _, err := plainBuffer.Write([]plainRow{{ID: 6, Name: "six"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.WriteRowGroup(plainBuffer); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file retains its previous footer until the appender is closed.
+	previous, err := parquet.ReadFile[appendRow](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(previous, rows[:2]) {
+		t.Errorf("rows mismatch before closing the appender:\nwant: %+v\ngot:  %+v", rows[:2], previous)
+	}
+
+	type otherRow struct {
+		ID   int32  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	if err := a.Write(otherRow{}); !errors.Is(err, parquet.ErrSchemaMismatch) {
+		t.Errorf("wrong error writing a row of mismatching schema: %v", err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(file, stat.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(f.RowGroups()); n != 4 {
+		t.Errorf("wrong number of row groups: want=4 got=%d", n)
+	}
+	if n := f.NumRows(); n != int64(len(rows)) {
+		t.Errorf("wrong number of rows: want=%d got=%d", len(rows), n)
+	}
+	for key, want := range map[string]string{"hello": "world", "append": "1"} {
+		if value, ok := f.Lookup(key); !ok || value != want {
+			t.Errorf("wrong key/value metadata %q: want=%q got=%q", key, want, value)
+		}
+	}
+	for i, rowGroup := range f.RowGroups() {
+		index := rowGroup.ColumnChunks()[0].ColumnIndex()
+		if index == nil {
+			t.Errorf("row group %d: missing column index", i)
+			continue
+		}
+		if min, max := index.MinValue(0), index.MaxValue(0); min.Int64() > max.Int64() {
+			t.Errorf("row group %d: wrong column index bounds: min=%v max=%v", i, min, max)
+		}
+	}
+
+	got, err := parquet.Read[appendRow](file, stat.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestOpenFileForAppendSchemaMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "append.parquet")
+	if err := parquet.WriteFile(path, []appendRow{{ID: 1, Name: "one"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	type otherRow struct {
+		ID string `parquet:"id"`
+	}
+	_, err := parquet.OpenFileForAppend(path, parquet.SchemaOf(otherRow{}))
+	if !errors.Is(err, parquet.ErrSchemaMismatch) {
+		t.Errorf("wrong error opening file with mismatching schema: %v", err)
+	}
+
+	// The file must not have been modified by the failed attempt.
+	got, err := parquet.ReadFile[appendRow](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("wrong number of rows: want=1 got=%d", len(got))
+	}
+}
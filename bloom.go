@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/parquet-go/parquet-go/bloom"
@@ -23,7 +24,12 @@ type BloomFilter interface {
 
 	// Tests whether the given value is present in the filter.
 	//
-	// A non-nil error may be returned if reading the filter failed. This may
+	// The value must be of the physical type of the column that the filter
+	// belongs to, an error is returned if the kinds mismatch, or if the value
+	// is a fixed length byte array of the wrong size. Null values are never
+	// present in bloom filters.
+	//
+	// A non-nil error may also be returned if reading the filter failed. This may
 	// happen if the filter was lazily loaded from a storage medium during the
 	// call to Check for example. Applications that can guarantee that the
 	// filter was in memory at the time Check was called can safely ignore the
@@ -33,11 +39,23 @@ type BloomFilter interface {
 
 type bloomFilter struct {
 	io.SectionReader
+	typ   Type
 	hash  bloom.Hash
 	check func(io.ReaderAt, int64, uint64) (bool, error)
 }
 
 func (f *bloomFilter) Check(v Value) (bool, error) {
+	if v.IsNull() {
+		return false, nil
+	}
+	if kind := f.typ.Kind(); v.Kind() != kind {
+		return false, fmt.Errorf("cannot check bloom filter of %s column for value of type %s", kind, v.Kind())
+	}
+	if v.Kind() == FixedLenByteArray {
+		if size := f.typ.Length(); len(v.byteArray()) != size {
+			return false, fmt.Errorf("cannot check bloom filter of %s(%d) column for value of length %d", FixedLenByteArray, size, len(v.byteArray()))
+		}
+	}
 	return f.check(&f.SectionReader, f.Size(), v.hash(f.hash))
 }
 
@@ -54,12 +72,13 @@ func (v Value) hash(h bloom.Hash) uint64 {
 	}
 }
 
-func newBloomFilter(file io.ReaderAt, offset int64, header *format.BloomFilterHeader) *bloomFilter {
+func newBloomFilter(file io.ReaderAt, offset int64, typ Type, header *format.BloomFilterHeader) *bloomFilter {
 	if header.Algorithm.Block != nil {
 		if header.Hash.XxHash != nil {
 			if header.Compression.Uncompressed != nil {
 				return &bloomFilter{
 					SectionReader: *io.NewSectionReader(file, offset, int64(header.NumBytes)),
+					typ:           typ,
 					hash:          bloom.XXH64{},
 					check:         bloom.CheckSplitBlock,
 				}
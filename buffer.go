@@ -123,6 +123,9 @@ func (buf *GenericBuffer[T]) Swap(i, j int) {
 	buf.base.Swap(i, j)
 }
 
+// Reset clears the rows held in the buffer, allowing it to be reused to write
+// the next batch of rows. The memory allocated by the column buffers is
+// retained, and the schema and sorting columns of the buffer are unchanged.
 func (buf *GenericBuffer[T]) Reset() {
 	buf.base.Reset()
 }
@@ -252,8 +255,14 @@ func (buf *Buffer) configure(schema *Schema) {
 		}
 
 		sortingIndex := searchSortingColumn(sortingColumns, leaf.path)
-		if sortingIndex < len(sortingColumns) && sortingColumns[sortingIndex].NullsFirst() {
-			nullOrdering = nullsGoFirst
+		if sortingIndex < len(sortingColumns) {
+			// Columns sorted in descending order are wrapped in a reversed
+			// column buffer, which also reverses the order of null values, so
+			// they must go first in the buffer to be placed last in the rows.
+			sorting := sortingColumns[sortingIndex]
+			if sorting.Descending() != sorting.NullsFirst() {
+				nullOrdering = nullsGoFirst
+			}
 		}
 
 		column := columnType.NewColumnBuffer(columnIndex, bufferCap)
@@ -353,6 +362,9 @@ func (buf *Buffer) Swap(i, j int) {
 }
 
 // Reset clears the content of the buffer, allowing it to be reused.
+//
+// The memory allocated by the column buffers is retained, and the schema and
+// sorting columns of the buffer are unchanged.
 func (buf *Buffer) Reset() {
 	for _, col := range buf.columns {
 		col.Reset()
@@ -503,6 +515,9 @@ type bufferPool struct {
 	//   364K, 546K, 819K ...
 	//
 	buckets [bufferPoolBucketCount]sync.Pool
+	// When memory is not nil, the buckets are unused and the byte slices of
+	// buffers are acquired from and released to the memory pool.
+	memory MemoryPool
 }
 
 func (p *bufferPool) newBuffer(bufferSize, bucketSize int) *buffer {
@@ -521,6 +536,20 @@ func (p *bufferPool) newBuffer(bufferSize, bucketSize int) *buffer {
 // get returns a buffer from the levelled buffer pool. size is used to choose
 // the appropriate pool.
 func (p *bufferPool) get(bufferSize int) *buffer {
+	if p.memory != nil {
+		data := p.memory.GetBytes(bufferSize)
+		if cap(data) < bufferSize {
+			data = make([]byte, bufferSize)
+		}
+		b := &buffer{data: data[:bufferSize], refc: 1, pool: p}
+		if debug.TRACEBUF > 0 {
+			b.stack = make([]byte, 4096)
+			b.stack = b.stack[:runtime.Stack(b.stack, false)]
+			runtime.SetFinalizer(b, monitorBufferRelease)
+		}
+		return b
+	}
+
 	bucketIndex, bucketSize := bufferPoolBucketIndexAndSizeOfGet(bufferSize)
 
 	b := (*buffer)(nil)
@@ -548,6 +577,12 @@ func (p *bufferPool) put(b *buffer) {
 	if b.refCount() != 0 {
 		panic("BUG: buffer returned to pool with a non-zero reference count")
 	}
+	if p.memory != nil {
+		data := b.data[:0]
+		b.data = nil
+		p.memory.PutBytes(data)
+		return
+	}
 	if bucketIndex, _ := bufferPoolBucketIndexAndSizeOfPut(cap(b.data)); bucketIndex >= 0 {
 		p.buckets[bucketIndex].Put(b)
 	}
@@ -41,6 +41,29 @@ type BufferPool interface {
 // Go heap.
 func NewBufferPool() BufferPool { return new(memoryBufferPool) }
 
+// MemoryPool is an interface abstracting the allocation of the memory buffers
+// used to decompress and decode the pages read from parquet files.
+//
+// Applications reading files at a high rate may provide their own
+// implementation, for example backed by a sync.Pool, and install it via the
+// parquet.FileMemoryPool file option to control the allocation and retention
+// of decoding buffers.
+//
+// MemoryPool implementations must be safe to use concurrently from multiple
+// goroutines.
+type MemoryPool interface {
+	// GetBytes is called when a file needs a byte slice of the given size to
+	// decode a page. The returned slice may be longer than size, but its
+	// capacity must be at least size; its content does not need to be zeroed.
+	GetBytes(size int) []byte
+
+	// PutBytes is called when the buffer of a page is released. The slice may
+	// differ from the one returned by GetBytes if it had to be grown while
+	// decoding the page. The memory of the slice is not used by the file after
+	// the call.
+	PutBytes([]byte)
+}
+
 type memoryBuffer struct {
 	data []byte
 	off  int
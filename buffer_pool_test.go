@@ -3,7 +3,10 @@ package parquet_test
 import (
 	"bytes"
 	"io"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"testing/iotest"
 
@@ -127,3 +130,80 @@ func assertBufferContent(t *testing.T, b io.ReadSeeker, s string) {
 		t.Error("iotest:", err)
 	}
 }
+
+type countingMemoryPool struct {
+	pool sync.Pool
+	gets int64
+	puts int64
+}
+
+func (p *countingMemoryPool) GetBytes(size int) []byte {
+	atomic.AddInt64(&p.gets, 1)
+	if b, _ := p.pool.Get().(*[]byte); b != nil && cap(*b) >= size {
+		return (*b)[:size]
+	}
+	return make([]byte, size)
+}
+
+func (p *countingMemoryPool) PutBytes(b []byte) {
+	atomic.AddInt64(&p.puts, 1)
+	p.pool.Put(&b)
+}
+
+func TestFileMemoryPool(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name string  `parquet:"name,zstd"`
+		Tags []int32 `parquet:"tags"`
+		Note *string `parquet:"note,optional"`
+	}
+
+	note := "note"
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Name: strings.Repeat("x", i%10),
+			Tags: []int32{int32(i), int32(i + 1)},
+		}
+		if i%3 == 0 {
+			rows[i].Note = &note
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(512))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := new(countingMemoryPool)
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.FileMemoryPool(pool))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := parquet.NewGenericReader[Row](f)
+	got := make([]Row, len(rows))
+	n, err := r.Read(got)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got[:n], rows) {
+		t.Fatalf("rows mismatch: read %d rows", n)
+	}
+
+	gets, puts := atomic.LoadInt64(&pool.gets), atomic.LoadInt64(&pool.puts)
+	if gets == 0 {
+		t.Fatal("no buffers were acquired from the memory pool")
+	}
+	if gets != puts {
+		t.Errorf("buffers acquired from the memory pool were not all released: gets=%d puts=%d", gets, puts)
+	}
+}
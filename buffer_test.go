@@ -15,6 +15,7 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 func TestGenericBuffer(t *testing.T) {
@@ -175,18 +176,29 @@ func benchmarkGenericBuffer[Row generator[Row]](b *testing.B) {
 }
 
 func TestIssue327(t *testing.T) {
-	t.Run("untagged nested lists should panic", func(t *testing.T) {
+	t.Run("untagged nested lists are represented as repeated lists", func(t *testing.T) {
 		type testType struct {
 			ListOfLists [][]int
 		}
 
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("Nested lists without the list tag should panic")
-			}
-		}()
+		buf := parquet.NewGenericBuffer[testType]()
+		column, ok := buf.Schema().Lookup("ListOfLists", "list", "element")
+		if !ok {
+			t.Fatalf("nested lists have no list elements:\n%s", buf.Schema())
+		}
+		if column.MaxRepetitionLevel != 2 || column.MaxDefinitionLevel != 2 {
+			t.Errorf("wrong levels of nested lists: repetition=%d definition=%d", column.MaxRepetitionLevel, column.MaxDefinitionLevel)
+		}
 
-		_ = parquet.NewGenericBuffer[testType]()
+		rows := []testType{{ListOfLists: [][]int{{1, 2}, {}, {3}}}}
+		if _, err := buf.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]testType, 1)
+		if _, err := parquet.NewGenericRowGroupReader[testType](buf).Read(got); err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, rows, got)
 	})
 }
 
@@ -1008,3 +1020,189 @@ func BenchmarkBufferWriteRows100x(b *testing.B) {
 		return n
 	})
 }
+
+func TestGenericBufferSortingOrder(t *testing.T) {
+	type Row struct {
+		Value *int32 `parquet:"value,optional"`
+	}
+	value := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		scenario string
+		sorting  parquet.SortingColumn
+		want     []*int32
+	}{
+		{
+			scenario: "ascending nulls last",
+			sorting:  parquet.Ascending("value"),
+			want:     []*int32{value(1), value(2), value(3), nil},
+		},
+		{
+			scenario: "ascending nulls first",
+			sorting:  parquet.SortingColumnOf([]string{"value"}, false, true),
+			want:     []*int32{nil, value(1), value(2), value(3)},
+		},
+		{
+			scenario: "descending nulls last",
+			sorting:  parquet.Descending("value"),
+			want:     []*int32{value(3), value(2), value(1), nil},
+		},
+		{
+			scenario: "descending nulls last explicit",
+			sorting:  parquet.SortingColumnOf([]string{"value"}, true, false),
+			want:     []*int32{value(3), value(2), value(1), nil},
+		},
+		{
+			scenario: "descending nulls first",
+			sorting:  parquet.NullsFirst(parquet.Descending("value")),
+			want:     []*int32{nil, value(3), value(2), value(1)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := parquet.NewGenericBuffer[Row](
+				parquet.SortingRowGroupConfig(parquet.SortingColumns(test.sorting)),
+			)
+			if _, err := buf.Write([]Row{{value(2)}, {nil}, {value(3)}, {value(1)}}); err != nil {
+				t.Fatal(err)
+			}
+			sort.Sort(buf)
+
+			output := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](output)
+			if _, err := w.WriteRowGroup(buf); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]*int32, len(rows))
+			for i, row := range rows {
+				got[i] = row.Value
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("wrong order of values:\nwant: %v\ngot:  %v", derefs(test.want), derefs(got))
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sortingColumns := f.Metadata().RowGroups[0].SortingColumns
+			if len(sortingColumns) != 1 {
+				t.Fatalf("wrong number of sorting columns in the footer: %d", len(sortingColumns))
+			}
+			if s := sortingColumns[0]; s.Descending != test.sorting.Descending() || s.NullsFirst != test.sorting.NullsFirst() {
+				t.Errorf("wrong sorting column in the footer: %+v", s)
+			}
+		})
+	}
+}
+
+func TestGenericBufferSortingColumnsNotInSchema(t *testing.T) {
+	type Row struct {
+		Value int32 `parquet:"value"`
+	}
+	buf := parquet.NewGenericBuffer[Row](
+		parquet.SortingRowGroupConfig(parquet.SortingColumns(
+			parquet.Ascending("missing"),
+			parquet.Descending("value"),
+		)),
+	)
+	if _, err := buf.Write([]Row{{1}, {2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](output)
+	if _, err := w.WriteRowGroup(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []format.SortingColumn{{ColumnIdx: 0, Descending: true}}
+	if got := f.Metadata().RowGroups[0].SortingColumns; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong sorting columns in the footer:\nwant: %+v\ngot:  %+v", want, got)
+	}
+}
+
+func derefs(values []*int32) []interface{} {
+	s := make([]interface{}, len(values))
+	for i, v := range values {
+		if v != nil {
+			s[i] = *v
+		}
+	}
+	return s
+}
+
+func TestGenericBufferReset(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags,list"`
+	}
+	name := func(s string) *string { return &s }
+
+	buf := parquet.NewGenericBuffer[Row](
+		parquet.SortingRowGroupConfig(parquet.SortingColumns(parquet.Descending("id"))),
+	)
+	schema := buf.Schema()
+
+	batches := [][]Row{
+		{{ID: 1, Name: name("one"), Tags: []string{"a"}}, {ID: 3, Tags: []string{}}, {ID: 2, Tags: []string{"b", "c"}}},
+		{{ID: 5, Tags: []string{"d"}}, {ID: 6, Name: name("six"), Tags: []string{}}},
+	}
+
+	var capacity []int
+	for i, batch := range batches {
+		buf.Reset()
+		if n := buf.NumRows(); n != 0 {
+			t.Fatalf("batch %d: buffer has %d rows after reset", i, n)
+		}
+		if i > 0 {
+			for j, col := range buf.ColumnBuffers() {
+				if col.Cap() < capacity[j] {
+					t.Errorf("batch %d: column %d capacity shrunk from %d to %d", i, j, capacity[j], col.Cap())
+				}
+			}
+		}
+		if buf.Schema() != schema {
+			t.Fatalf("batch %d: schema changed after reset", i)
+		}
+
+		if _, err := buf.Write(batch); err != nil {
+			t.Fatal(err)
+		}
+		sort.Sort(buf)
+
+		want := append([]Row{}, batch...)
+		sort.Slice(want, func(i, j int) bool { return want[i].ID > want[j].ID })
+
+		got := make([]Row, len(batch))
+		n, err := parquet.NewGenericRowGroupReader[Row](buf).Read(got)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got[:n], want) {
+			t.Errorf("batch %d: wrong rows:\nwant: %+v\ngot:  %+v", i, want, got[:n])
+		}
+
+		capacity = capacity[:0]
+		for _, col := range buf.ColumnBuffers() {
+			capacity = append(capacity, col.Cap())
+		}
+	}
+}
@@ -421,6 +421,12 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 			return (*bsonType)(lt.Bson)
 		case lt.UUID != nil:
 			return (*uuidType)(lt.UUID)
+		case lt.Float16 != nil:
+			return (*float16Type)(lt.Float16)
+		case lt.Geometry != nil:
+			return (*geometryType)(lt.Geometry)
+		case lt.Geography != nil:
+			return (*geographyType)(lt.Geography)
 		}
 	}
 
@@ -499,7 +505,9 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case deprecated.Bson:
 			return &bsonType{}
 		case deprecated.Interval:
-			// TODO
+			if s.TypeLength != nil && *s.TypeLength == intervalSize {
+				return IntervalType
+			}
 		}
 	}
 
@@ -541,8 +549,16 @@ func schemaRepetitionTypeOf(s *format.SchemaElement) format.FieldRepetitionType
 	return format.Required
 }
 
+// pool returns the pool that buffers of decoded pages are allocated from.
+func (c *Column) pool() *bufferPool {
+	if c.file != nil && c.file.buffers != nil {
+		return c.file.buffers
+	}
+	return &buffers
+}
+
 func (c *Column) decompress(compressedPageData []byte, uncompressedPageSize int32) (page *buffer, err error) {
-	page = buffers.get(int(uncompressedPageSize))
+	page = c.pool().get(int(uncompressedPageSize))
 	page.data, err = c.compression.Decode(page.data, compressedPageData)
 	if err != nil {
 		page.unref()
@@ -575,7 +591,7 @@ func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Di
 
 	if c.maxRepetitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
-		repetitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
+		repetitionLevels, pageData, err = decodeLevelsV1(c.pool(), encoding, numValues, pageData)
 		if err != nil {
 			return nil, fmt.Errorf("decoding repetition levels of data page v1: %w", err)
 		}
@@ -584,7 +600,7 @@ func (c *Column) decodeDataPageV1(header DataPageHeaderV1, page *buffer, dict Di
 
 	if c.maxDefinitionLevel > 0 {
 		encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
-		definitionLevels, pageData, err = decodeLevelsV1(encoding, numValues, pageData)
+		definitionLevels, pageData, err = decodeLevelsV1(c.pool(), encoding, numValues, pageData)
 		if err != nil {
 			return nil, fmt.Errorf("decoding definition levels of data page v1: %w", err)
 		}
@@ -621,7 +637,7 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 			pageData, err = skipLevelsV2(pageData, length)
 		} else {
 			encoding := lookupLevelEncoding(header.RepetitionLevelEncoding(), c.maxRepetitionLevel)
-			repetitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
+			repetitionLevels, pageData, err = decodeLevelsV2(c.pool(), encoding, numValues, pageData, length)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("decoding repetition levels of data page v2: %w", io.ErrUnexpectedEOF)
@@ -636,7 +652,7 @@ func (c *Column) decodeDataPageV2(header DataPageHeaderV2, page *buffer, dict Di
 			pageData, err = skipLevelsV2(pageData, length)
 		} else {
 			encoding := lookupLevelEncoding(header.DefinitionLevelEncoding(), c.maxDefinitionLevel)
-			definitionLevels, pageData, err = decodeLevelsV2(encoding, numValues, pageData, length)
+			definitionLevels, pageData, err = decodeLevelsV2(c.pool(), encoding, numValues, pageData, length)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("decoding definition levels of data page v2: %w", io.ErrUnexpectedEOF)
@@ -679,14 +695,14 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 		vbuf = page
 		pageValues = data
 	} else {
-		vbuf = buffers.get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
+		vbuf = c.pool().get(pageType.EstimateDecodeSize(numValues, data, pageEncoding))
 		defer vbuf.unref()
 		pageValues = vbuf.data
 	}
 
 	// Page offsets not needed when dictionary-encoded
 	if pageType.Kind() == ByteArray && !isDictionaryEncoding(pageEncoding) {
-		obuf = buffers.get(4 * (numValues + 1))
+		obuf = c.pool().get(4 * (numValues + 1))
 		defer obuf.unref()
 		pageOffsets = unsafecast.BytesToUint32(obuf.data)
 	}
@@ -718,7 +734,7 @@ func (c *Column) decodeDataPage(header DataPageHeader, numValues int, repetition
 	return newBufferedPage(newPage, vbuf, obuf, repetitionLevels, definitionLevels), nil
 }
 
-func decodeLevelsV1(enc encoding.Encoding, numValues int, data []byte) (*buffer, []byte, error) {
+func decodeLevelsV1(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte) (*buffer, []byte, error) {
 	if len(data) < 4 {
 		return nil, data, io.ErrUnexpectedEOF
 	}
@@ -727,17 +743,17 @@ func decodeLevelsV1(enc encoding.Encoding, numValues int, data []byte) (*buffer,
 	if j > len(data) {
 		return nil, data, io.ErrUnexpectedEOF
 	}
-	levels, err := decodeLevels(enc, numValues, data[i:j])
+	levels, err := decodeLevels(pool, enc, numValues, data[i:j])
 	return levels, data[j:], err
 }
 
-func decodeLevelsV2(enc encoding.Encoding, numValues int, data []byte, length int64) (*buffer, []byte, error) {
-	levels, err := decodeLevels(enc, numValues, data[:length])
+func decodeLevelsV2(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte, length int64) (*buffer, []byte, error) {
+	levels, err := decodeLevels(pool, enc, numValues, data[:length])
 	return levels, data[length:], err
 }
 
-func decodeLevels(enc encoding.Encoding, numValues int, data []byte) (levels *buffer, err error) {
-	levels = buffers.get(numValues)
+func decodeLevels(pool *bufferPool, enc encoding.Encoding, numValues int, data []byte) (levels *buffer, err error) {
+	levels = pool.get(numValues)
 	levels.data, err = enc.DecodeLevels(levels.data, data)
 	if err != nil {
 		levels.unref()
@@ -13,6 +13,7 @@ import (
 
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding/plain"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/bitpack"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 	"github.com/parquet-go/parquet-go/sparse"
@@ -257,6 +258,7 @@ func (col *optionalColumnBuffer) Page() Page {
 
 func (col *optionalColumnBuffer) Reset() {
 	col.base.Reset()
+	col.reordered = false
 	col.rows = col.rows[:0]
 	col.definitionLevels = col.definitionLevels[:0]
 }
@@ -572,6 +574,7 @@ func (col *repeatedColumnBuffer) swapReorderingBuffer(buf *repeatedColumnBuffer)
 
 func (col *repeatedColumnBuffer) Reset() {
 	col.base.Reset()
+	col.reordered = false
 	col.rows = col.rows[:0]
 	col.repetitionLevels = col.repetitionLevels[:0]
 	col.definitionLevels = col.definitionLevels[:0]
@@ -1967,15 +1970,51 @@ type writeRowsFunc func(columns []ColumnBuffer, rows sparse.Array, levels column
 // parquet schema. The column path indicates the column that the function is
 // being generated for in the parquet schema.
 func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	// Pointers written to optional JSON columns are dereferenced first, the
+	// nil pointers being null values.
 	if leaf, exists := schema.Lookup(path...); exists && leaf.Node.Type().LogicalType() != nil && leaf.Node.Type().LogicalType().Json != nil {
-		return writeRowsFuncOfJSON(t, schema, path)
+		if t.Kind() != reflect.Pointer || !leaf.Node.Optional() {
+			return writeRowsFuncOfJSON(t, schema, path)
+		}
+	}
+
+	if t.Kind() != reflect.Pointer {
+		if typ := customTypeOf(schema, path); typ != nil {
+			return writeRowsFuncOfCustom(t, schema, path, typ)
+		}
+	}
+
+	if t.Kind() != reflect.Pointer && isDecimalGoType(t) {
+		return writeRowsFuncOfDecimal(t, schema, path)
+	}
+
+	switch t.Kind() {
+	case reflect.Uint16, reflect.Float32, reflect.Float64:
+		if leaf, exists := schema.Lookup(path...); exists {
+			if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.Float16 != nil {
+				return writeRowsFuncOfFloat16(t, schema, path)
+			}
+		}
 	}
 
 	switch t {
 	case reflect.TypeOf(deprecated.Int96{}):
 		return writeRowsFuncOfRequired(t, schema, path)
 	case reflect.TypeOf(time.Time{}):
+		if leaf, exists := schema.Lookup(path...); exists {
+			if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.Date != nil {
+				return writeRowsFuncOfDate(t, schema, path)
+			}
+		}
 		return writeRowsFuncOfTime(t, schema, path)
+	case reflect.TypeOf(Interval{}):
+		return writeRowsFuncOfInterval(t, schema, path)
+	case reflect.TypeOf(time.Duration(0)):
+		if leaf, exists := schema.Lookup(path...); exists {
+			if lt := leaf.Node.Type().LogicalType(); lt != nil && lt.Time != nil {
+				return writeRowsFuncOfDuration(t, schema, path)
+			}
+		}
 	}
 
 	switch t.Kind() {
@@ -2003,6 +2042,9 @@ func writeRowsFuncOf(t reflect.Type, schema *Schema, path columnPath) writeRowsF
 			return writeRowsFuncOfRequired(t, schema, path)
 		}
 
+	case reflect.Int8, reflect.Int16, reflect.Uint8, reflect.Uint16:
+		return writeRowsFuncOfSmallInt(t, schema, path)
+
 	case reflect.Pointer:
 		return writeRowsFuncOfPointer(t, schema, path)
 
@@ -2179,7 +2221,15 @@ func writeRowsFuncOfPointer(t reflect.Type, schema *Schema, path columnPath) wri
 func writeRowsFuncOfSlice(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
 	elemType := t.Elem()
 	elemSize := uintptr(elemType.Size())
-	writeRows := writeRowsFuncOf(elemType, schema, path)
+	elemPath := path
+	// Slices of slices are represented by repeated LIST groups, the values of
+	// the inner slices are written to the elements of the lists.
+	if elemType.Kind() == reflect.Slice && elemType.Elem().Kind() != reflect.Uint8 {
+		if node := lookupColumnPath(schema, path); node != nil && isList(node) {
+			elemPath = path.append("list", "element")
+		}
+	}
+	writeRows := writeRowsFuncOf(elemType, schema, elemPath)
 
 	// When the element is a pointer type, the writeRows function will be an
 	// instance returned by writeRowsFuncOfPointer, which handles incrementing
@@ -2234,11 +2284,12 @@ func writeRowsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) writ
 	columns := make([]column, len(fields))
 
 	for i, f := range fields {
-		optional := false
+		list, optional := false, false
 		columnPath := path.append(f.Name)
 		forEachStructTagOption(f, func(_ reflect.Type, option, _ string) {
 			switch option {
 			case "list":
+				list = true
 				columnPath = columnPath.append("list", "element")
 			case "optional":
 				optional = true
@@ -2248,7 +2299,14 @@ func writeRowsFuncOfStruct(t reflect.Type, schema *Schema, path columnPath) writ
 		writeRows := writeRowsFuncOf(f.Type, schema, columnPath)
 		if optional {
 			switch f.Type.Kind() {
-			case reflect.Pointer, reflect.Slice:
+			case reflect.Pointer:
+			case reflect.Slice:
+				// Byte slices are leaf values of the optional column, and
+				// optional lists are null when the slices are nil. Other
+				// slices apply the optional tag to their elements.
+				if list || f.Type.Elem().Kind() == reflect.Uint8 {
+					writeRows = writeRowsFuncOfOptional(f.Type, schema, columnPath, writeRows)
+				}
 			default:
 				writeRows = writeRowsFuncOfOptional(f.Type, schema, columnPath, writeRows)
 			}
@@ -2378,16 +2436,162 @@ func writeRowsFuncOfJSON(t reflect.Type, schema *Schema, path columnPath) writeR
 	}
 }
 
+func writeRowsFuncOfDecimal(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	col, _ := schema.Lookup(path...)
+	typ := col.Node.Type()
+	lt := typ.LogicalType()
+	if lt == nil || lt.Decimal == nil {
+		panic("cannot write Go values of type " + typeNameOf(t) + " to parquet column of type " + typ.String())
+	}
+	kind := typ.Kind()
+	size := typ.Length()
+	writeRows := writeRowsFuncOfRequired(t, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		values := make([]Value, rows.Len())
+		for i := range values {
+			v, err := makeValueDecimal(kind, lt.Decimal, reflect.NewAt(t, rows.Index(i)).Elem())
+			if err != nil {
+				return err
+			}
+			values[i] = v
+		}
+
+		var a sparse.Array
+		switch kind {
+		case Int32:
+			s := make([]int32, len(values))
+			for i, v := range values {
+				s[i] = v.int32()
+			}
+			a = makeArrayOf(s)
+		case Int64:
+			s := make([]int64, len(values))
+			for i, v := range values {
+				s[i] = v.int64()
+			}
+			a = makeArrayOf(s)
+		default:
+			b := make([]byte, len(values)*size)
+			for i, v := range values {
+				copy(b[i*size:], v.byteArray())
+			}
+			a = makeArray(unsafe.Pointer(&b[0]), len(values), uintptr(size))
+		}
+		return writeRows(columns, a, levels)
+	}
+}
+
+// writeRowsFuncOfSmallInt returns a writeRowsFunc for Go integers of less than
+// 32 bits, which are stored in INT32 columns and are widened before being
+// written to the column buffers.
+func writeRowsFuncOfSmallInt(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	writeRows := writeRowsFuncOfRequired(t, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		values := make([]int32, rows.Len())
+		for i := range values {
+			switch p := rows.Index(i); t.Kind() {
+			case reflect.Int8:
+				values[i] = int32(*(*int8)(p))
+			case reflect.Int16:
+				values[i] = int32(*(*int16)(p))
+			case reflect.Uint8:
+				values[i] = int32(*(*uint8)(p))
+			default:
+				values[i] = int32(*(*uint16)(p))
+			}
+		}
+		return writeRows(columns, makeArrayOf(values), levels)
+	}
+}
+
+func writeRowsFuncOfDuration(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	col, _ := schema.Lookup(path...)
+	lt := col.Node.Type().LogicalType().Time
+
+	if lt.Unit.Millis != nil {
+		t := reflect.TypeOf(int32(0))
+		elemSize := uintptr(t.Size())
+		writeRows := writeRowsFuncOf(t, schema, path)
+
+		return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+			if rows.Len() == 0 {
+				return writeRows(columns, rows, levels)
+			}
+			durations := rows.Int64Array()
+			for i := 0; i < durations.Len(); i++ {
+				val := int32(timeOfDuration(time.Duration(durations.Index(i)), lt))
+				a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
+				if err := writeRows(columns, a, levels); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	t := reflect.TypeOf(int64(0))
+	elemSize := uintptr(t.Size())
+	writeRows := writeRowsFuncOf(t, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+		durations := rows.Int64Array()
+		for i := 0; i < durations.Len(); i++ {
+			val := timeOfDuration(time.Duration(durations.Index(i)), lt)
+			a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
+			if err := writeRows(columns, a, levels); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func writeRowsFuncOfDate(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	t := reflect.TypeOf(int32(0))
+	elemSize := uintptr(t.Size())
+	writeRows := writeRowsFuncOf(t, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		times := rows.TimeArray()
+		for i := 0; i < times.Len(); i++ {
+			val := dateOfTime(times.Index(i))
+
+			a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
+			if err := writeRows(columns, a, levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
 	t := reflect.TypeOf(int64(0))
 	elemSize := uintptr(t.Size())
 	writeRows := writeRowsFuncOf(t, schema, path)
 
 	col, _ := schema.Lookup(path...)
-	unit := Nanosecond.TimeUnit()
-	lt := col.Node.Type().LogicalType()
-	if lt != nil && lt.Timestamp != nil {
-		unit = lt.Timestamp.Unit
+	var ts *format.TimestampType
+	if lt := col.Node.Type().LogicalType(); lt != nil {
+		ts = lt.Timestamp
 	}
 
 	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
@@ -2397,16 +2601,7 @@ func writeRowsFuncOfTime(_ reflect.Type, schema *Schema, path columnPath) writeR
 
 		times := rows.TimeArray()
 		for i := 0; i < times.Len(); i++ {
-			t := times.Index(i)
-			var val int64
-			switch {
-			case unit.Millis != nil:
-				val = t.UnixMilli()
-			case unit.Micros != nil:
-				val = t.UnixMicro()
-			default:
-				val = t.UnixNano()
-			}
+			val := timestampOfTime(times.Index(i), ts)
 
 			a := makeArray(unsafecast.PointerOfValue(reflect.ValueOf(val)), 1, elemSize)
 			if err := writeRows(columns, a, levels); err != nil {
@@ -2,6 +2,9 @@ package parquet
 
 import (
 	"io"
+
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 // The ColumnChunk interface represents individual columns of a row group.
@@ -34,6 +37,100 @@ type ColumnChunk interface {
 	NumValues() int64
 }
 
+// ColumnReader reads the values of a column chunk, iterating over its pages.
+//
+// ColumnReader values are useful to programs which process columns without
+// reconstructing rows, for example to compute aggregates of a single column.
+// The repetition and definition levels of the values are exposed by the
+// values themselves, as well as by the RepetitionLevel and DefinitionLevel
+// methods returning the levels of the last value read.
+type ColumnReader struct {
+	pages  Pages
+	page   Page
+	values ValueReader
+
+	repetitionLevel int
+	definitionLevel int
+}
+
+// NewColumnReader constructs a reader of the values of chunk.
+//
+// The program must call Close when it does not need the reader anymore, to
+// release the resources held by the reader.
+func NewColumnReader(chunk ColumnChunk) *ColumnReader {
+	return &ColumnReader{pages: chunk.Pages()}
+}
+
+// ReadValues reads the next values of the column chunk into values, returning
+// the number of values read. When all the values have been read, the method
+// returns io.EOF.
+//
+// The values are read from a single page at a time, the method may return
+// fewer values than len(values) when it reaches the end of a page. Values of
+// BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY types reference the memory of the page
+// they were read from, and remain valid until the next call to ReadValues or
+// Close; programs must clone the values to retain them longer.
+func (r *ColumnReader) ReadValues(values []Value) (int, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	for {
+		if r.values == nil {
+			r.releasePage()
+			if r.pages == nil {
+				return 0, io.EOF
+			}
+			page, err := r.pages.ReadPage()
+			if err != nil {
+				return 0, err
+			}
+			r.page, r.values = page, page.Values()
+		}
+
+		n, err := r.values.ReadValues(values)
+		if n > 0 {
+			last := values[n-1]
+			r.repetitionLevel = last.RepetitionLevel()
+			r.definitionLevel = last.DefinitionLevel()
+		}
+		if err == io.EOF {
+			r.values = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// RepetitionLevel returns the repetition level of the last value read.
+func (r *ColumnReader) RepetitionLevel() int { return r.repetitionLevel }
+
+// DefinitionLevel returns the definition level of the last value read.
+func (r *ColumnReader) DefinitionLevel() int { return r.definitionLevel }
+
+// Close closes the reader, releasing the page that it was reading from.
+func (r *ColumnReader) Close() error {
+	r.releasePage()
+	r.values = nil
+	if r.pages == nil {
+		return nil
+	}
+	err := r.pages.Close()
+	r.pages = nil
+	return err
+}
+
+func (r *ColumnReader) releasePage() {
+	if r.page != nil {
+		Release(r.page)
+		r.page = nil
+	}
+}
+
+var _ ValueReader = (*ColumnReader)(nil)
+
 type pageAndValueWriter interface {
 	PageWriter
 	ValueWriter
@@ -215,3 +312,370 @@ func readRowsFuncOfLeaf(columnIndex int, repetitionDepth byte) (int, readRowsFun
 
 	return columnIndex + 1, read
 }
+
+// ColumnChunkNullCount returns the number of null values recorded in the
+// statistics of a column chunk. The boolean is false when the count was not
+// recorded, which is always the case for the column chunks of buffers.
+//
+// The statistics of parquet files do not distinguish a null count of zero from
+// a missing one, a count of zero is reported as not recorded.
+//
+// For the column chunks of merged row groups, the counts of the merged chunks
+// are added together; the boolean is false if some of the chunks do not have a
+// null count.
+func ColumnChunkNullCount(chunk ColumnChunk) (int64, bool) {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		n := c.chunk.MetaData.Statistics.NullCount
+		return n, n != 0
+	case *seekColumnChunk:
+		return ColumnChunkNullCount(c.base)
+	case *multiColumnChunk:
+		n := int64(0)
+		for _, chunk := range c.chunks {
+			count, ok := ColumnChunkNullCount(chunk)
+			if !ok {
+				return 0, false
+			}
+			n += count
+		}
+		return n, true
+	case *missingColumnChunk:
+		return c.numNulls, true
+	case *emptyColumnChunk:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// ColumnChunkDistinctCount returns the number of distinct values recorded in
+// the statistics of a column chunk. The boolean is false when the count was
+// not recorded, which is always the case for the column chunks of buffers.
+//
+// The statistics of parquet files do not distinguish a distinct count of zero
+// from a missing one, a count of zero is reported as not recorded.
+//
+// Distinct counts cannot be added together since values may occur in more than
+// one chunk, the boolean is false for the column chunks of merged row groups,
+// unless a single chunk was merged.
+func ColumnChunkDistinctCount(chunk ColumnChunk) (int64, bool) {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		n := c.chunk.MetaData.Statistics.DistinctCount
+		return n, n != 0
+	case *seekColumnChunk:
+		return ColumnChunkDistinctCount(c.base)
+	case *multiColumnChunk:
+		if len(c.chunks) == 1 {
+			return ColumnChunkDistinctCount(c.chunks[0])
+		}
+		return 0, false
+	case *emptyColumnChunk:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// ColumnChunkMinMax returns the min and max values recorded in the statistics
+// of a column chunk, decoded according to the physical type of the column. The
+// boolean is false when the statistics have no min and max values, which is
+// always the case for the column chunks of buffers.
+//
+// Values of logical types like DECIMAL or TIMESTAMP are returned in their
+// physical representation, and may be converted to Go values with the
+// AssignValue method of the column type.
+//
+// For the column chunks of merged row groups, the bounds of the merged chunks
+// are combined; the boolean is false if some of the chunks do not have min and
+// max values.
+func ColumnChunkMinMax(chunk ColumnChunk) (min, max Value, ok bool) {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		stats := &c.chunk.MetaData.Statistics
+		if stats.MinValue == nil || stats.MaxValue == nil {
+			return Value{}, Value{}, false
+		}
+		kind := c.Type().Kind()
+		min, err := parseValue(kind, stats.MinValue)
+		if err != nil {
+			return Value{}, Value{}, false
+		}
+		max, err = parseValue(kind, stats.MaxValue)
+		if err != nil {
+			return Value{}, Value{}, false
+		}
+		return min.Clone(), max.Clone(), true
+	case *seekColumnChunk:
+		return ColumnChunkMinMax(c.base)
+	case *multiColumnChunk:
+		typ := c.Type()
+		for i, chunk := range c.chunks {
+			chunkMin, chunkMax, chunkOk := ColumnChunkMinMax(chunk)
+			if !chunkOk {
+				return Value{}, Value{}, false
+			}
+			if i == 0 || typ.Compare(chunkMin, min) < 0 {
+				min = chunkMin
+			}
+			if i == 0 || typ.Compare(chunkMax, max) > 0 {
+				max = chunkMax
+			}
+		}
+		return min, max, len(c.chunks) > 0
+	default:
+		return Value{}, Value{}, false
+	}
+}
+
+// DictionaryValues returns the values of the dictionary of a column chunk,
+// which are the distinct values that the chunk holds. The boolean is false if
+// the column chunk is not dictionary encoded.
+//
+// For column chunks of parquet files, only the dictionary page is read, which
+// makes the function a cheap way to get the domain of the values of a chunk.
+// Writers may fall back to other encodings when the dictionary grows too large
+// (see MaxDictionarySize), in which case the values of pages written after the
+// fallback are not in the dictionary; the encodings of pages are returned by
+// ColumnChunkPageEncodingStats.
+//
+// The returned values are copies that programs may retain after closing the
+// file that the chunk was read from.
+func DictionaryValues(chunk ColumnChunk) ([]Value, bool, error) {
+	var dict Dictionary
+
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		d, err := c.readDictionary()
+		if err != nil {
+			return nil, false, err
+		}
+		dict = d
+	case *seekColumnChunk:
+		return DictionaryValues(c.base)
+	default:
+		pages := chunk.Pages()
+		defer pages.Close()
+		page, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return nil, false, err
+		}
+		defer Release(page)
+		dict = page.Dictionary()
+	}
+
+	if dict == nil {
+		return nil, false, nil
+	}
+	values := make([]Value, dict.Len())
+	for i := range values {
+		values[i] = dict.Index(int32(i)).Clone()
+	}
+	return values, true, nil
+}
+
+// ColumnChunkEncodings returns the encodings declared in the metadata of a
+// column chunk, in the order they were recorded by the writer of the file.
+// For the column chunks of merged row groups, the encodings of all the merged
+// chunks are returned, without duplicates.
+//
+// The list contains the encodings of the values, and of the repetition and
+// definition levels of optional and repeated columns, which are usually RLE.
+// Dictionary encoded chunks list PLAIN, or PLAIN_DICTIONARY in files written
+// with version 1 data pages, for the dictionary page, and RLE_DICTIONARY for
+// the data pages; chunks where the writer fell back to another encoding also
+// list the encoding of the pages written after the fallback. The number of
+// pages written with each encoding is returned by ColumnChunkPageEncodingStats.
+//
+// Encodings not supported by this package are returned as encoding.NotSupported
+// values. The function returns nil for column chunks which do not have
+// metadata, like the column chunks of buffers.
+func ColumnChunkEncodings(chunk ColumnChunk) []encoding.Encoding {
+	formatEncodings := columnChunkFormatEncodings(chunk)
+	if formatEncodings == nil {
+		return nil
+	}
+	encodings := make([]encoding.Encoding, len(formatEncodings))
+	for i, enc := range formatEncodings {
+		encodings[i] = LookupEncoding(enc)
+	}
+	return encodings
+}
+
+// columnChunkFormatEncodings returns the encodings recorded in the metadata of
+// chunk. The encodings of merged column chunks are deduplicated on their values
+// in the metadata, so distinct encodings remain distinct even if they are not
+// supported by this package.
+func columnChunkFormatEncodings(chunk ColumnChunk) []format.Encoding {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		return c.chunk.MetaData.Encoding
+	case *seekColumnChunk:
+		return columnChunkFormatEncodings(c.base)
+	case *multiColumnChunk:
+		var encodings []format.Encoding
+		for _, chunk := range c.chunks {
+			for _, enc := range columnChunkFormatEncodings(chunk) {
+				if !hasFormatEncoding(encodings, enc) {
+					encodings = append(encodings, enc)
+				}
+			}
+		}
+		return encodings
+	default:
+		return nil
+	}
+}
+
+func hasFormatEncoding(encodings []format.Encoding, enc format.Encoding) bool {
+	for _, e := range encodings {
+		if e == enc {
+			return true
+		}
+	}
+	return false
+}
+
+// PageEncodingStat is the number of pages of a column chunk which are of the
+// same type and were written with the same encoding.
+type PageEncodingStat struct {
+	PageType format.PageType
+	Encoding encoding.Encoding
+	Count    int
+}
+
+// ColumnChunkPageEncodingStats returns the page encoding statistics recorded in
+// the metadata of a column chunk, which summarize the encodings of its pages
+// without reading them; for example, the statistics tell whether all the data
+// pages of a dictionary encoded chunk use the dictionary, or if the writer fell
+// back to another encoding.
+//
+// For the column chunks of merged row groups, the page counts of the merged
+// chunks are added together. The function returns nil for column chunks which
+// do not have metadata, like the column chunks of buffers, and for the chunks
+// of files written without page encoding statistics.
+func ColumnChunkPageEncodingStats(chunk ColumnChunk) []PageEncodingStat {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		var stats []PageEncodingStat
+		for _, st := range c.chunk.MetaData.EncodingStats {
+			stats = addPageEncodingStat(stats, PageEncodingStat{
+				PageType: st.PageType,
+				Encoding: LookupEncoding(st.Encoding),
+				Count:    int(st.Count),
+			})
+		}
+		return stats
+	case *seekColumnChunk:
+		return ColumnChunkPageEncodingStats(c.base)
+	case *multiColumnChunk:
+		var stats []PageEncodingStat
+		for _, chunk := range c.chunks {
+			for _, st := range ColumnChunkPageEncodingStats(chunk) {
+				stats = addPageEncodingStat(stats, st)
+			}
+		}
+		return stats
+	default:
+		return nil
+	}
+}
+
+func addPageEncodingStat(stats []PageEncodingStat, add PageEncodingStat) []PageEncodingStat {
+	for i, st := range stats {
+		if st.PageType == add.PageType && st.Encoding.Encoding() == add.Encoding.Encoding() {
+			stats[i].Count += add.Count
+			return stats
+		}
+	}
+	return append(stats, add)
+}
+
+// SizeStatistics carries the size statistics of column chunks, which writers
+// may record in the column chunk metadata to help readers estimate the memory
+// needed to decode the values, or the number of nulls and length of lists
+// without reading the pages.
+//
+// The histograms hold the number of times each repetition and definition level
+// was observed in the column chunk, for example the element at index 0 of the
+// definition level histogram is the number of values which are null at the
+// top level of the record. Writers may omit the histograms when the maximum
+// repetition level is zero, and when the maximum definition level is zero or
+// one, in which case the slices are empty.
+type SizeStatistics struct {
+	// The number of bytes of BYTE_ARRAY values, excluding the 4 bytes length
+	// prefix of each value in the PLAIN encoding. Zero for columns of other
+	// physical types.
+	UnencodedByteArrayDataBytes int64
+	RepetitionLevelHistogram    []int64
+	DefinitionLevelHistogram    []int64
+}
+
+// ColumnChunkSizeStatistics returns the size statistics recorded in the
+// metadata of a column chunk. The boolean is false when the chunk has no size
+// statistics, which is the case of files written by older versions of parquet
+// writers, including this package, and of the column chunks of buffers.
+//
+// For the column chunks of merged row groups, the statistics of the merged
+// chunks are added together; the boolean is false if some of the chunks do not
+// have size statistics.
+//
+// The returned histograms are copies that programs may retain or modify.
+func ColumnChunkSizeStatistics(chunk ColumnChunk) (SizeStatistics, bool) {
+	switch c := chunk.(type) {
+	case *fileColumnChunk:
+		stats := &c.chunk.MetaData.SizeStatistics
+		if stats.UnencodedByteArrayDataBytes == nil && len(stats.RepetitionLevelHistogram) == 0 && len(stats.DefinitionLevelHistogram) == 0 {
+			return SizeStatistics{}, false
+		}
+		sizeStats := SizeStatistics{
+			RepetitionLevelHistogram: copyInt64s(stats.RepetitionLevelHistogram),
+			DefinitionLevelHistogram: copyInt64s(stats.DefinitionLevelHistogram),
+		}
+		if stats.UnencodedByteArrayDataBytes != nil {
+			sizeStats.UnencodedByteArrayDataBytes = *stats.UnencodedByteArrayDataBytes
+		}
+		return sizeStats, true
+	case *seekColumnChunk:
+		return ColumnChunkSizeStatistics(c.base)
+	case *multiColumnChunk:
+		var sizeStats SizeStatistics
+		for i, chunk := range c.chunks {
+			stats, ok := ColumnChunkSizeStatistics(chunk)
+			if !ok {
+				return SizeStatistics{}, false
+			}
+			if i == 0 {
+				sizeStats = stats
+				continue
+			}
+			if len(stats.RepetitionLevelHistogram) != len(sizeStats.RepetitionLevelHistogram) ||
+				len(stats.DefinitionLevelHistogram) != len(sizeStats.DefinitionLevelHistogram) {
+				return SizeStatistics{}, false
+			}
+			sizeStats.UnencodedByteArrayDataBytes += stats.UnencodedByteArrayDataBytes
+			addInt64s(sizeStats.RepetitionLevelHistogram, stats.RepetitionLevelHistogram)
+			addInt64s(sizeStats.DefinitionLevelHistogram, stats.DefinitionLevelHistogram)
+		}
+		return sizeStats, len(c.chunks) > 0
+	default:
+		return SizeStatistics{}, false
+	}
+}
+
+func copyInt64s(values []int64) []int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	return append(make([]int64, 0, len(values)), values...)
+}
+
+func addInt64s(dst, src []int64) {
+	for i := range dst {
+		dst[i] += src[i]
+	}
+}
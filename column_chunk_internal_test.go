@@ -0,0 +1,34 @@
+package parquet
+
+import (
+	"testing"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestColumnChunkEncodingsNotSupported(t *testing.T) {
+	chunkOf := func(encodings ...format.Encoding) ColumnChunk {
+		return &fileColumnChunk{chunk: &format.ColumnChunk{
+			MetaData: format.ColumnMetaData{Encoding: encodings},
+		}}
+	}
+	merged := &multiColumnChunk{chunks: []ColumnChunk{
+		chunkOf(format.Plain, 100),
+		chunkOf(format.Plain, 101),
+		chunkOf(100),
+	}}
+
+	got := columnChunkFormatEncodings(merged)
+	want := []format.Encoding{format.Plain, 100, 101}
+	if len(got) != len(want) {
+		t.Fatalf("wrong encodings of merged column chunks: want=%v got=%v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong encoding at index %d: want=%v got=%v", i, want[i], got[i])
+		}
+	}
+	if n := len(ColumnChunkEncodings(merged)); n != len(want) {
+		t.Errorf("wrong number of encodings: want=%d got=%d", len(want), n)
+	}
+}
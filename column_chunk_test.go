@@ -0,0 +1,297 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+func TestColumnReader(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Tags []string `parquet:"tags"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, "tag")
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+	if n := columns[0].OffsetIndex().NumPages(); n < 2 {
+		t.Fatalf("the column chunk must have multiple pages to exercise the reader: %d", n)
+	}
+
+	readAll := func(chunk parquet.ColumnChunk) (values []parquet.Value) {
+		r := parquet.NewColumnReader(chunk)
+		defer r.Close()
+		buffer := make([]parquet.Value, 100)
+		for {
+			n, err := r.ReadValues(buffer)
+			for _, v := range buffer[:n] {
+				values = append(values, v.Clone())
+			}
+			if n > 0 {
+				last := buffer[n-1]
+				if r.RepetitionLevel() != last.RepetitionLevel() || r.DefinitionLevel() != last.DefinitionLevel() {
+					t.Fatalf("wrong levels of the last value read: want=(%d,%d) got=(%d,%d)",
+						last.RepetitionLevel(), last.DefinitionLevel(), r.RepetitionLevel(), r.DefinitionLevel())
+				}
+			}
+			if err == io.EOF {
+				return values
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	ids := readAll(columns[0])
+	if len(ids) != len(rows) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(rows), len(ids))
+	}
+	for i, v := range ids {
+		if v.Int64() != rows[i].ID {
+			t.Fatalf("value %d: want=%d got=%d", i, rows[i].ID, v.Int64())
+		}
+	}
+
+	tags := readAll(columns[1])
+	i := 0
+	for _, row := range rows {
+		if len(row.Tags) == 0 {
+			if v := tags[i]; !v.IsNull() || v.RepetitionLevel() != 0 || v.DefinitionLevel() != 0 {
+				t.Fatalf("value %d: expected an empty list: %v", i, v)
+			}
+			i++
+			continue
+		}
+		for j, tag := range row.Tags {
+			v := tags[i]
+			repetitionLevel := 1
+			if j == 0 {
+				repetitionLevel = 0
+			}
+			if v.String() != tag || v.DefinitionLevel() != 1 || v.RepetitionLevel() != repetitionLevel {
+				t.Fatalf("value %d: wrong value or levels: %v (r=%d d=%d)", i, v, v.RepetitionLevel(), v.DefinitionLevel())
+			}
+			i++
+		}
+	}
+	if i != len(tags) {
+		t.Fatalf("wrong number of values: want=%d got=%d", i, len(tags))
+	}
+}
+
+func TestColumnChunkEncodings(t *testing.T) {
+	type Row struct {
+		Color string `parquet:"color,dict"`
+		Value int64  `parquet:"value,delta"`
+		Name  string `parquet:"name"`
+	}
+	rows := []Row{
+		{Color: "red", Value: 1, Name: "one"},
+		{Color: "blue", Value: 2, Name: "two"},
+		{Color: "red", Value: 3, Name: "three"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodingsOf := func(chunk parquet.ColumnChunk) []string {
+		var names []string
+		for _, enc := range parquet.ColumnChunkEncodings(chunk) {
+			names = append(names, enc.String())
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	chunks := f.RowGroups()[0].ColumnChunks()
+	for i, want := range [][]string{
+		{"PLAIN", "RLE_DICTIONARY"},
+		{"DELTA_BINARY_PACKED"},
+		{"DELTA_LENGTH_BYTE_ARRAY"},
+	} {
+		got := encodingsOf(chunks[i])
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong encodings of column %d: want=%q got=%q", i, want, got)
+		}
+	}
+
+	merged := parquet.MultiRowGroup(f.RowGroups()[0], f.RowGroups()[0])
+	if got, want := encodingsOf(merged.ColumnChunks()[1]), encodingsOf(chunks[1]); !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong encodings of merged column chunks: want=%q got=%q", want, got)
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	buffer.Write(rows)
+	if encodings := parquet.ColumnChunkEncodings(buffer.ColumnChunks()[0]); encodings != nil {
+		t.Errorf("unexpected encodings of a buffer column chunk: %v", encodings)
+	}
+}
+
+func TestColumnChunkPageEncodingStats(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprintf("name-%04d", i)}
+	}
+
+	// The dictionary outgrows its maximum size after a few pages, the writer
+	// then falls back to the PLAIN encoding.
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256), parquet.MaxDictionarySize(1024)); err != nil {
+		t.Fatal(err)
+	}
+	f := openFile(t, buf.Bytes())
+	chunk := f.RowGroups()[0].ColumnChunks()[0]
+
+	stats := parquet.ColumnChunkPageEncodingStats(chunk)
+	counts := make(map[string]int)
+	for _, st := range stats {
+		counts[st.PageType.String()+"/"+st.Encoding.String()] += st.Count
+	}
+	if counts["DICTIONARY_PAGE/PLAIN"] != 1 || counts["DATA_PAGE_V2/RLE_DICTIONARY"] == 0 || counts["DATA_PAGE_V2/PLAIN"] == 0 {
+		t.Errorf("wrong page encoding stats: %v", counts)
+	}
+	if numPages := chunk.OffsetIndex().NumPages(); numPages != counts["DATA_PAGE_V2/RLE_DICTIONARY"]+counts["DATA_PAGE_V2/PLAIN"] {
+		t.Errorf("page encoding stats do not add up to the %d data pages: %v", numPages, counts)
+	}
+
+	merged := parquet.MultiRowGroup(f.RowGroups()[0], f.RowGroups()[0])
+	mergedStats := parquet.ColumnChunkPageEncodingStats(merged.ColumnChunks()[0])
+	if len(mergedStats) != len(stats) {
+		t.Fatalf("wrong page encoding stats of merged column chunks: %v", mergedStats)
+	}
+	for i := range stats {
+		if mergedStats[i].Count != 2*stats[i].Count {
+			t.Errorf("wrong page count of merged column chunks: want=%d got=%d", 2*stats[i].Count, mergedStats[i].Count)
+		}
+	}
+
+	buffer := parquet.NewGenericBuffer[Row]()
+	buffer.Write(rows)
+	if stats := parquet.ColumnChunkPageEncodingStats(buffer.ColumnChunks()[0]); stats != nil {
+		t.Errorf("unexpected page encoding stats of a buffer column chunk: %v", stats)
+	}
+}
+
+func TestColumnChunkSizeStatistics(t *testing.T) {
+	type Row struct {
+		Name string   `parquet:"name"`
+		Tags []string `parquet:"tags,optional"`
+	}
+	rows := []Row{
+		{Name: "a", Tags: []string{"x", "y"}},
+		{Name: "bc"},
+		{Name: "def", Tags: []string{"z"}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(2)); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	f := openFile(t, b)
+	for _, rowGroup := range f.RowGroups() {
+		for _, chunk := range rowGroup.ColumnChunks() {
+			if _, ok := parquet.ColumnChunkSizeStatistics(chunk); ok {
+				t.Error("unexpected size statistics in a file written without them")
+			}
+		}
+	}
+
+	// The writer does not produce size statistics, they are added to the
+	// footer of the file to simulate files written by other implementations.
+	metadata := *f.Metadata()
+	metadata.RowGroups = append([]format.RowGroup{}, metadata.RowGroups...)
+	for i := range metadata.RowGroups {
+		rowGroup := &metadata.RowGroups[i]
+		rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+		name := int64(3)
+		rowGroup.Columns[0].MetaData.SizeStatistics = format.SizeStatistics{
+			UnencodedByteArrayDataBytes: &name,
+		}
+		tags := int64(2)
+		rowGroup.Columns[1].MetaData.SizeStatistics = format.SizeStatistics{
+			UnencodedByteArrayDataBytes: &tags,
+			RepetitionLevelHistogram:    []int64{int64(2 - i), int64(1 - i)},
+			DefinitionLevelHistogram:    []int64{0, int64(1 - i), int64(i), 1},
+		}
+	}
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerSize := binary.LittleEndian.Uint32(b[len(b)-8:])
+	b = append([]byte{}, b[:len(b)-8-int(footerSize)]...)
+	b = append(b, footer...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(footer)))
+	b = append(b, "PAR1"...)
+
+	f = openFile(t, b)
+	stats, ok := parquet.ColumnChunkSizeStatistics(f.RowGroups()[0].ColumnChunks()[1])
+	if !ok {
+		t.Fatal("missing size statistics")
+	}
+	want := parquet.SizeStatistics{
+		UnencodedByteArrayDataBytes: 2,
+		RepetitionLevelHistogram:    []int64{2, 1},
+		DefinitionLevelHistogram:    []int64{0, 1, 0, 1},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("wrong size statistics:\nwant: %+v\ngot:  %+v", want, stats)
+	}
+	stats.DefinitionLevelHistogram[0] = 42
+	if stats, _ := parquet.ColumnChunkSizeStatistics(f.RowGroups()[0].ColumnChunks()[1]); stats.DefinitionLevelHistogram[0] != 0 {
+		t.Error("the histograms returned are not copies of the metadata")
+	}
+
+	stats, ok = parquet.ColumnChunkSizeStatistics(f.RowGroups()[0].ColumnChunks()[0])
+	if !ok || stats.UnencodedByteArrayDataBytes != 3 || stats.RepetitionLevelHistogram != nil || stats.DefinitionLevelHistogram != nil {
+		t.Errorf("wrong size statistics of the required column: ok=%t stats=%+v", ok, stats)
+	}
+
+	merged := parquet.MultiRowGroup(f.RowGroups()...)
+	stats, ok = parquet.ColumnChunkSizeStatistics(merged.ColumnChunks()[1])
+	if !ok {
+		t.Fatal("missing size statistics of merged column chunks")
+	}
+	want = parquet.SizeStatistics{
+		UnencodedByteArrayDataBytes: 4,
+		RepetitionLevelHistogram:    []int64{3, 1},
+		DefinitionLevelHistogram:    []int64{0, 1, 1, 2},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("wrong size statistics of merged column chunks:\nwant: %+v\ngot:  %+v", want, stats)
+	}
+}
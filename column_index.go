@@ -1,17 +1,27 @@
 package parquet
 
 import (
+	"math"
+
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding/plain"
 	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/unsafecast"
 )
 
+// ColumnIndex is the interface implemented by the column indexes of column
+// chunks, which record statistics about each page of the column chunk.
+//
+// Pages are identified by their index in the column chunk, from zero to
+// NumPages()-1; methods panic if they receive an index out of this range.
 type ColumnIndex interface {
 	// NumPages returns the number of paged in the column index.
 	NumPages() int
 
 	// Returns the number of null values in the page at the given index.
+	//
+	// The method returns zero if the column index does not record the number
+	// of null values of pages.
 	NullCount(int) int64
 
 	// Tells whether the page at the given index contains null values only.
@@ -19,6 +29,11 @@ type ColumnIndex interface {
 
 	// PageIndex return min/max bounds for the page at the given index in the
 	// column.
+	//
+	// The values are null if the page contains null values only, or if the
+	// column index does not record the bounds of the page. Note that writers
+	// may truncate the bounds of byte array columns, in which case the values
+	// are only a lower and upper bound of the values in the page.
 	MinValue(int) Value
 	MaxValue(int) Value
 
@@ -66,14 +81,14 @@ func (f *formatColumnIndex) MinValue(i int) Value {
 	if f.NullPage(i) {
 		return Value{}
 	}
-	return f.kind.Value(f.index.MinValues[i])
+	return columnIndexValue(f.kind, f.index.MinValues[i])
 }
 
 func (f *formatColumnIndex) MaxValue(i int) Value {
 	if f.NullPage(i) {
 		return Value{}
 	}
-	return f.kind.Value(f.index.MaxValues[i])
+	return columnIndexValue(f.kind, f.index.MaxValues[i])
 }
 
 func (f *formatColumnIndex) IsAscending() bool {
@@ -124,7 +139,18 @@ func (i fileColumnIndex) IsDescending() bool {
 }
 
 func (i *fileColumnIndex) makeValue(b []byte) Value {
-	return i.chunk.column.typ.Kind().Value(b)
+	return columnIndexValue(i.chunk.column.typ.Kind(), b)
+}
+
+// columnIndexValue decodes a min or max value of a column index, returning a
+// null value if the bytes do not hold a value of the given kind, for example
+// when the writer did not record the bounds of a page.
+func columnIndexValue(kind Kind, b []byte) Value {
+	v, err := parseValue(kind, b)
+	if err != nil {
+		return Value{}
+	}
+	return v
 }
 
 type emptyColumnIndex struct{}
@@ -297,6 +323,30 @@ func (i *baseColumnIndexer) columnIndex(minValues, maxValues [][]byte, minOrder,
 	}
 }
 
+// unorderedColumnIndexer is the column indexer of types which define no sort
+// order, the min and max values of pages are left empty and the boundary order
+// is always unordered.
+type unorderedColumnIndexer struct {
+	baseColumnIndexer
+}
+
+func newUnorderedColumnIndexer() *unorderedColumnIndexer {
+	return new(unorderedColumnIndexer)
+}
+
+func (i *unorderedColumnIndexer) Reset() {
+	i.reset()
+}
+
+func (i *unorderedColumnIndexer) IndexPage(numValues, numNulls int64, min, max Value) {
+	i.observe(numValues, numNulls)
+}
+
+func (i *unorderedColumnIndexer) ColumnIndex() format.ColumnIndex {
+	numPages := len(i.nullPages)
+	return i.columnIndex(make([][]byte, numPages), make([][]byte, numPages), 0, 0)
+}
+
 type booleanColumnIndexer struct {
 	baseColumnIndexer
 	minValues []bool
@@ -425,6 +475,9 @@ type floatColumnIndexer struct {
 	baseColumnIndexer
 	minValues []float32
 	maxValues []float32
+	// Set when a page only holds NaN values, in which case NaN is written as
+	// its min and max values and the boundary order is unordered.
+	hasNaN bool
 }
 
 func newFloatColumnIndexer() *floatColumnIndexer {
@@ -433,29 +486,45 @@ func newFloatColumnIndexer() *floatColumnIndexer {
 
 func (i *floatColumnIndexer) Reset() {
 	i.reset()
+	i.hasNaN = false
 	i.minValues = i.minValues[:0]
 	i.maxValues = i.maxValues[:0]
 }
 
 func (i *floatColumnIndexer) IndexPage(numValues, numNulls int64, min, max Value) {
 	i.observe(numValues, numNulls)
-	i.minValues = append(i.minValues, min.float())
-	i.maxValues = append(i.maxValues, max.float())
+	minValue, maxValue := min.float(), max.float()
+	if min.IsNull() && numValues > numNulls {
+		minValue, maxValue = float32(math.NaN()), float32(math.NaN())
+		i.hasNaN = true
+	}
+	i.minValues = append(i.minValues, minValue)
+	i.maxValues = append(i.maxValues, maxValue)
 }
 
 func (i *floatColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Float32ToBytes(i.minValues), 4),
 		splitFixedLenByteArrays(unsafecast.Float32ToBytes(i.maxValues), 4),
-		orderOfFloat32(i.minValues),
-		orderOfFloat32(i.maxValues),
+		i.order(i.minValues),
+		i.order(i.maxValues),
 	)
 }
 
+func (i *floatColumnIndexer) order(values []float32) int {
+	if i.hasNaN {
+		return 0
+	}
+	return orderOfFloat32(values)
+}
+
 type doubleColumnIndexer struct {
 	baseColumnIndexer
 	minValues []float64
 	maxValues []float64
+	// Set when a page only holds NaN values, in which case NaN is written as
+	// its min and max values and the boundary order is unordered.
+	hasNaN bool
 }
 
 func newDoubleColumnIndexer() *doubleColumnIndexer {
@@ -464,25 +533,38 @@ func newDoubleColumnIndexer() *doubleColumnIndexer {
 
 func (i *doubleColumnIndexer) Reset() {
 	i.reset()
+	i.hasNaN = false
 	i.minValues = i.minValues[:0]
 	i.maxValues = i.maxValues[:0]
 }
 
 func (i *doubleColumnIndexer) IndexPage(numValues, numNulls int64, min, max Value) {
 	i.observe(numValues, numNulls)
-	i.minValues = append(i.minValues, min.double())
-	i.maxValues = append(i.maxValues, max.double())
+	minValue, maxValue := min.double(), max.double()
+	if min.IsNull() && numValues > numNulls {
+		minValue, maxValue = math.NaN(), math.NaN()
+		i.hasNaN = true
+	}
+	i.minValues = append(i.minValues, minValue)
+	i.maxValues = append(i.maxValues, maxValue)
 }
 
 func (i *doubleColumnIndexer) ColumnIndex() format.ColumnIndex {
 	return i.columnIndex(
 		splitFixedLenByteArrays(unsafecast.Float64ToBytes(i.minValues), 8),
 		splitFixedLenByteArrays(unsafecast.Float64ToBytes(i.maxValues), 8),
-		orderOfFloat64(i.minValues),
-		orderOfFloat64(i.maxValues),
+		i.order(i.minValues),
+		i.order(i.maxValues),
 	)
 }
 
+func (i *doubleColumnIndexer) order(values []float64) int {
+	if i.hasNaN {
+		return 0
+	}
+	return orderOfFloat64(values)
+}
+
 type byteArrayColumnIndexer struct {
 	baseColumnIndexer
 	sizeLimit int
@@ -679,14 +761,26 @@ func truncateLargeMinByteArrayValue(value []byte, sizeLimit int) []byte {
 
 // truncateLargeMaxByteArrayValue truncates the given byte array to the given size limit.
 // If the given byte array is truncated, it is incremented by 1 in place.
+//
+// When the prefix of the value is made of 0xFF bytes it cannot be incremented,
+// the value is returned untruncated since any shorter value would be lower.
 func truncateLargeMaxByteArrayValue(value []byte, sizeLimit int) []byte {
-	if len(value) > sizeLimit {
+	if len(value) > sizeLimit && !isMaxByteArray(value[:sizeLimit]) {
 		value = value[:sizeLimit]
 		incrementByteArrayInplace(value)
 	}
 	return value
 }
 
+func isMaxByteArray(value []byte) bool {
+	for _, b := range value {
+		if b != 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
 // incrementByteArray increments the given byte array by 1.
 // Reference: https://github.com/apache/parquet-mr/blob/master/parquet-column/src/main/java/org/apache/parquet/internal/column/columnindex/BinaryTruncator.java#L124
 func incrementByteArrayInplace(value []byte) {
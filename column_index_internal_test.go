@@ -23,3 +23,32 @@ func TestIncrementByteArrayInplace(t *testing.T) {
 		}
 	}
 }
+
+func TestTruncateLargeByteArrayValues(t *testing.T) {
+	testCases := []struct {
+		value []byte
+		limit int
+		min   []byte
+		max   []byte
+	}{
+		{[]byte("abc"), 4, []byte("abc"), []byte("abc")},
+		{[]byte("abcd"), 4, []byte("abcd"), []byte("abcd")},
+		{[]byte("abcdef"), 4, []byte("abcd"), []byte("abce")},
+		{[]byte("ab\xffzz"), 3, []byte("ab\xff"), []byte("ac\x00")},
+		{[]byte("\xff\xff\xffa"), 2, []byte("\xff\xff"), []byte("\xff\xff\xffa")},
+	}
+
+	for _, test := range testCases {
+		min := truncateLargeMinByteArrayValue(copyBytes(test.value), test.limit)
+		max := truncateLargeMaxByteArrayValue(copyBytes(test.value), test.limit)
+		if !bytes.Equal(min, test.min) {
+			t.Errorf("truncateLargeMinByteArrayValue(%q, %d) = %q, want %q", test.value, test.limit, min, test.min)
+		}
+		if !bytes.Equal(max, test.max) {
+			t.Errorf("truncateLargeMaxByteArrayValue(%q, %d) = %q, want %q", test.value, test.limit, max, test.max)
+		}
+		if bytes.Compare(min, test.value) > 0 || bytes.Compare(max, test.value) < 0 {
+			t.Errorf("truncated bounds [%q,%q] do not contain %q", min, max, test.value)
+		}
+	}
+}
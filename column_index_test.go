@@ -1,9 +1,11 @@
 package parquet_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 func TestBinaryColumnIndexMinMax(t *testing.T) {
@@ -32,6 +34,12 @@ func TestBinaryColumnIndexMinMax(t *testing.T) {
 			[]byte{1, 2, 3, 5, 6, 7}, false, // should be no hit since it definitely exceeds page max
 			[]byte{2, 3, 4, 5, 0, 0}, false, // should be no hit since it definitely exceeds page max
 		},
+		{parquet.ByteArray, parquet.ByteArrayType,
+			[]byte{0, 0, 0, 0, 0, 0}, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 1}, 4,
+			[]byte{0xFF, 0xFF, 0xFF, 0xFF}, true,
+			[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 1}, true, // the max value cannot be truncated up
+			[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 2}, false,
+		},
 	}
 	for _, testCase := range testCases {
 		kind := testCase[0].(parquet.Kind)
@@ -58,3 +66,89 @@ func TestBinaryColumnIndexMinMax(t *testing.T) {
 		}
 	}
 }
+
+func TestColumnIndexPageStatistics(t *testing.T) {
+	type Row struct {
+		Value *int32 `parquet:"value,optional"`
+	}
+	value := func(v int32) *int32 { return &v }
+
+	pages := [][]Row{
+		{{value(3)}, {nil}, {value(1)}},
+		{{nil}, {nil}},
+		{{value(5)}, {value(2)}},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf)
+	for _, rows := range pages {
+		// Each row group holds a single page.
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		nullCount int64
+		nullPage  bool
+		min, max  parquet.Value
+	}{
+		{nullCount: 1, min: parquet.Int32Value(1), max: parquet.Int32Value(3)},
+		{nullCount: 2, nullPage: true, min: parquet.Value{}, max: parquet.Value{}},
+		{nullCount: 0, min: parquet.Int32Value(2), max: parquet.Int32Value(5)},
+	}
+
+	for i, test := range tests {
+		index := f.RowGroups()[i].ColumnChunks()[0].ColumnIndex()
+		if index.NumPages() != 1 {
+			t.Fatalf("row group %d: wrong number of pages: %d", i, index.NumPages())
+		}
+		if n := index.NullCount(0); n != test.nullCount {
+			t.Errorf("row group %d: wrong null count: want=%d got=%d", i, test.nullCount, n)
+		}
+		if nullPage := index.NullPage(0); nullPage != test.nullPage {
+			t.Errorf("row group %d: wrong null page: want=%t got=%t", i, test.nullPage, nullPage)
+		}
+		if v := index.MinValue(0); !parquet.Equal(v, test.min) {
+			t.Errorf("row group %d: wrong min value: want=%v got=%v", i, test.min, v)
+		}
+		if v := index.MaxValue(0); !parquet.Equal(v, test.max) {
+			t.Errorf("row group %d: wrong max value: want=%v got=%v", i, test.max, v)
+		}
+	}
+}
+
+func TestColumnIndexMissingBounds(t *testing.T) {
+	index := parquet.NewColumnIndex(parquet.Int64, &format.ColumnIndex{
+		NullPages: []bool{false, false},
+		MinValues: [][]byte{{}, {1, 0, 0, 0, 0, 0, 0, 0}},
+		MaxValues: [][]byte{{}, {2, 0, 0, 0, 0, 0, 0, 0}},
+	})
+
+	if v := index.MinValue(0); !v.IsNull() {
+		t.Errorf("page without bounds has a min value: %v", v)
+	}
+	if v := index.MaxValue(0); !v.IsNull() {
+		t.Errorf("page without bounds has a max value: %v", v)
+	}
+	if v := index.MinValue(1); v.Int64() != 1 {
+		t.Errorf("wrong min value: %v", v)
+	}
+	if v := index.MaxValue(1); v.Int64() != 2 {
+		t.Errorf("wrong max value: %v", v)
+	}
+	if n := index.NullCount(1); n != 0 {
+		t.Errorf("column index without null counts reports %d nulls", n)
+	}
+}
@@ -91,6 +91,70 @@ func (u *unsupported) error() error {
 	return fmt.Errorf("unsupported compression codec: %s", u.codec)
 }
 
+// CompressionLevel associates a compression codec with the level that it must
+// compress data at.
+//
+// The range of valid levels depends on the codec:
+//
+//	GZIP    | -2 (huffman only) to 9, -1 selects the default level
+//	BROTLI  | 0 to 11
+//	ZSTD    | 1 to 22
+//	LZ4_RAW | 0 (fast) to 9
+//
+// Other codecs do not support compression levels.
+type CompressionLevel struct {
+	Codec compress.Codec
+	Level int
+}
+
+// NewCodec returns a copy of the codec configured to compress data at the
+// level of c, or an error if the codec does not support compression levels or
+// the level is out of range.
+func (c CompressionLevel) NewCodec() (compress.Codec, error) {
+	outOfRange := func(min, max int) error {
+		return fmt.Errorf("%s compression level out of range: %d not in [%d, %d]", c.Codec, c.Level, min, max)
+	}
+	switch c.Codec.(type) {
+	case *gzip.Codec:
+		if c.Level < gzip.HuffmanOnly || c.Level > gzip.BestCompression {
+			return nil, outOfRange(gzip.HuffmanOnly, gzip.BestCompression)
+		}
+		return &gzip.Codec{Level: c.Level}, nil
+	case *brotli.Codec:
+		if c.Level < 0 || c.Level > 11 {
+			return nil, outOfRange(0, 11)
+		}
+		return &brotli.Codec{Quality: c.Level, LGWin: brotli.DefaultLGWin}, nil
+	case *zstd.Codec:
+		if c.Level < 1 || c.Level > 22 {
+			return nil, outOfRange(1, 22)
+		}
+		return &zstd.Codec{Level: zstd.LevelFromZstd(c.Level)}, nil
+	case *lz4.Codec:
+		if c.Level < 0 || c.Level > 9 {
+			return nil, outOfRange(0, 9)
+		}
+		return &lz4.Codec{Level: lz4Levels[c.Level]}, nil
+	case nil:
+		return nil, fmt.Errorf("missing compression codec")
+	default:
+		return nil, fmt.Errorf("%s compression codec does not support compression levels", c.Codec)
+	}
+}
+
+var lz4Levels = [...]lz4.Level{
+	lz4.Fast,
+	lz4.Level1,
+	lz4.Level2,
+	lz4.Level3,
+	lz4.Level4,
+	lz4.Level5,
+	lz4.Level6,
+	lz4.Level7,
+	lz4.Level8,
+	lz4.Level9,
+}
+
 func isCompressed(c compress.Codec) bool {
 	return c != nil && c.CompressionCodec() != format.Uncompressed
 }
@@ -33,6 +33,10 @@ const (
 	DefaultLevel = SpeedDefault
 )
 
+// LevelFromZstd returns the encoder level which most closely matches the given
+// zstd compression level, between 1 and 22.
+func LevelFromZstd(level int) Level { return zstd.EncoderLevelFromZstd(level) }
+
 type Codec struct {
 	Level Level
 
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"math"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
 )
 
 // ReadMode is an enum that is used to configure the way that a File reads pages.
@@ -19,16 +21,20 @@ const (
 )
 
 const (
-	DefaultColumnIndexSizeLimit = 16
-	DefaultColumnBufferCapacity = 16 * 1024
-	DefaultPageBufferSize       = 256 * 1024
-	DefaultWriteBufferSize      = 32 * 1024
-	DefaultDataPageVersion      = 2
-	DefaultDataPageStatistics   = false
-	DefaultSkipPageIndex        = false
-	DefaultSkipBloomFilters     = false
-	DefaultMaxRowsPerRowGroup   = math.MaxInt64
-	DefaultReadMode             = ReadModeSync
+	DefaultColumnIndexSizeLimit   = 16
+	DefaultColumnBufferCapacity   = 16 * 1024
+	DefaultPageBufferSize         = 256 * 1024
+	DefaultWriteBufferSize        = 32 * 1024
+	DefaultDataPageVersion        = 2
+	DefaultDataPageStatistics     = false
+	DefaultPageChecksums          = true
+	DefaultSkipPageIndex          = false
+	DefaultSkipBloomFilters       = false
+	DefaultSkipPageChecksums      = false
+	DefaultMaxRowsPerRowGroup     = math.MaxInt64
+	DefaultColumnWriteConcurrency = 1
+	DefaultMaxDictionarySize      = math.MaxInt64
+	DefaultReadMode               = ReadModeSync
 )
 
 const (
@@ -90,22 +96,27 @@ func formatCreatedBy(application, version, build string) string {
 //		ReadMode:         ReadModeAsync,
 //	})
 type FileConfig struct {
-	SkipPageIndex    bool
-	SkipBloomFilters bool
-	ReadBufferSize   int
-	ReadMode         ReadMode
-	Schema           *Schema
+	SkipPageIndex     bool
+	SkipBloomFilters  bool
+	SkipPageChecksums bool
+	ReadBufferSize    int
+	ReadMode          ReadMode
+	Schema            *Schema
+	DecryptionKeys    map[string][]byte
+	MemoryPool        MemoryPool
+	OnWarning         func(error)
 }
 
 // DefaultFileConfig returns a new FileConfig value initialized with the
 // default file configuration.
 func DefaultFileConfig() *FileConfig {
 	return &FileConfig{
-		SkipPageIndex:    DefaultSkipPageIndex,
-		SkipBloomFilters: DefaultSkipBloomFilters,
-		ReadBufferSize:   defaultReadBufferSize,
-		ReadMode:         DefaultReadMode,
-		Schema:           nil,
+		SkipPageIndex:     DefaultSkipPageIndex,
+		SkipBloomFilters:  DefaultSkipBloomFilters,
+		SkipPageChecksums: DefaultSkipPageChecksums,
+		ReadBufferSize:    defaultReadBufferSize,
+		ReadMode:          DefaultReadMode,
+		Schema:            nil,
 	}
 }
 
@@ -130,17 +141,32 @@ func (c *FileConfig) Apply(options ...FileOption) {
 // ConfigureFile applies configuration options from c to config.
 func (c *FileConfig) ConfigureFile(config *FileConfig) {
 	*config = FileConfig{
-		SkipPageIndex:    c.SkipPageIndex,
-		SkipBloomFilters: c.SkipBloomFilters,
-		ReadBufferSize:   coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
-		ReadMode:         ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
-		Schema:           coalesceSchema(c.Schema, config.Schema),
+		SkipPageIndex:     c.SkipPageIndex,
+		SkipBloomFilters:  c.SkipBloomFilters,
+		SkipPageChecksums: c.SkipPageChecksums,
+		ReadBufferSize:    coalesceInt(c.ReadBufferSize, config.ReadBufferSize),
+		ReadMode:          ReadMode(coalesceInt(int(c.ReadMode), int(config.ReadMode))),
+		Schema:            coalesceSchema(c.Schema, config.Schema),
+		DecryptionKeys:    coalesceKeys(c.DecryptionKeys, config.DecryptionKeys),
+		MemoryPool:        coalesceMemoryPool(c.MemoryPool, config.MemoryPool),
+		OnWarning:         coalesceOnWarning(c.OnWarning, config.OnWarning),
+	}
+}
+
+func (c *FileConfig) warn(err error) {
+	if c.OnWarning != nil {
+		c.OnWarning(err)
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *FileConfig) Validate() error {
-	return nil
+	const baseName = "parquet.(*FileConfig)."
+	errs := make([]error, 0, len(c.DecryptionKeys))
+	for path, key := range c.DecryptionKeys {
+		errs = append(errs, validateKeySize(baseName+"DecryptionKeys["+strconv.Quote(path)+"]", key))
+	}
+	return errorInvalidConfiguration(errs...)
 }
 
 // The ReaderConfig type carries configuration options for parquet readers.
@@ -152,7 +178,14 @@ func (c *FileConfig) Validate() error {
 //		// ...
 //	})
 type ReaderConfig struct {
-	Schema *Schema
+	Schema                 *Schema
+	Columns                []string
+	Predicates             []ColumnPredicate
+	StrictTypes            bool
+	CaseInsensitiveColumns bool
+	DisallowMissingColumns bool
+	OrderedMaps            bool
+	RowFactory             interface{}
 }
 
 // DefaultReaderConfig returns a new ReaderConfig value initialized with the
@@ -182,7 +215,14 @@ func (c *ReaderConfig) Apply(options ...ReaderOption) {
 // ConfigureReader applies configuration options from c to config.
 func (c *ReaderConfig) ConfigureReader(config *ReaderConfig) {
 	*config = ReaderConfig{
-		Schema: coalesceSchema(c.Schema, config.Schema),
+		Schema:                 coalesceSchema(c.Schema, config.Schema),
+		Columns:                coalesceStrings(c.Columns, config.Columns),
+		Predicates:             coalescePredicates(c.Predicates, config.Predicates),
+		StrictTypes:            c.StrictTypes,
+		CaseInsensitiveColumns: c.CaseInsensitiveColumns,
+		DisallowMissingColumns: c.DisallowMissingColumns,
+		OrderedMaps:            c.OrderedMaps,
+		RowFactory:             coalesceRowFactory(c.RowFactory, config.RowFactory),
 	}
 }
 
@@ -200,33 +240,43 @@ func (c *ReaderConfig) Validate() error {
 //		CreatedBy: "my test program",
 //	})
 type WriterConfig struct {
-	CreatedBy            string
-	ColumnPageBuffers    BufferPool
-	ColumnIndexSizeLimit int
-	PageBufferSize       int
-	WriteBufferSize      int
-	DataPageVersion      int
-	DataPageStatistics   bool
-	MaxRowsPerRowGroup   int64
-	KeyValueMetadata     map[string]string
-	Schema               *Schema
-	BloomFilters         []BloomFilterColumn
-	Compression          compress.Codec
-	Sorting              SortingConfig
+	CreatedBy              string
+	ColumnPageBuffers      BufferPool
+	ColumnIndexSizeLimit   int
+	PageBufferSize         int
+	WriteBufferSize        int
+	DataPageVersion        int
+	DataPageStatistics     bool
+	DisablePageChecksums   bool
+	MaxRowsPerRowGroup     int64
+	MaxRowGroupBytes       int64
+	AssertSorted           bool
+	ColumnWriteConcurrency int
+	MaxDictionarySize      int64
+	KeyValueMetadata       map[string]string
+	Schema                 *Schema
+	BloomFilters           []BloomFilterColumn
+	Compression            compress.Codec
+	Compressions           map[string]CompressionLevel
+	Encodings              map[string]encoding.Encoding
+	Sorting                SortingConfig
 }
 
 // DefaultWriterConfig returns a new WriterConfig value initialized with the
 // default writer configuration.
 func DefaultWriterConfig() *WriterConfig {
 	return &WriterConfig{
-		CreatedBy:            defaultCreatedBy(),
-		ColumnPageBuffers:    &defaultColumnBufferPool,
-		ColumnIndexSizeLimit: DefaultColumnIndexSizeLimit,
-		PageBufferSize:       DefaultPageBufferSize,
-		WriteBufferSize:      DefaultWriteBufferSize,
-		DataPageVersion:      DefaultDataPageVersion,
-		DataPageStatistics:   DefaultDataPageStatistics,
-		MaxRowsPerRowGroup:   DefaultMaxRowsPerRowGroup,
+		CreatedBy:              defaultCreatedBy(),
+		ColumnPageBuffers:      &defaultColumnBufferPool,
+		ColumnIndexSizeLimit:   DefaultColumnIndexSizeLimit,
+		PageBufferSize:         DefaultPageBufferSize,
+		WriteBufferSize:        DefaultWriteBufferSize,
+		DataPageVersion:        DefaultDataPageVersion,
+		DataPageStatistics:     DefaultDataPageStatistics,
+		DisablePageChecksums:   !DefaultPageChecksums,
+		MaxRowsPerRowGroup:     DefaultMaxRowsPerRowGroup,
+		ColumnWriteConcurrency: DefaultColumnWriteConcurrency,
+		MaxDictionarySize:      DefaultMaxDictionarySize,
 		Sorting: SortingConfig{
 			SortingBuffers: &defaultSortingBufferPool,
 		},
@@ -263,33 +313,67 @@ func (c *WriterConfig) ConfigureWriter(config *WriterConfig) {
 		}
 	}
 
+	compressions := config.Compressions
+	if len(c.Compressions) > 0 {
+		if compressions == nil {
+			compressions = make(map[string]CompressionLevel, len(c.Compressions))
+		}
+		for k, v := range c.Compressions {
+			compressions[k] = v
+		}
+	}
+
+	encodings := config.Encodings
+	if len(c.Encodings) > 0 {
+		if encodings == nil {
+			encodings = make(map[string]encoding.Encoding, len(c.Encodings))
+		}
+		for k, v := range c.Encodings {
+			encodings[k] = v
+		}
+	}
+
 	*config = WriterConfig{
-		CreatedBy:            coalesceString(c.CreatedBy, config.CreatedBy),
-		ColumnPageBuffers:    coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
-		ColumnIndexSizeLimit: coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
-		PageBufferSize:       coalesceInt(c.PageBufferSize, config.PageBufferSize),
-		WriteBufferSize:      coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
-		DataPageVersion:      coalesceInt(c.DataPageVersion, config.DataPageVersion),
-		DataPageStatistics:   config.DataPageStatistics,
-		MaxRowsPerRowGroup:   config.MaxRowsPerRowGroup,
-		KeyValueMetadata:     keyValueMetadata,
-		Schema:               coalesceSchema(c.Schema, config.Schema),
-		BloomFilters:         coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
-		Compression:          coalesceCompression(c.Compression, config.Compression),
-		Sorting:              coalesceSortingConfig(c.Sorting, config.Sorting),
+		CreatedBy:              coalesceString(c.CreatedBy, config.CreatedBy),
+		ColumnPageBuffers:      coalesceBufferPool(c.ColumnPageBuffers, config.ColumnPageBuffers),
+		ColumnIndexSizeLimit:   coalesceInt(c.ColumnIndexSizeLimit, config.ColumnIndexSizeLimit),
+		PageBufferSize:         coalesceInt(c.PageBufferSize, config.PageBufferSize),
+		WriteBufferSize:        coalesceInt(c.WriteBufferSize, config.WriteBufferSize),
+		DataPageVersion:        coalesceInt(c.DataPageVersion, config.DataPageVersion),
+		DataPageStatistics:     c.DataPageStatistics || config.DataPageStatistics,
+		DisablePageChecksums:   c.DisablePageChecksums || config.DisablePageChecksums,
+		MaxRowsPerRowGroup:     coalesceInt64(c.MaxRowsPerRowGroup, config.MaxRowsPerRowGroup),
+		MaxRowGroupBytes:       coalesceInt64(c.MaxRowGroupBytes, config.MaxRowGroupBytes),
+		AssertSorted:           c.AssertSorted,
+		ColumnWriteConcurrency: coalesceInt(c.ColumnWriteConcurrency, config.ColumnWriteConcurrency),
+		MaxDictionarySize:      coalesceInt64(c.MaxDictionarySize, config.MaxDictionarySize),
+		KeyValueMetadata:       keyValueMetadata,
+		Schema:                 coalesceSchema(c.Schema, config.Schema),
+		BloomFilters:           coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
+		Compression:            coalesceCompression(c.Compression, config.Compression),
+		Compressions:           compressions,
+		Encodings:              encodings,
+		Sorting:                coalesceSortingConfig(c.Sorting, config.Sorting),
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *WriterConfig) Validate() error {
 	const baseName = "parquet.(*WriterConfig)."
-	return errorInvalidConfiguration(
+	errs := []error{
 		validateNotNil(baseName+"ColumnPageBuffers", c.ColumnPageBuffers),
 		validatePositiveInt(baseName+"ColumnIndexSizeLimit", c.ColumnIndexSizeLimit),
 		validatePositiveInt(baseName+"PageBufferSize", c.PageBufferSize),
 		validateOneOfInt(baseName+"DataPageVersion", c.DataPageVersion, 1, 2),
+		validateNonNegativeInt64(baseName+"MaxRowGroupBytes", c.MaxRowGroupBytes),
+		validatePositiveInt(baseName+"ColumnWriteConcurrency", c.ColumnWriteConcurrency),
+		validatePositiveInt64(baseName+"MaxDictionarySize", c.MaxDictionarySize),
 		c.Sorting.Validate(),
-	)
+	}
+	for column, compression := range c.Compressions {
+		errs = append(errs, validateCompressionLevel(baseName+"Compressions["+strconv.Quote(column)+"]", compression))
+	}
+	return errorInvalidConfiguration(errs...)
 }
 
 // The RowGroupConfig type carries configuration options for parquet row groups.
@@ -454,6 +538,25 @@ func SkipBloomFilters(skip bool) FileOption {
 	return fileOption(func(config *FileConfig) { config.SkipBloomFilters = skip })
 }
 
+// SkipPageChecksums is a file configuration option which disables verifying
+// the CRC32 checksums of pages read from parquet files, when set to true. This
+// is useful as an optimization when programs trust the storage medium to
+// detect corruptions.
+//
+// Defaults to false.
+func SkipPageChecksums(skip bool) FileOption {
+	return fileOption(func(config *FileConfig) { config.SkipPageChecksums = skip })
+}
+
+// OnWarning is a file configuration option which sets a function called
+// with the errors that do not prevent reading a parquet file, for example
+// when the page index section of the file is malformed and is ignored.
+//
+// By default, warnings are silently discarded.
+func OnWarning(callback func(error)) FileOption {
+	return fileOption(func(config *FileConfig) { config.OnWarning = callback })
+}
+
 // FileReadMode is a file configuration option which controls the way pages
 // are read. Currently the only two options are ReadModeAsync and ReadModeSync
 // which control whether or not pages are loaded asynchronously. It can be
@@ -476,6 +579,19 @@ func ReadBufferSize(size int) FileOption {
 	return fileOption(func(config *FileConfig) { config.ReadBufferSize = size })
 }
 
+// FileMemoryPool is a file configuration option which sets the pool that the
+// memory buffers used to decompress and decode pages are allocated from.
+//
+// Programs reading many small files concurrently may use this option to share
+// a pool across files and control the retention of decoding buffers. Since
+// readers decode pages of the files they read from, the option applies to
+// readers created on files opened with it.
+//
+// Defaults to nil, which uses a pool shared by all files of the program.
+func FileMemoryPool(pool MemoryPool) FileOption {
+	return fileOption(func(config *FileConfig) { config.MemoryPool = pool })
+}
+
 // FileSchema is used to pass a known schema in while opening a Parquet file.
 // This optimization is only useful if your application is currently opening
 // an extremely large number of parquet files with the same, known schema.
@@ -485,6 +601,137 @@ func FileSchema(schema *Schema) FileOption {
 	return fileOption(func(config *FileConfig) { config.Schema = schema })
 }
 
+// DecryptionKeys is a file configuration option which provides the keys used
+// to decrypt parquet files written with modular encryption.
+//
+// The keys are indexed by the dot-separated path of the columns they decrypt,
+// the key associated with the empty string is the footer key. Files encrypted
+// with a uniform key only need the footer key to be set. Keys must be 16, 24,
+// or 32 bytes long to select AES-128, AES-192, or AES-256.
+//
+// Only the footer and column metadata are decrypted when opening a file;
+// pages of encrypted columns cannot be read yet and report an error wrapping
+// ErrEncryptedColumn.
+//
+// Defaults to nil.
+func DecryptionKeys(keys map[string][]byte) FileOption {
+	return fileOption(func(config *FileConfig) { config.DecryptionKeys = keys })
+}
+
+// Columns is a reader configuration option restricting the columns read to the
+// given list of column paths. Nested columns are selected by joining the names
+// of their parent groups with dots, for example "address.city"; selecting a
+// group selects all the columns nested in it. Columns nested in maps cannot be
+// read separately, selecting one of them selects the whole map.
+//
+// Pages of the columns which were not selected are never read. When reading
+// rows into maps or values of type any, the rows only contain the selected
+// columns; fields of Go structs mapping to other columns are left to their
+// zero-value.
+//
+// Readers panic if one of the selected columns does not exist in the schema.
+func Columns(columns ...string) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.Columns = columns })
+}
+
+// Where is a reader configuration option which skips the pages of a column
+// that cannot contain values matching the predicate, based on the min and max
+// values of pages recorded in the column index. The rows of skipped pages are
+// skipped in all the columns read.
+//
+// The option may be given multiple times, rows are read only if the pages
+// holding them may match all the predicates. Pages of columns which have no
+// column index, or have no min and max values, are never skipped.
+//
+// Note that pages which may match the predicate are read in full, programs
+// must still test the rows returned by the reader to get the exact set of
+// matching rows.
+//
+// Readers panic if the column does not exist in the schema of the file.
+func Where(column string, predicate Predicate) ReaderOption {
+	return readerOption(func(config *ReaderConfig) {
+		config.Predicates = append(config.Predicates, ColumnPredicate{Column: column, Predicate: predicate})
+	})
+}
+
+// StrictTypes is a reader configuration option which, when set to true,
+// rejects reading columns into Go values of a different type than the physical
+// type of the column, for example an INT32 column into a field of type int64.
+//
+// By default, readers convert values to the types of the schema they read rows
+// into when the conversion does not lose information; values which cannot be
+// represented in the target type, such as an INT64 value out of the range of an
+// int32, cause the reads to return errors wrapping ErrInvalidConversion.
+//
+// Defaults to false.
+func StrictTypes(strict bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.StrictTypes = strict })
+}
+
+// CaseInsensitiveColumns is a reader configuration option which, when set to
+// true, matches the columns of files to the columns of the schema that rows
+// are read into by ignoring the case of their names, when no column has the
+// exact same name. Files where a column name matches multiple columns, or
+// where multiple columns match the same name, cannot be read and cause the
+// reader constructors to panic with an error wrapping ErrSchemaMismatch.
+//
+// This is useful to read files written by producers which do not use the
+// same capitalization of column names, for example "UserID" and "userid".
+//
+// Defaults to false.
+func CaseInsensitiveColumns(caseInsensitive bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.CaseInsensitiveColumns = caseInsensitive })
+}
+
+// AllowMissingColumns is a reader configuration option which, when set to
+// true, lets readers read files which lack some of the columns of the schema
+// that rows are read into. The values of missing columns are null, which leaves
+// the Go fields that they map to at their zero values. This is the expected
+// behavior when reading files written with an older version of a schema which
+// was later extended with new columns.
+//
+// When set to false, files missing columns of the schema cannot be read and
+// cause the reader constructors to panic with an error wrapping
+// ErrSchemaMismatch. Columns of files which do not exist in the schema are
+// always ignored.
+//
+// The option sets the DisallowMissingColumns field of ReaderConfig to the
+// opposite value, so the zero value of ReaderConfig allows missing columns.
+//
+// Defaults to true.
+func AllowMissingColumns(allow bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.DisallowMissingColumns = !allow })
+}
+
+// OrderedMaps is a reader configuration option which, when set to true, makes
+// readers represent the groups of rows read into Go values of type any as
+// OrderedMap values instead of map[string]any. The entries of ordered maps are
+// in the order that the columns are declared in the schema, whereas the
+// iteration order of Go maps is random.
+//
+// The option only applies to rows read into values of type any, for example
+// with GenericReader[any] or ReadFile[any].
+//
+// Defaults to false.
+func OrderedMaps(enabled bool) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.OrderedMaps = enabled })
+}
+
+// RowFactory is a reader configuration option which makes the Read method of
+// GenericReader[T] obtain the values that rows are decoded into by calling
+// factory, for example to get them from a pool of values holding large buffers
+// that the program wants to recycle.
+//
+// The memory of slices and maps held by the values returned by factory is
+// reused to decode the rows when possible, like with ReadInto; the values must
+// not be shared with other parts of the program while the reader uses them.
+//
+// The type parameter T must be the type of rows read by the reader, the reader
+// constructors panic otherwise.
+func RowFactory[T any](factory func() T) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.RowFactory = factory })
+}
+
 // PageBufferSize configures the size of column page buffers on parquet writers.
 //
 // Note that the page buffer size refers to the in-memory buffers where pages
@@ -524,21 +771,106 @@ func MaxRowsPerRowGroup(numRows int64) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.MaxRowsPerRowGroup = numRows })
 }
 
+// MaxRowGroupBytes configures the target size in bytes of the row groups that
+// a writer produces. The writer flushes a row group when its size reaches the
+// target, row groups always contain at least one row so a single row larger
+// than the target is written to its own row group.
+//
+// The size of a row group is estimated as the compressed size of its pages
+// already written, plus the uncompressed size of the values and dictionaries
+// buffered by its columns. Since rows are written in batches, row groups may
+// exceed the target by the size of a few rows.
+//
+// The option can be combined with MaxRowsPerRowGroup, row groups are flushed
+// when either of the limits is reached. Row groups passed to WriteRowGroup are
+// written row by row when the option is set, so they are split as needed.
+//
+// Defaults to zero, which does not limit the size of row groups.
+func MaxRowGroupBytes(size int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.MaxRowGroupBytes = size })
+}
+
+// AssertSorted configures writers to verify that rows are written in the order
+// of the sorting columns declared with SortingWriterConfig, when set to true.
+//
+// Writing rows which are not sorted produces files with misleading sorting
+// metadata; with this option, the writer returns an error wrapping
+// ErrRowsNotSorted at the first row ordered before the previous one, naming
+// the index of the row in the file. The rows preceding it are written.
+//
+// The verification has a cost since rows are compared as they are written,
+// and row groups passed to WriteRowGroup are written row by row. The option
+// has no effect when no sorting columns are declared.
+//
+// Defaults to false.
+func AssertSorted(assert bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.AssertSorted = assert })
+}
+
+// ColumnWriteConcurrency configures the number of columns that a writer
+// encodes and compresses in parallel when flushing pages.
+//
+// The pages of each column are produced by a pool of n goroutines, then written
+// to the output in column order; the content of the parquet file does not
+// depend on the concurrency.
+//
+// Defaults to 1, which encodes the columns sequentially.
+func ColumnWriteConcurrency(n int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.ColumnWriteConcurrency = n })
+}
+
+// MaxDictionarySize configures the maximum size in bytes of the dictionary of
+// columns using dictionary encoding.
+//
+// When the values of a column chunk grow the dictionary past this size, the
+// data pages written for the rest of the column chunk use the PLAIN encoding
+// instead, and the dictionary stops growing. The dictionary page still holds
+// the values of the pages written before the limit was reached. Each column
+// chunk starts with an empty dictionary, so the following row groups use the
+// dictionary encoding again.
+//
+// Defaults to math.MaxInt64, which means that dictionaries are not limited.
+func MaxDictionarySize(size int64) WriterOption {
+	if size <= 0 {
+		size = DefaultMaxDictionarySize
+	}
+	return writerOption(func(config *WriterConfig) { config.MaxDictionarySize = size })
+}
+
 // CreatedBy creates a configuration option which sets the name of the
 // application that created a parquet file.
 //
-// The option formats the "CreatedBy" file metadata according to the convention
-// described by the parquet spec:
+// When called with only the application, the "CreatedBy" file metadata is set
+// to its value verbatim. When the version and build of the application are also
+// passed, the option formats the metadata like the default value, which is
+// derived from the convention described by the parquet spec:
 //
-//	"<application> version <version> (build <build>)"
+//	"<application> version <version>(build <build>)"
 //
 // By default, the option is set to the parquet-go module name, version, and
 // build hash.
-func CreatedBy(application, version, build string) WriterOption {
-	createdBy := formatCreatedBy(application, version, build)
+func CreatedBy(application string, versionAndBuild ...string) WriterOption {
+	createdBy := application
+	switch len(versionAndBuild) {
+	case 0:
+	case 2:
+		createdBy = formatCreatedBy(application, versionAndBuild[0], versionAndBuild[1])
+	default:
+		panic("parquet.CreatedBy: expected the application, optionally followed by its version and build")
+	}
 	return writerOption(func(config *WriterConfig) { config.CreatedBy = createdBy })
 }
 
+// CreatedByVersion creates a configuration option which sets the name, version,
+// and build of the application that created a parquet file. It is equivalent
+// to calling CreatedBy with the three arguments, the "CreatedBy" file metadata
+// is formatted as:
+//
+//	"<application> version <version>(build <build>)"
+func CreatedByVersion(application, version, build string) WriterOption {
+	return CreatedBy(application, version, build)
+}
+
 // ColumnPageBuffers creates a configuration option to customize the buffer pool
 // used when constructing row groups. This can be used to provide on-disk buffers
 // as swap space to ensure that the parquet file creation will no be bottlenecked
@@ -552,14 +884,45 @@ func ColumnPageBuffers(buffers BufferPool) WriterOption {
 // ColumnIndexSizeLimit creates a configuration option to customize the size
 // limit of page boundaries recorded in column indexes.
 //
+// The limit applies to the min and max values of BYTE_ARRAY and
+// FIXED_LEN_BYTE_ARRAY columns, which are truncated to at most sizeLimit
+// bytes in the column indexes. Min values are truncated down to their prefix,
+// and max values are truncated up by incrementing the last byte of their prefix,
+// so the truncated boundaries still contain all the values of the pages. Max
+// values which cannot be truncated up are recorded in full. Column statistics
+// are not truncated.
+//
+// Programs which need to record the exact page boundaries may set the limit to
+// the maximum length of the values, or to math.MaxInt to disable truncation.
+//
 // Defaults to 16.
 func ColumnIndexSizeLimit(sizeLimit int) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.ColumnIndexSizeLimit = sizeLimit })
 }
 
+// ColumnIndexTruncateLength creates a configuration option which truncates the
+// min and max values of BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY columns recorded in
+// column indexes to at most length bytes, as described in ColumnIndexSizeLimit.
+// A length of zero, or less, disables the truncation.
+//
+// The option does not change the default: writers which are not configured
+// with this option or ColumnIndexSizeLimit truncate the values to
+// DefaultColumnIndexSizeLimit bytes, like previous versions of the package did.
+func ColumnIndexTruncateLength(length int) WriterOption {
+	if length <= 0 {
+		length = math.MaxInt
+	}
+	return ColumnIndexSizeLimit(length)
+}
+
 // DataPageVersion creates a configuration option which configures the version of
 // data pages used when creating a parquet file.
 //
+// Version 2 data pages store the repetition and definition levels outside of
+// the compressed section of the pages, while version 1 data pages compress
+// them with the values; version 1 may be needed for older parquet readers.
+// The only valid values are 1 and 2.
+//
 // Defaults to version 2.
 func DataPageVersion(version int) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.DataPageVersion = version })
@@ -575,6 +938,18 @@ func DataPageStatistics(enabled bool) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.DataPageStatistics = enabled })
 }
 
+// PageChecksums creates a configuration option which defines whether the CRC32
+// checksums of pages are computed and stored in the page headers, allowing
+// readers to detect corrupted pages.
+//
+// The option sets the DisablePageChecksums field of WriterConfig to the
+// opposite value, so the zero value of WriterConfig writes checksums.
+//
+// Defaults to true.
+func PageChecksums(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.DisablePageChecksums = !enabled })
+}
+
 // KeyValueMetadata creates a configuration option which adds key/value metadata
 // to add to the metadata of parquet files.
 //
@@ -598,6 +973,23 @@ func KeyValueMetadata(key, value string) WriterOption {
 	})
 }
 
+// KeyValueMetadataMap creates a configuration option which adds all the
+// key/value pairs of metadata to the metadata of parquet files.
+//
+// Like KeyValueMetadata, this option is additive and may be combined with other
+// key/value metadata options; when keys are repeated, the last value is
+// retained.
+func KeyValueMetadataMap(metadata map[string]string) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		if config.KeyValueMetadata == nil {
+			config.KeyValueMetadata = make(map[string]string, len(metadata))
+		}
+		for key, value := range metadata {
+			config.KeyValueMetadata[key] = value
+		}
+	})
+}
+
 // BloomFilters creates a configuration option which defines the bloom filters
 // that parquet writers should generate.
 //
@@ -616,6 +1008,48 @@ func Compression(codec compress.Codec) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.Compression = codec })
 }
 
+// ColumnCompression creates a configuration option which sets the compression
+// codec and level used by a writer for the column at the given path, overriding
+// the compression declared in the schema. The codec is one of the parquet
+// compression codecs supporting levels, for example:
+//
+//	parquet.ColumnCompression("payload", &parquet.Zstd, 19)
+//
+// This option is additive, it may be used multiple times to set compression of
+// more than one column.
+//
+// The configuration is invalid if the codec does not support compression levels
+// or the level is out of range, see CompressionLevel for the valid ranges.
+func ColumnCompression(column string, codec compress.Codec, level int) WriterOption {
+	compression := CompressionLevel{Codec: codec, Level: level}
+	return writerOption(func(config *WriterConfig) {
+		if config.Compressions == nil {
+			config.Compressions = map[string]CompressionLevel{column: compression}
+		} else {
+			config.Compressions[column] = compression
+		}
+	})
+}
+
+// ColumnEncoding creates a configuration option which sets the encoding used by
+// a writer for the column at the given path, overriding the encoding declared
+// in the schema. The path of nested columns is made of the names of their parent
+// groups joined by dots, for example "address.city".
+//
+// This option is additive, it may be used multiple times to set encodings of
+// more than one column.
+//
+// Writers panic if the encoding cannot be applied to the type of the column.
+func ColumnEncoding(column string, enc encoding.Encoding) WriterOption {
+	return writerOption(func(config *WriterConfig) {
+		if config.Encodings == nil {
+			config.Encodings = map[string]encoding.Encoding{column: enc}
+		} else {
+			config.Encodings[column] = enc
+		}
+	})
+}
+
 // SortingWriterConfig is a writer option which applies configuration specific
 // to sorting writers.
 func SortingWriterConfig(options ...SortingOption) WriterOption {
@@ -713,6 +1147,13 @@ func coalesceString(s1, s2 string) string {
 	return s2
 }
 
+func coalesceRowFactory(f1, f2 interface{}) interface{} {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
 func coalesceBytes(b1, b2 []byte) []byte {
 	if b1 != nil {
 		return b1
@@ -720,6 +1161,27 @@ func coalesceBytes(b1, b2 []byte) []byte {
 	return b2
 }
 
+func coalesceStrings(s1, s2 []string) []string {
+	if s1 != nil {
+		return s1
+	}
+	return s2
+}
+
+func coalescePredicates(p1, p2 []ColumnPredicate) []ColumnPredicate {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
+func coalesceKeys(k1, k2 map[string][]byte) map[string][]byte {
+	if k1 != nil {
+		return k1
+	}
+	return k2
+}
+
 func coalesceBufferPool(p1, p2 BufferPool) BufferPool {
 	if p1 != nil {
 		return p1
@@ -727,6 +1189,20 @@ func coalesceBufferPool(p1, p2 BufferPool) BufferPool {
 	return p2
 }
 
+func coalesceMemoryPool(p1, p2 MemoryPool) MemoryPool {
+	if p1 != nil {
+		return p1
+	}
+	return p2
+}
+
+func coalesceOnWarning(f1, f2 func(error)) func(error) {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
 func coalesceSchema(s1, s2 *Schema) *Schema {
 	if s1 != nil {
 		return s1
@@ -777,6 +1253,13 @@ func validatePositiveInt64(optionName string, optionValue int64) error {
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateNonNegativeInt64(optionName string, optionValue int64) error {
+	if optionValue >= 0 {
+		return nil
+	}
+	return errorInvalidOptionValue(optionName, optionValue)
+}
+
 func validateOneOfInt(optionName string, optionValue int, supportedValues ...int) error {
 	for _, value := range supportedValues {
 		if value == optionValue {
@@ -786,6 +1269,21 @@ func validateOneOfInt(optionName string, optionValue int, supportedValues ...int
 	return errorInvalidOptionValue(optionName, optionValue)
 }
 
+func validateCompressionLevel(optionName string, compression CompressionLevel) error {
+	if _, err := compression.NewCodec(); err != nil {
+		return fmt.Errorf("invalid option value: %s: %w", optionName, err)
+	}
+	return nil
+}
+
+func validateKeySize(optionName string, key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	}
+	return fmt.Errorf("invalid option value: %s: key length must be 16, 24, or 32 bytes but got %d", optionName, len(key))
+}
+
 func validateNotNil(optionName string, optionValue interface{}) error {
 	if optionValue != nil {
 		return nil
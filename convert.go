@@ -79,11 +79,15 @@ type conversionFunc func([]Value) error
 
 func convertToSelf(column []Value) error { return nil }
 
+// convertToType returns a conversion function which converts values of
+// sourceType into values of targetType, the receiver of Type.ConvertValue being
+// the type that values are converted to.
+//
 //go:noinline
 func convertToType(targetType, sourceType Type) conversionFunc {
 	return func(column []Value) error {
 		for i, v := range column {
-			v, err := sourceType.ConvertValue(v, targetType)
+			v, err := targetType.ConvertValue(v, sourceType)
 			if err != nil {
 				return err
 			}
@@ -95,6 +99,30 @@ func convertToType(targetType, sourceType Type) conversionFunc {
 	}
 }
 
+// convertToTypeLossless is like convertToType but returns an error wrapping
+// ErrInvalidConversion if a value cannot be converted without loss, which is
+// detected by converting the values back to the source type.
+func convertToTypeLossless(targetType, sourceType Type) conversionFunc {
+	return func(column []Value) error {
+		for i, v := range column {
+			c, err := targetType.ConvertValue(v, sourceType)
+			if err != nil {
+				return err
+			}
+			if !v.IsNull() {
+				r, err := sourceType.ConvertValue(c, targetType)
+				if err != nil || sourceType.Compare(r, v) != 0 {
+					return fmt.Errorf("cannot convert %s value %v to %s without loss: %w", sourceType, v, targetType, ErrInvalidConversion)
+				}
+			}
+			column[i].ptr = c.ptr
+			column[i].u64 = c.u64
+			column[i].kind = c.kind
+		}
+		return nil
+	}
+}
+
 //go:noinline
 func convertToValue(value Value) conversionFunc {
 	return func(column []Value) error {
@@ -241,9 +269,48 @@ func (id identity) Schema() *Schema                 { return id.schema }
 // The returned function is intended to be used to append the converted source
 // row to the destination buffer.
 func Convert(to, from Node) (conv Conversion, err error) {
+	return convert(to, from, convertAnyTypes)
+}
+
+// conversionMode defines how conversions handle columns of different types in
+// the source and target schemas.
+type conversionMode int
+
+const (
+	// Values are converted with the ConvertValue method of the target type,
+	// which may lose information, for example when narrowing integers.
+	convertAnyTypes conversionMode = iota
+	// Values of numeric columns are converted only if the conversion does not
+	// lose information; converting values which cannot be represented in the
+	// target type fails. Other columns are converted like with convertAnyTypes.
+	convertLosslessValues
+	// Columns must have the same physical types, only the logical types may
+	// differ; values are converted like with convertLosslessValues.
+	convertSameKinds
+)
+
+// readerConversionMode returns the conversion mode used by readers configured
+// with the StrictTypes option set to strictTypes.
+func readerConversionMode(strictTypes bool) conversionMode {
+	if strictTypes {
+		return convertSameKinds
+	}
+	return convertLosslessValues
+}
+
+func isNumericKind(kind Kind) bool {
+	switch kind {
+	case Int32, Int64, Float, Double:
+		return true
+	default:
+		return false
+	}
+}
+
+func convert(to, from Node, mode conversionMode) (Conversion, error) {
 	schema, _ := to.(*Schema)
 	if schema == nil {
-		schema = NewSchema("", to)
+		schema = newSchema("", to)
 	}
 
 	if nodesAreEqual(to, from) {
@@ -263,42 +330,30 @@ func Convert(to, from Node) (conv Conversion, err error) {
 			targetType := targetColumn.node.Type()
 			sourceType := sourceColumn.node.Type()
 			if !typesAreEqual(targetType, sourceType) {
-				conversions = append(conversions,
-					convertToType(targetType, sourceType),
-				)
-			}
-
-			repetitionLevels := make([]byte, len(path)+1)
-			definitionLevels := make([]byte, len(path)+1)
-			targetRepetitionLevel := byte(0)
-			targetDefinitionLevel := byte(0)
-			sourceRepetitionLevel := byte(0)
-			sourceDefinitionLevel := byte(0)
-			targetNode := to
-			sourceNode := from
-
-			for j := 0; j < len(path); j++ {
-				targetNode = fieldByName(targetNode, path[j])
-				sourceNode = fieldByName(sourceNode, path[j])
-
-				targetRepetitionLevel, targetDefinitionLevel = applyFieldRepetitionType(
-					fieldRepetitionTypeOf(targetNode),
-					targetRepetitionLevel,
-					targetDefinitionLevel,
-				)
-				sourceRepetitionLevel, sourceDefinitionLevel = applyFieldRepetitionType(
-					fieldRepetitionTypeOf(sourceNode),
-					sourceRepetitionLevel,
-					sourceDefinitionLevel,
-				)
-
-				repetitionLevels[sourceRepetitionLevel] = targetRepetitionLevel
-				definitionLevels[sourceDefinitionLevel] = targetDefinitionLevel
+				switch mode {
+				case convertAnyTypes:
+					conversions = append(conversions,
+						convertToType(targetType, sourceType),
+					)
+				case convertSameKinds:
+					if targetType.Kind() != sourceType.Kind() || targetType.Length() != sourceType.Length() {
+						return nil, fmt.Errorf("cannot convert column %q of type %s to %s: %w", columnPath(path), sourceType, targetType, ErrInvalidConversion)
+					}
+					fallthrough
+				default:
+					if isNumericKind(targetType.Kind()) && isNumericKind(sourceType.Kind()) && targetType.Kind() != sourceType.Kind() {
+						conversions = append(conversions,
+							convertToTypeLossless(targetType, sourceType),
+						)
+					} else {
+						conversions = append(conversions,
+							convertToType(targetType, sourceType),
+						)
+					}
+				}
 			}
 
-			repetitionLevels = repetitionLevels[:sourceRepetitionLevel+1]
-			definitionLevels = definitionLevels[:sourceDefinitionLevel+1]
-
+			repetitionLevels, definitionLevels := levelMappingOf(to, from, path, path)
 			if !isDirectLevelMapping(repetitionLevels) || !isDirectLevelMapping(definitionLevels) {
 				conversions = append(conversions,
 					convertToLevels(repetitionLevels, definitionLevels),
@@ -313,6 +368,18 @@ func Convert(to, from Node) (conv Conversion, err error) {
 				conversions = append(conversions,
 					convertToZero(targetKind),
 				)
+				// The closest column is a leaf of the deepest parent group that
+				// the source and target columns have in common; its levels are
+				// mapped to the levels of the parent in the target schema so the
+				// null values are placed within the parent group when it exists.
+				parentPath := commonGroupPath(from, path)
+				closestPath := parentPath.append(sourceColumn.path[len(sourceColumn.path)-1])
+				repetitionLevels, definitionLevels := levelMappingOf(to, from, parentPath, closestPath)
+				if !isDirectLevelMapping(repetitionLevels) || !isDirectLevelMapping(definitionLevels) {
+					conversions = append(conversions,
+						convertToLevels(repetitionLevels, definitionLevels),
+					)
+				}
 			} else {
 				conversions = append(conversions,
 					convertToValue(ZeroValue(targetKind)),
@@ -334,6 +401,53 @@ func Convert(to, from Node) (conv Conversion, err error) {
 	return c, nil
 }
 
+// commonGroupPath returns the longest prefix of path which is a group of node.
+func commonGroupPath(node Node, path columnPath) columnPath {
+	for i, name := range path {
+		if node = fieldByName(node, name); node == nil || node.Leaf() {
+			return path[:i]
+		}
+	}
+	return path
+}
+
+// levelMappingOf returns tables mapping the repetition and definition levels of
+// values of the column at sourcePath in the from schema to the levels of the
+// node at targetPath in the to schema. The target path must be equal to the
+// source path or to one of its prefixes.
+func levelMappingOf(to, from Node, targetPath, sourcePath columnPath) (repetitionLevels, definitionLevels []byte) {
+	repetitionLevels = make([]byte, len(sourcePath)+1)
+	definitionLevels = make([]byte, len(sourcePath)+1)
+	targetRepetitionLevel := byte(0)
+	targetDefinitionLevel := byte(0)
+	sourceRepetitionLevel := byte(0)
+	sourceDefinitionLevel := byte(0)
+	targetNode := to
+	sourceNode := from
+
+	for j := 0; j < len(sourcePath); j++ {
+		if j < len(targetPath) {
+			targetNode = fieldByName(targetNode, targetPath[j])
+			targetRepetitionLevel, targetDefinitionLevel = applyFieldRepetitionType(
+				fieldRepetitionTypeOf(targetNode),
+				targetRepetitionLevel,
+				targetDefinitionLevel,
+			)
+		}
+		sourceNode = fieldByName(sourceNode, sourcePath[j])
+		sourceRepetitionLevel, sourceDefinitionLevel = applyFieldRepetitionType(
+			fieldRepetitionTypeOf(sourceNode),
+			sourceRepetitionLevel,
+			sourceDefinitionLevel,
+		)
+
+		repetitionLevels[sourceRepetitionLevel] = targetRepetitionLevel
+		definitionLevels[sourceDefinitionLevel] = targetDefinitionLevel
+	}
+
+	return repetitionLevels[:sourceRepetitionLevel+1], definitionLevels[:sourceDefinitionLevel+1]
+}
+
 func isDirectLevelMapping(levels []byte) bool {
 	for i, level := range levels {
 		if level != byte(i) {
@@ -747,6 +861,31 @@ func convertInt96ToString(v Value) (Value, error) {
 	return v.convertToByteArray([]byte(v.String())), nil
 }
 
+// convertInt96ToTimestamp converts a legacy INT96 timestamp, as written by
+// Impala and Hive, to a TIMESTAMP value of the given unit.
+func convertInt96ToTimestamp(v Value, unit format.TimeUnit) (Value, error) {
+	days, nanos := int96TimestampOf(v.int96())
+	scale := timeUnitDuration(unit).Nanoseconds()
+	return v.convertToInt64(days*(nanosecondsPerDay/scale) + nanos/scale), nil
+}
+
+// julianDayOfUnixEpoch is the Julian day number of 1970-01-01.
+const julianDayOfUnixEpoch = 2440588
+
+// int96TimestampOf returns the number of days since the unix epoch and the
+// nanoseconds within the day of an INT96 timestamp. The first 8 bytes of INT96
+// timestamps hold the nanoseconds and the last 4 bytes the Julian day number.
+func int96TimestampOf(i deprecated.Int96) (days, nanos int64) {
+	days = int64(i[2]) - julianDayOfUnixEpoch
+	nanos = int64(uint64(i[1])<<32 | uint64(i[0]))
+	return days, nanos
+}
+
+func int96ToTime(i deprecated.Int96) time.Time {
+	days, nanos := int96TimestampOf(i)
+	return time.Unix(days*(nanosecondsPerDay/1e9), nanos).UTC()
+}
+
 func convertFloatToBoolean(v Value) (Value, error) {
 	return v.convertToBoolean(v.float() != 0), nil
 }
@@ -971,6 +1110,15 @@ func convertStringToTimeMicros(v Value, tz *time.Location) (Value, error) {
 	return v.convertToInt64(microseconds), nil
 }
 
+func convertStringToTimestamp(v Value, u format.TimeUnit, tz *time.Location) (Value, error) {
+	t, err := time.ParseInLocation(time.RFC3339Nano, v.string(), tz)
+	if err != nil {
+		return v, conversionError(v, "STRING", "TIMESTAMP", err)
+	}
+	d := timeUnitDuration(u)
+	return v.convertToInt64(int64(t.Sub(unixEpoch) / d)), nil
+}
+
 func convertDateToTimestamp(v Value, u format.TimeUnit, tz *time.Location) (Value, error) {
 	t := unixEpoch.AddDate(0, 0, int(v.int32()))
 	d := timeUnitDuration(u)
@@ -1055,3 +1203,27 @@ func invalidConversion(value Value, from, to string) error {
 func conversionError(value Value, from, to string, err error) error {
 	return fmt.Errorf("%s to %s: %q: %s: %w", from, to, value.string(), err, ErrInvalidConversion)
 }
+
+func convertFloat16ToFloat(v Value) (Value, error) {
+	return v.convertToFloat(float16ValueOf(v)), nil
+}
+
+func convertFloat16ToDouble(v Value) (Value, error) {
+	return v.convertToDouble(float64(float16ValueOf(v))), nil
+}
+
+func convertFloatToFloat16(v Value) (Value, error) {
+	return v.convertToFixedLenByteArray(float16Bytes(float32ToFloat16(v.float()))), nil
+}
+
+func convertDoubleToFloat16(v Value) (Value, error) {
+	return v.convertToFixedLenByteArray(float16Bytes(float32ToFloat16(float32(v.double())))), nil
+}
+
+func convertStringToFloat16(v Value) (Value, error) {
+	f, err := strconv.ParseFloat(v.string(), 32)
+	if err != nil {
+		return v, conversionError(v, "STRING", "FLOAT16", err)
+	}
+	return v.convertToFixedLenByteArray(float16Bytes(float32ToFloat16(float32(f)))), nil
+}
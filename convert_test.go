@@ -1,6 +1,9 @@
 package parquet_test
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"reflect"
 	"testing"
 	"time"
@@ -141,6 +144,26 @@ var conversionTests = [...]struct {
 		}{ID: 2, Details: nil},
 	},
 
+	{
+		scenario: "extra column in optional group",
+		from: struct {
+			Details struct{ FirstName string }
+		}{
+			Details: struct{ FirstName string }{FirstName: "Luke"},
+		},
+		to: struct {
+			Details *struct {
+				Age       *int32
+				FirstName string
+			}
+		}{
+			Details: &struct {
+				Age       *int32
+				FirstName string
+			}{FirstName: "Luke"},
+		},
+	},
+
 	{
 		scenario: "extra repeated column",
 		from:     struct{ ID uint64 }{ID: 1},
@@ -1234,3 +1257,144 @@ type convertMissingColumn struct {
 func (m convertMissingColumn) Column(_ int) int                        { return -1 }
 func (m convertMissingColumn) Schema() *parquet.Schema                 { return m.schema }
 func (m convertMissingColumn) Convert(rows []parquet.Row) (int, error) { return len(rows), nil }
+
+func TestReadConvertedTypes(t *testing.T) {
+	type narrow struct {
+		I int32   `parquet:"i"`
+		F float32 `parquet:"f"`
+	}
+	type wide struct {
+		I int64   `parquet:"i"`
+		F float64 `parquet:"f"`
+	}
+
+	t.Run("widening", func(t *testing.T) {
+		f := openConvertTestFile(t, []narrow{{I: -1, F: 1.5}, {I: 1 << 30, F: -0.25}})
+		r := parquet.NewGenericReader[wide](f)
+		defer r.Close()
+
+		rows := make([]wide, 2)
+		n, err := r.Read(rows)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		want := []wide{{I: -1, F: 1.5}, {I: 1 << 30, F: -0.25}}
+		if !reflect.DeepEqual(rows[:n], want) {
+			t.Fatalf("rows mismatch:\nwant = %+v\ngot  = %+v", want, rows[:n])
+		}
+	})
+
+	t.Run("lossless narrowing", func(t *testing.T) {
+		f := openConvertTestFile(t, []wide{{I: 42, F: 0.5}})
+		r := parquet.NewGenericReader[narrow](f)
+		defer r.Close()
+
+		rows := make([]narrow, 1)
+		n, err := r.Read(rows)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatal(err)
+		}
+		if want := []narrow{{I: 42, F: 0.5}}; !reflect.DeepEqual(rows[:n], want) {
+			t.Fatalf("rows mismatch:\nwant = %+v\ngot  = %+v", want, rows[:n])
+		}
+	})
+
+	for _, test := range []struct {
+		scenario string
+		row      wide
+	}{
+		{scenario: "integer overflow", row: wide{I: 1 << 40}},
+		{scenario: "float precision", row: wide{F: 1.1}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			f := openConvertTestFile(t, []wide{test.row})
+			r := parquet.NewGenericReader[narrow](f)
+			defer r.Close()
+
+			_, err := r.Read(make([]narrow, 1))
+			if !errors.Is(err, parquet.ErrInvalidConversion) {
+				t.Fatalf("expected an error wrapping ErrInvalidConversion, got %v", err)
+			}
+		})
+	}
+
+	t.Run("strict types", func(t *testing.T) {
+		f := openConvertTestFile(t, []narrow{{I: 1, F: 1}})
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the reader to panic on mismatching column types")
+			}
+		}()
+		parquet.NewGenericReader[wide](f, parquet.StrictTypes(true))
+	})
+}
+
+func openConvertTestFile[T any](t *testing.T, rows []T) *parquet.File {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestReadConvertedLogicalTypes(t *testing.T) {
+	type millis struct {
+		T int64 `parquet:"t,timestamp(millisecond)"`
+	}
+	type micros struct {
+		T int64 `parquet:"t,timestamp(microsecond)"`
+	}
+	type integer struct {
+		V int64 `parquet:"v"`
+	}
+	type text struct {
+		V string `parquet:"v"`
+	}
+	type date struct {
+		D int32 `parquet:"d,date"`
+	}
+	type timestamp struct {
+		D int64 `parquet:"d,timestamp(millisecond)"`
+	}
+
+	t.Run("timestamp millis to micros", func(t *testing.T) {
+		testReadConvertedRows(t, []millis{{T: 1500}}, []micros{{T: 1500000}})
+	})
+
+	t.Run("timestamp micros to millis", func(t *testing.T) {
+		testReadConvertedRows(t, []micros{{T: 1500000}}, []millis{{T: 1500}})
+	})
+
+	t.Run("int64 to string", func(t *testing.T) {
+		testReadConvertedRows(t, []integer{{V: -42}}, []text{{V: "-42"}})
+	})
+
+	t.Run("string to int64", func(t *testing.T) {
+		testReadConvertedRows(t, []text{{V: "123"}}, []integer{{V: 123}})
+	})
+
+	t.Run("date to timestamp", func(t *testing.T) {
+		testReadConvertedRows(t, []date{{D: 2}}, []timestamp{{D: 2 * 24 * 3600 * 1000}})
+	})
+}
+
+func testReadConvertedRows[From, To any](t *testing.T, rows []From, want []To) {
+	t.Helper()
+	f := openConvertTestFile(t, rows)
+	r := parquet.NewGenericReader[To](f)
+	defer r.Close()
+
+	got := make([]To, len(want))
+	n, err := r.Read(got)
+	if err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got[:n], want) {
+		t.Fatalf("rows mismatch:\nwant = %+v\ngot  = %+v", want, got[:n])
+	}
+}
@@ -0,0 +1,236 @@
+package parquet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CSVOption is an interface implemented by options configuring the conversion
+// of CSV data by ConvertCSV.
+//
+// CSV options are also writer options so they can be passed to ConvertCSV
+// alongside the options of the parquet writer; they have no effect on writers.
+type CSVOption interface {
+	WriterOption
+	ConfigureCSV(*CSVConfig)
+}
+
+// The CSVConfig type carries configuration options for CSV conversions.
+type CSVConfig struct {
+	// The field delimiter, defaults to ','.
+	Delimiter rune
+	// Whether the first record of the CSV data is a header holding the names
+	// of columns. When nil, the first record is considered to be a header if
+	// all its fields are names of columns of the schema.
+	Header *bool
+}
+
+// ConfigureWriter satisfies the WriterOption interface, it has no effect.
+func (c *CSVConfig) ConfigureWriter(*WriterConfig) {}
+
+// ConfigureCSV applies configuration options from c to config.
+func (c *CSVConfig) ConfigureCSV(config *CSVConfig) {
+	if c.Delimiter != 0 {
+		config.Delimiter = c.Delimiter
+	}
+	if c.Header != nil {
+		config.Header = c.Header
+	}
+}
+
+type csvOption func(*CSVConfig)
+
+func (opt csvOption) ConfigureWriter(*WriterConfig)  {}
+func (opt csvOption) ConfigureCSV(config *CSVConfig) { opt(config) }
+
+// CSVDelimiter creates a configuration option which sets the field delimiter
+// of CSV data converted by ConvertCSV.
+func CSVDelimiter(delimiter rune) CSVOption {
+	return csvOption(func(config *CSVConfig) { config.Delimiter = delimiter })
+}
+
+// CSVHeader creates a configuration option which declares whether the first
+// record of CSV data converted by ConvertCSV is a header holding column names.
+//
+// Without this option, the header is detected by comparing the fields of the
+// first record with the names of columns.
+func CSVHeader(header bool) CSVOption {
+	return csvOption(func(config *CSVConfig) { config.Header = &header })
+}
+
+// csvBatchSize is the number of rows buffered by ConvertCSV between writes.
+const csvBatchSize = 1024
+
+// ConvertCSV reads CSV records from src and writes them as rows of a parquet
+// file to dst.
+//
+// The schema must be made of top-level leaf columns that are not repeated.
+// Fields of the CSV records are converted to the types of columns: for example
+// integers are parsed in base 10, booleans with strconv.ParseBool, dates with
+// the "2006-01-02" layout, and timestamps with the RFC 3339 layout. Empty
+// fields of optional columns are written as null values.
+//
+// When the CSV data has a header, fields are matched to columns by name, and
+// columns missing from the header are null. Otherwise, the fields of each
+// record are in the order of the columns of the schema.
+//
+// Errors converting fields report the line number and the name of the column.
+//
+// The options may be CSVOption values or writer options, which configure the
+// parquet writer.
+func ConvertCSV(dst io.Writer, src io.Reader, schema *Schema, options ...WriterOption) error {
+	config := &CSVConfig{Delimiter: ','}
+	writerOptions := make([]WriterOption, 0, len(options)+1)
+	writerOptions = append(writerOptions, schema)
+	for _, opt := range options {
+		if csvOpt, ok := opt.(CSVOption); ok {
+			csvOpt.ConfigureCSV(config)
+		} else {
+			writerOptions = append(writerOptions, opt)
+		}
+	}
+
+	columns := schema.Fields()
+	for _, column := range columns {
+		if !column.Leaf() || column.Repeated() {
+			return fmt.Errorf("cannot convert CSV data to parquet schema %s: column %q is not a flat column", schema.Name(), column.Name())
+		}
+	}
+
+	r := csv.NewReader(src)
+	r.Comma = config.Delimiter
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	// mapping[i] is the index of the CSV field holding the value of column i,
+	// or -1 if the column is missing from the CSV data.
+	mapping := make([]int, len(columns))
+	for i := range mapping {
+		mapping[i] = i
+	}
+	numFields := len(columns)
+
+	record, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		if err == nil {
+			w := NewWriter(dst, writerOptions...)
+			err = w.Close()
+		}
+		return err
+	}
+
+	header := config.Header != nil && *config.Header
+	if config.Header == nil {
+		header = isCSVHeader(record, columns)
+	}
+	if header {
+		numFields = len(record)
+		for i := range mapping {
+			mapping[i] = -1
+		}
+		for i, name := range record {
+			j := fieldIndexOf(columns, name)
+			if j < 0 {
+				line, _ := r.FieldPos(i)
+				return fmt.Errorf("line %d: CSV header has unknown column %q", line, name)
+			}
+			mapping[j] = i
+		}
+		for i, column := range columns {
+			if mapping[i] < 0 && !column.Optional() {
+				return fmt.Errorf("CSV header is missing required column %q", column.Name())
+			}
+		}
+	}
+
+	w := NewWriter(dst, writerOptions...)
+	rows := make([]Row, 0, csvBatchSize)
+	values := make([]Value, 0, csvBatchSize*len(columns))
+	stringType := String().Type()
+
+	flush := func() error {
+		_, err := w.WriteRows(rows)
+		rows, values = rows[:0], values[:0]
+		return err
+	}
+
+	for {
+		if header {
+			header = false
+		} else {
+			if len(record) != numFields {
+				line, _ := r.FieldPos(0)
+				return fmt.Errorf("line %d: wrong number of CSV fields: want %d but got %d", line, numFields, len(record))
+			}
+
+			offset := len(values)
+			for i, column := range columns {
+				var value Value
+				field := ""
+				if mapping[i] >= 0 {
+					field = record[mapping[i]]
+				}
+				switch {
+				case field == "" && column.Optional():
+					value = NullValue().Level(0, 0, i)
+				default:
+					typ := column.Type()
+					v, err := typ.ConvertValue(ByteArrayValue([]byte(field)), stringType)
+					if err != nil {
+						line, _ := r.FieldPos(mapping[i])
+						return fmt.Errorf("line %d: column %q: %w", line, column.Name(), err)
+					}
+					definitionLevel := 0
+					if column.Optional() {
+						definitionLevel = 1
+					}
+					value = v.Level(0, definitionLevel, i)
+				}
+				values = append(values, value)
+			}
+			rows = append(rows, values[offset:len(values):len(values)])
+
+			if len(rows) == cap(rows) {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+
+		record, err = r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func isCSVHeader(record []string, columns []Field) bool {
+	for _, name := range record {
+		if fieldIndexOf(columns, name) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func fieldIndexOf(fields []Field, name string) int {
+	for i, f := range fields {
+		if f.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type csvRow struct {
+	ID      int64     `parquet:"id"`
+	Name    string    `parquet:"name"`
+	Score   *float64  `parquet:"score,optional"`
+	Active  bool      `parquet:"active"`
+	Created time.Time `parquet:"created,timestamp(millisecond)"`
+}
+
+func convertCSV(t *testing.T, input string, options ...parquet.WriterOption) ([]csvRow, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := parquet.ConvertCSV(buf, strings.NewReader(input), parquet.SchemaOf(csvRow{}), options...); err != nil {
+		return nil, err
+	}
+	return parquet.Read[csvRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
+
+func TestConvertCSV(t *testing.T) {
+	score := 4.5
+	created := time.Date(2023, time.June, 1, 12, 30, 0, 0, time.UTC)
+	want := []csvRow{
+		{ID: 1, Name: "Luke", Score: &score, Active: true, Created: created},
+		{ID: 2, Name: "Leia", Active: false, Created: created.Add(time.Hour)},
+	}
+
+	tests := []struct {
+		scenario string
+		input    string
+		options  []parquet.WriterOption
+	}{
+		{
+			scenario: "header",
+			input: "id,name,score,active,created\n" +
+				"1,Luke,4.5,true,2023-06-01T12:30:00Z\n" +
+				"2,Leia,,false,2023-06-01T13:30:00Z\n",
+		},
+		{
+			scenario: "reordered header",
+			input: "name,created,id,active,score\n" +
+				"Luke,2023-06-01T12:30:00Z,1,true,4.5\n" +
+				"Leia,2023-06-01T13:30:00Z,2,false,\n",
+		},
+		{
+			scenario: "missing optional column",
+			input: "id,name,active,created\n" +
+				"1,Luke,true,2023-06-01T12:30:00Z\n" +
+				"2,Leia,false,2023-06-01T13:30:00Z\n",
+		},
+		{
+			scenario: "no header",
+			input: "1,Luke,4.5,true,2023-06-01T12:30:00Z\n" +
+				"2,Leia,,false,2023-06-01T13:30:00Z\n",
+		},
+		{
+			scenario: "delimiter",
+			input: "id;name;score;active;created\n" +
+				"1;Luke;4.5;true;2023-06-01T12:30:00Z\n" +
+				"2;Leia;;false;2023-06-01T13:30:00Z\n",
+			options: []parquet.WriterOption{parquet.CSVDelimiter(';'), parquet.Compression(&parquet.Zstd)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			rows, err := convertCSV(t, test.input, test.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect := want
+			if test.scenario == "missing optional column" {
+				expect = []csvRow{want[0], want[1]}
+				expect[0].Score = nil
+			}
+			if !reflect.DeepEqual(rows, expect) {
+				t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", expect, rows)
+			}
+		})
+	}
+}
+
+func TestConvertCSVErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		input    string
+		options  []parquet.WriterOption
+		errors   []string
+	}{
+		{
+			scenario: "invalid integer",
+			input:    "id,name,score,active,created\n1,Luke,4.5,true,2023-06-01T12:30:00Z\nabc,Leia,,false,2023-06-01T13:30:00Z\n",
+			errors:   []string{"line 3", `column "id"`},
+		},
+		{
+			scenario: "invalid timestamp",
+			input:    "id,name,score,active,created\n1,Luke,4.5,true,yesterday\n",
+			errors:   []string{"line 2", `column "created"`},
+		},
+		{
+			scenario: "missing required column",
+			input:    "id,name,score,created\n",
+			options:  []parquet.WriterOption{parquet.CSVHeader(true)},
+			errors:   []string{`"active"`},
+		},
+		{
+			scenario: "wrong number of fields",
+			input:    "id,name,score,active,created\n1,Luke\n",
+			errors:   []string{"line 2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := convertCSV(t, test.input, test.options...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, s := range test.errors {
+				if !strings.Contains(err.Error(), s) {
+					t.Errorf("error %q does not contain %q", err, s)
+				}
+			}
+			if test.scenario == "invalid integer" && !errors.Is(err, parquet.ErrInvalidConversion) {
+				t.Errorf("error is not a conversion error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package parquet
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+// LogicalTypeHandler is the interface implemented by custom logical types,
+// which programs register with RegisterLogicalType to control how Go values
+// are represented in parquet columns.
+//
+// Values of custom logical types are stored as byte arrays, the handlers are
+// responsible for converting the Go values to and from their byte array
+// representation.
+type LogicalTypeHandler interface {
+	// Returns the parquet type of columns holding values of the custom logical
+	// type, for example ByteArrayType or String().Type(). The physical type
+	// must be BYTE_ARRAY.
+	Type() Type
+
+	// Appends the byte array representation of value to b and returns the
+	// extended buffer.
+	//
+	// The value has the Go type of the struct field that the custom logical
+	// type was declared on, or of the element type when the field is a
+	// pointer.
+	Encode(b []byte, value reflect.Value) ([]byte, error)
+
+	// Sets value to the Go value represented by the byte array b.
+	//
+	// The method must not retain b, which may be reused after it returns.
+	Decode(value reflect.Value, b []byte) error
+}
+
+var logicalTypes struct {
+	mutex    sync.RWMutex
+	handlers map[string]LogicalTypeHandler
+}
+
+// RegisterLogicalType registers a handler for the custom logical type of the
+// given name, which struct fields declare with the "custom:name" option of
+// their parquet tag, for example:
+//
+//	func init() {
+//		parquet.RegisterLogicalType("point", pointHandler{})
+//	}
+//
+//	type Shape struct {
+//		Center Point  `parquet:"center,custom:point"`
+//		Origin *Point `parquet:"origin,optional,custom:point"`
+//	}
+//
+// Custom logical types are a separate namespace from the options of parquet
+// tags, so they never take precedence over the logical types built into the
+// package. The parquet files do not record the name of custom logical types,
+// columns are written with the type returned by the handler and can be read
+// by any parquet reader.
+//
+// Schemas are cached when they are derived from Go types, so handlers should
+// be registered before the types using them are first passed to this package,
+// typically in an init function.
+//
+// The function panics if the name is empty or already registered, or if the
+// handler's type does not have a BYTE_ARRAY physical type.
+func RegisterLogicalType(name string, handler LogicalTypeHandler) {
+	if name == "" {
+		panic("cannot register a custom parquet logical type with an empty name")
+	}
+	if kind := handler.Type().Kind(); kind != ByteArray {
+		panic("cannot register custom parquet logical type " + name + " of physical type " + kind.String())
+	}
+
+	logicalTypes.mutex.Lock()
+	defer logicalTypes.mutex.Unlock()
+
+	if _, exists := logicalTypes.handlers[name]; exists {
+		panic("custom parquet logical type " + name + " is already registered")
+	}
+	if logicalTypes.handlers == nil {
+		logicalTypes.handlers = make(map[string]LogicalTypeHandler)
+	}
+	logicalTypes.handlers[name] = handler
+}
+
+func lookupLogicalType(name string) LogicalTypeHandler {
+	logicalTypes.mutex.RLock()
+	defer logicalTypes.mutex.RUnlock()
+	return logicalTypes.handlers[name]
+}
+
+// customType wraps the type of a custom logical type handler to convert the
+// Go values of columns with the handler.
+type customType struct {
+	Type
+	name    string
+	handler LogicalTypeHandler
+}
+
+func (t *customType) AssignValue(dst reflect.Value, src Value) error {
+	if src.IsNull() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if err := t.handler.Decode(dst, src.byteArray()); err != nil {
+		return fmt.Errorf("cannot assign value of custom logical type %s to %s: %w", t.name, dst.Type(), err)
+	}
+	return nil
+}
+
+func (t *customType) encode(b []byte, value reflect.Value) ([]byte, error) {
+	b, err := t.handler.Encode(b, value)
+	if err != nil {
+		return b, fmt.Errorf("cannot encode %s value of custom logical type %s: %w", value.Type(), t.name, err)
+	}
+	return b, nil
+}
+
+func (t *customType) makeValue(value reflect.Value) Value {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return Value{}
+		}
+		value = value.Elem()
+	}
+	b, err := t.encode(nil, value)
+	if err != nil {
+		panic(&valueError{err})
+	}
+	return ByteArrayValue(b)
+}
+
+func customNodeOf(t reflect.Type, name, typeName string, tag []string) Node {
+	handler := lookupLogicalType(typeName)
+	if handler == nil {
+		throwInvalidNode(t, "struct field has unregistered custom logical type "+typeName, name, tag...)
+	}
+	return Leaf(&customType{
+		Type:    handler.Type(),
+		name:    typeName,
+		handler: handler,
+	})
+}
+
+func customTypeOf(schema *Schema, path columnPath) *customType {
+	if leaf, exists := schema.Lookup(path...); exists {
+		t, _ := leaf.Node.Type().(*customType)
+		return t
+	}
+	return nil
+}
+
+func writeRowsFuncOfCustom(t reflect.Type, schema *Schema, path columnPath, typ *customType) writeRowsFunc {
+	writeRows := writeRowsFuncOfRequired(reflect.TypeOf(""), schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		var buffer []byte
+		values := []string{""}
+		for i := 0; i < rows.Len(); i++ {
+			var err error
+			buffer, err = typ.encode(buffer[:0], reflect.NewAt(t, rows.Index(i)).Elem())
+			if err != nil {
+				return err
+			}
+			values[0] = unsafecast.BytesToString(buffer)
+			if err := writeRows(columns, makeArrayString(values), levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,206 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type point struct {
+	X, Y int32
+}
+
+type pointType struct{}
+
+func (pointType) Type() parquet.Type { return parquet.ByteArrayType }
+
+func (pointType) Encode(b []byte, value reflect.Value) ([]byte, error) {
+	p := value.Interface().(point)
+	b = binary.LittleEndian.AppendUint32(b, uint32(p.X))
+	b = binary.LittleEndian.AppendUint32(b, uint32(p.Y))
+	return b, nil
+}
+
+func (pointType) Decode(value reflect.Value, b []byte) error {
+	if len(b) != 8 {
+		return fmt.Errorf("invalid point of %d bytes", len(b))
+	}
+	value.Set(reflect.ValueOf(point{
+		X: int32(binary.LittleEndian.Uint32(b[0:])),
+		Y: int32(binary.LittleEndian.Uint32(b[4:])),
+	}))
+	return nil
+}
+
+// counterType is a custom logical type which fails to encode negative values.
+type counterType struct{}
+
+func (counterType) Type() parquet.Type { return parquet.ByteArrayType }
+
+func (counterType) Encode(b []byte, value reflect.Value) ([]byte, error) {
+	if value.Int() < 0 {
+		return b, fmt.Errorf("negative counter value: %d", value.Int())
+	}
+	return binary.AppendUvarint(b, uint64(value.Int())), nil
+}
+
+func (counterType) Decode(value reflect.Value, b []byte) error {
+	v, n := binary.Uvarint(b)
+	if n <= 0 {
+		return fmt.Errorf("invalid counter of %d bytes", len(b))
+	}
+	value.SetInt(int64(v))
+	return nil
+}
+
+func init() {
+	parquet.RegisterLogicalType("point", pointType{})
+	parquet.RegisterLogicalType("counter", counterType{})
+}
+
+type customTypeRow struct {
+	Center point  `parquet:"center,custom:point"`
+	Origin *point `parquet:"origin,optional,custom:point"`
+}
+
+func TestCustomLogicalTypeRoundTrip(t *testing.T) {
+	rows := []customTypeRow{
+		{Center: point{X: 1, Y: 2}},
+		{Center: point{X: -1, Y: 1 << 30}, Origin: &point{X: 3, Y: 4}},
+		{},
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, rows)
+			},
+		},
+		{
+			scenario: "rows",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, parquet.SchemaOf(customTypeRow{}))
+				for _, row := range rows {
+					if err := writer.Write(row); err != nil {
+						return err
+					}
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaf, _ := f.Schema().Lookup("center")
+			if kind := leaf.Node.Type().Kind(); kind != parquet.ByteArray {
+				t.Errorf("wrong kind of custom column read from the file: %v", kind)
+			}
+
+			pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+			defer pages.Close()
+			page, err := pages.ReadPage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := make([]parquet.Value, page.NumValues())
+			if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			want := []byte{1, 0, 0, 0, 2, 0, 0, 0}
+			if got := values[0].ByteArray(); !bytes.Equal(got, want) {
+				t.Errorf("wrong point representation:\nwant: %v\ngot:  %v", want, got)
+			}
+
+			got, err := parquet.Read[customTypeRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("rows mismatch:\nwant: %v\ngot:  %v", rows, got)
+			}
+		})
+	}
+}
+
+func TestCustomLogicalTypeUnregistered(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), "unregistered custom logical type") {
+			t.Errorf("expected a panic using an unregistered custom logical type, got %v", r)
+		}
+	}()
+	type Row struct {
+		Value point `parquet:"value,custom:unknown"`
+	}
+	parquet.SchemaOf(Row{})
+}
+
+func TestRegisterLogicalTypeTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic registering the same custom logical type twice")
+		}
+	}()
+	parquet.RegisterLogicalType("point", pointType{})
+}
+
+func TestCustomLogicalTypeEncodeError(t *testing.T) {
+	type Row struct {
+		Count    int64  `parquet:"count,custom:counter"`
+		Optional *int64 `parquet:"optional,optional,custom:counter"`
+	}
+	negative := int64(-1)
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, []Row{{Count: -1}})
+			},
+		},
+		{
+			scenario: "generic optional",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, []Row{{Optional: &negative}})
+			},
+		},
+		{
+			scenario: "rows",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, parquet.SchemaOf(Row{}))
+				if err := writer.Write(Row{Optional: &negative}); err != nil {
+					return err
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			err := test.write(new(bytes.Buffer))
+			if err == nil || !strings.Contains(err.Error(), "negative counter value") {
+				t.Errorf("expected the encoding error of the custom type, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package parquet
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// DecimalValue is an interface implemented by Go types which represent
+// decimal numbers of arbitrary precision.
+//
+// Decimal values are exchanged as unscaled integers: the decimal number is
+// equal to the unscaled value multiplied by 10^(-scale).
+//
+// Fields of types implementing this interface can be mapped to the parquet
+// DECIMAL logical type using the decimal struct tag.
+type DecimalValue interface {
+	// Returns the unscaled value of the decimal number at the given scale.
+	DecimalUnscaled(scale int) *big.Int
+	// Sets the decimal number from its unscaled value at the given scale.
+	SetDecimalUnscaled(unscaled *big.Int, scale int) error
+}
+
+var (
+	bigIntType       = reflect.TypeOf(big.Int{})
+	bigRatType       = reflect.TypeOf(big.Rat{})
+	decimalValueType = reflect.TypeOf((*DecimalValue)(nil)).Elem()
+)
+
+// isDecimalGoType returns true if t is one of the Go types representing
+// decimal numbers of arbitrary precision: big.Int holding the unscaled value,
+// big.Rat holding the exact value, or types implementing DecimalValue.
+func isDecimalGoType(t reflect.Type) bool {
+	return t == bigIntType || t == bigRatType || reflect.PointerTo(t).Implements(decimalValueType)
+}
+
+// decimalBaseType returns the physical type used to store decimal values of
+// the given precision when the Go type does not determine it.
+func decimalBaseType(precision int) Type {
+	if precision <= 18 {
+		return Int64Type
+	}
+	return FixedLenByteArrayType(decimalFixedLenByteArraySize(precision))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// addressable returns a pointer to the Go value v, making a copy if the value
+// is not addressable.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// decimalUnscaledOf returns the unscaled value at the given scale of the
+// decimal number held in v. Rational numbers which cannot be represented
+// exactly at the scale are rounded half away from zero.
+func decimalUnscaledOf(v reflect.Value, scale int) *big.Int {
+	switch p := addressable(v).Interface().(type) {
+	case *big.Int:
+		return p
+	case *big.Rat:
+		x := new(big.Int).Mul(p.Num(), pow10(scale))
+		q, r := new(big.Int).QuoRem(x, p.Denom(), new(big.Int))
+		if r.Abs(r).Lsh(r, 1).Cmp(p.Denom()) >= 0 {
+			if x.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+		return q
+	case DecimalValue:
+		return p.DecimalUnscaled(scale)
+	default:
+		panic("cannot convert Go values of type " + v.Type().String() + " to parquet decimal")
+	}
+}
+
+// makeValueDecimal constructs a parquet value of the given kind from the Go
+// decimal number held in v.
+func makeValueDecimal(kind Kind, decimal *format.DecimalType, v reflect.Value) (Value, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return Value{}, nil
+		}
+		v = v.Elem()
+	}
+
+	unscaled := decimalUnscaledOf(v, int(decimal.Scale))
+	if new(big.Int).Abs(unscaled).Cmp(pow10(int(decimal.Precision))) >= 0 {
+		return Value{}, fmt.Errorf("decimal value %s overflows precision of %s", unscaled, decimal)
+	}
+
+	switch kind {
+	case Int32:
+		if !unscaled.IsInt64() || int64(int32(unscaled.Int64())) != unscaled.Int64() {
+			return Value{}, fmt.Errorf("decimal value %s overflows INT32", unscaled)
+		}
+		return makeValueInt32(int32(unscaled.Int64())), nil
+	case Int64:
+		if !unscaled.IsInt64() {
+			return Value{}, fmt.Errorf("decimal value %s overflows INT64", unscaled)
+		}
+		return makeValueInt64(unscaled.Int64()), nil
+	case FixedLenByteArray:
+		b := make([]byte, decimalFixedLenByteArraySize(int(decimal.Precision)))
+		if err := decimalFixedLenBytes(b, unscaled); err != nil {
+			return Value{}, err
+		}
+		return makeValueBytes(FixedLenByteArray, b), nil
+	default:
+		return Value{}, fmt.Errorf("cannot write decimal values to parquet columns of type %s", kind)
+	}
+}
+
+// decimalFixedLenBytes writes the unscaled value x to b as a big-endian two's
+// complement integer.
+func decimalFixedLenBytes(b []byte, x *big.Int) error {
+	bits := 8*len(b) - 1
+	if x.Sign() >= 0 {
+		if x.BitLen() > bits {
+			return fmt.Errorf("decimal value %s overflows FIXED_LEN_BYTE_ARRAY(%d)", x, len(b))
+		}
+		x.FillBytes(b)
+		return nil
+	}
+	if new(big.Int).Not(x).BitLen() > bits {
+		return fmt.Errorf("decimal value %s overflows FIXED_LEN_BYTE_ARRAY(%d)", x, len(b))
+	}
+	c := new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8))
+	c.Add(c, x).FillBytes(b)
+	return nil
+}
+
+// decimalUnscaledFromValue returns the unscaled integer stored in v.
+func decimalUnscaledFromValue(v Value) *big.Int {
+	switch v.Kind() {
+	case Int32:
+		return big.NewInt(int64(v.int32()))
+	case Int64:
+		return big.NewInt(v.int64())
+	default:
+		b := v.byteArray()
+		x := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return x
+	}
+}
+
+// assignDecimal assigns the decimal number held in src to the Go value dst.
+func assignDecimal(dst reflect.Value, src Value, scale int) error {
+	if src.IsNull() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+
+	unscaled := decimalUnscaledFromValue(src)
+	switch p := dst.Addr().Interface().(type) {
+	case *big.Int:
+		p.Set(unscaled)
+	case *big.Rat:
+		p.SetFrac(unscaled, pow10(scale))
+	case DecimalValue:
+		return p.SetDecimalUnscaled(unscaled, scale)
+	default:
+		return fmt.Errorf("cannot assign parquet decimal to Go value of type %s", dst.Type())
+	}
+	return nil
+}
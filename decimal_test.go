@@ -0,0 +1,262 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// fixedPoint is a decimal number represented by its unscaled value and scale,
+// implementing the parquet.DecimalValue interface.
+type fixedPoint struct {
+	unscaled int64
+	scale    int
+}
+
+func (d fixedPoint) DecimalUnscaled(scale int) *big.Int {
+	x := big.NewInt(d.unscaled)
+	for i := d.scale; i < scale; i++ {
+		x.Mul(x, big.NewInt(10))
+	}
+	return x
+}
+
+func (d *fixedPoint) SetDecimalUnscaled(unscaled *big.Int, scale int) error {
+	if !unscaled.IsInt64() {
+		return fmt.Errorf("decimal value %s overflows int64", unscaled)
+	}
+	d.unscaled, d.scale = unscaled.Int64(), scale
+	return nil
+}
+
+type decimalRow struct {
+	Amount   *big.Rat   `parquet:"amount,decimal(precision=18,scale=4)"`
+	Balance  big.Rat    `parquet:"balance,decimal(precision=38,scale=10)"`
+	Optional *big.Rat   `parquet:"optional,optional,decimal(precision=9,scale=2)"`
+	Unscaled *big.Int   `parquet:"unscaled,decimal(precision=20,scale=3)"`
+	Custom   fixedPoint `parquet:"custom,decimal(precision=10,scale=2)"`
+	Legacy   int64      `parquet:"legacy,decimal(2:10)"`
+}
+
+func TestDecimalSchema(t *testing.T) {
+	schema := parquet.SchemaOf(decimalRow{})
+
+	tests := []struct {
+		column    string
+		kind      parquet.Kind
+		length    int
+		scale     int32
+		precision int32
+	}{
+		{"amount", parquet.Int64, 0, 4, 18},
+		{"balance", parquet.FixedLenByteArray, 16, 10, 38},
+		{"optional", parquet.Int64, 0, 2, 9},
+		{"unscaled", parquet.FixedLenByteArray, 9, 3, 20},
+		{"custom", parquet.Int64, 0, 2, 10},
+		{"legacy", parquet.Int64, 0, 2, 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			leaf, ok := schema.Lookup(test.column)
+			if !ok {
+				t.Fatal("column not found")
+			}
+			typ := leaf.Node.Type()
+			if typ.Kind() != test.kind {
+				t.Errorf("wrong kind: want=%v got=%v", test.kind, typ.Kind())
+			}
+			if test.length != 0 && typ.Length() != test.length {
+				t.Errorf("wrong length: want=%d got=%d", test.length, typ.Length())
+			}
+			want := &format.DecimalType{Scale: test.scale, Precision: test.precision}
+			if got := typ.LogicalType().Decimal; !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong decimal type: want=%+v got=%+v", want, got)
+			}
+		})
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	rat := func(s string) *big.Rat {
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			t.Fatalf("invalid rational: %s", s)
+		}
+		return r
+	}
+	rows := []decimalRow{
+		{
+			Amount:   rat("1234.5678"),
+			Balance:  *rat("12345678901234567890.0123456789"),
+			Optional: rat("-0.5"),
+			Unscaled: big.NewInt(-12345678901234567),
+			Custom:   fixedPoint{unscaled: 1999, scale: 2},
+			Legacy:   -42,
+		},
+		{
+			Amount:   rat("-99999999999999.9999"),
+			Balance:  *rat("-12345678901234567890.0123456789"),
+			Unscaled: new(big.Int).Neg(new(big.Int).Exp(big.NewInt(10), big.NewInt(19), nil)),
+			Custom:   fixedPoint{unscaled: -5, scale: 1},
+		},
+		{
+			Amount:   rat("0"),
+			Balance:  *rat("-1/10000000000"),
+			Optional: rat("1234567.89"),
+			Unscaled: big.NewInt(0),
+			Custom:   fixedPoint{unscaled: 0, scale: 2},
+			Legacy:   1,
+		},
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(*bytes.Buffer) error
+	}{
+		{
+			scenario: "generic writer",
+			write: func(buf *bytes.Buffer) error {
+				w := parquet.NewGenericWriter[decimalRow](buf)
+				if _, err := w.Write(rows); err != nil {
+					return err
+				}
+				return w.Close()
+			},
+		},
+		{
+			scenario: "writer",
+			write: func(buf *bytes.Buffer) error {
+				w := parquet.NewWriter(buf, parquet.SchemaOf(decimalRow{}))
+				for i := range rows {
+					if err := w.Write(&rows[i]); err != nil {
+						return err
+					}
+				}
+				return w.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parquet.Read[decimalRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(rows) {
+				t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+			}
+
+			for i := range rows {
+				want, row := &rows[i], &got[i]
+				if want.Amount.Cmp(row.Amount) != 0 {
+					t.Errorf("row %d: wrong amount: want=%s got=%s", i, want.Amount.RatString(), row.Amount.RatString())
+				}
+				if want.Balance.Cmp(&row.Balance) != 0 {
+					t.Errorf("row %d: wrong balance: want=%s got=%s", i, want.Balance.RatString(), row.Balance.RatString())
+				}
+				switch {
+				case want.Optional == nil:
+					if row.Optional != nil {
+						t.Errorf("row %d: wrong optional: want=nil got=%s", i, row.Optional.RatString())
+					}
+				case row.Optional == nil || want.Optional.Cmp(row.Optional) != 0:
+					t.Errorf("row %d: wrong optional: want=%s got=%v", i, want.Optional.RatString(), row.Optional)
+				}
+				if want.Unscaled.Cmp(row.Unscaled) != 0 {
+					t.Errorf("row %d: wrong unscaled: want=%s got=%s", i, want.Unscaled, row.Unscaled)
+				}
+				if c := want.Custom.DecimalUnscaled(2); c.Cmp(row.Custom.DecimalUnscaled(2)) != 0 || row.Custom.scale != 2 {
+					t.Errorf("row %d: wrong custom: want=%+v got=%+v", i, want.Custom, row.Custom)
+				}
+				if want.Legacy != row.Legacy {
+					t.Errorf("row %d: wrong legacy: want=%d got=%d", i, want.Legacy, row.Legacy)
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	type row struct {
+		Value *big.Rat `parquet:"value,decimal(precision=5,scale=2)"`
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1/3", "0.33"},
+		{"2/3", "0.67"},
+		{"-2/3", "-0.67"},
+		{"0.125", "0.13"},
+		{"-0.125", "-0.13"},
+	}
+
+	rows := make([]row, len(tests))
+	for i, test := range tests {
+		rows[i].Value, _ = new(big.Rat).SetString(test.input)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, test := range tests {
+		if s := got[i].Value.FloatString(2); s != test.want {
+			t.Errorf("%s: want=%s got=%s", test.input, test.want, s)
+		}
+	}
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	type row struct {
+		Value *big.Rat `parquet:"value,decimal(precision=4,scale=2)"`
+	}
+	w := parquet.NewGenericWriter[row](new(bytes.Buffer))
+	if _, err := w.Write([]row{{Value: big.NewRat(100, 1)}}); err == nil {
+		t.Error("expected an error writing a decimal value which overflows the precision")
+	}
+
+	// The Writer deconstructs rows into values instead of writing them to the
+	// column buffers directly.
+	if err := parquet.NewWriter(new(bytes.Buffer)).Write(row{Value: big.NewRat(100, 1)}); err == nil {
+		t.Error("expected an error writing a decimal value which overflows the precision with a Writer")
+	}
+}
+
+func TestDecimalInvalidTag(t *testing.T) {
+	for _, model := range []any{
+		struct {
+			Value *big.Rat `parquet:"value,decimal(precision=4,scale=6)"`
+		}{},
+		struct {
+			Value *big.Rat `parquet:"value,decimal(scale=2)"`
+		}{},
+		struct {
+			Value string `parquet:"value,decimal(precision=4,scale=2)"`
+		}{},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic creating a schema of %T", model)
+				}
+			}()
+			parquet.SchemaOf(model)
+		}()
+	}
+}
@@ -1,5 +1,11 @@
 package parquet
 
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
 // DedupeRowReader constructs a row reader which drops duplicated consecutive
 // rows, according to the comparator function passed as argument.
 //
@@ -109,3 +115,107 @@ func (d *dedupe) deduplicate(rows []Row, compare func(Row, Row) int) int {
 	d.lastRow = append(d.lastRow[:0], lastRow...)
 	return len(d.uniq)
 }
+
+// DeduplicateRows writes to dst a parquet file holding the rows of src, keeping
+// only the last row of each sequence of rows with equal values in the key
+// columns. Key columns are given by their dot-separated paths, for example
+// "user.id".
+//
+// The rows of src must be sorted by the key columns. The order of key columns
+// declared in the sorting columns of the row groups of src is used, which
+// allows files sorted in descending order to be deduplicated; other key
+// columns are expected to be in ascending order. The function returns an error
+// if it encounters rows which are not sorted, in which case dst holds a parquet
+// file with only the rows which were deduplicated before the error.
+//
+// The output file has the schema of src, and declares that its rows are
+// sorted by the key columns. The options are applied to the writer of the
+// output file.
+func DeduplicateRows(dst io.Writer, src *File, keyColumns []string, options ...WriterOption) (err error) {
+	schema := src.Schema()
+	if len(keyColumns) == 0 {
+		return fmt.Errorf("deduplicating rows: no key columns")
+	}
+
+	var fileSortingColumns []SortingColumn
+	if rowGroups := src.RowGroups(); len(rowGroups) > 0 {
+		fileSortingColumns = rowGroups[0].SortingColumns()
+	}
+
+	sortingColumns := make([]SortingColumn, len(keyColumns))
+	for i, column := range keyColumns {
+		path := columnPath(strings.Split(column, "."))
+		if _, ok := schema.Lookup(path...); !ok {
+			return fmt.Errorf("deduplicating rows: key column %q does not exist in the schema", column)
+		}
+		sortingColumns[i] = Ascending(path...)
+		for _, sorting := range fileSortingColumns {
+			if path.equal(sorting.Path()) {
+				sortingColumns[i] = sorting
+				break
+			}
+		}
+	}
+
+	compare := schema.Comparator(sortingColumns...)
+	writer := NewWriter(dst, append([]WriterOption{
+		schema,
+		SortingWriterConfig(SortingColumns(sortingColumns...)),
+	}, options...)...)
+	// The writer is closed on errors as well, so dst holds a complete parquet
+	// file with the rows written before the error.
+	defer func() {
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	reader := NewReader(src)
+	defer reader.Close()
+
+	rows := make([]Row, defaultRowBufferSize)
+	uniq := make([]Row, 0, defaultRowBufferSize)
+	last := Row(nil)
+	numRows := int64(0)
+
+	for {
+		n, err := reader.ReadRows(rows)
+
+		for _, row := range rows[:n] {
+			if last != nil {
+				switch cmp := compare(row, last); {
+				case cmp < 0:
+					return fmt.Errorf("deduplicating rows: row %d is not sorted by the key columns", numRows)
+				case cmp > 0:
+					uniq = append(uniq, last)
+				}
+			}
+			last = row.Clone()
+			numRows++
+		}
+
+		if len(uniq) > 0 {
+			if _, err := writer.WriteRows(uniq); err != nil {
+				return fmt.Errorf("deduplicating rows: %w", err)
+			}
+			for i := range uniq {
+				uniq[i] = nil
+			}
+			uniq = uniq[:0]
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				return fmt.Errorf("deduplicating rows: %w", err)
+			}
+			break
+		}
+	}
+
+	if last != nil {
+		if _, err := writer.WriteRows([]Row{last}); err != nil {
+			return fmt.Errorf("deduplicating rows: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package parquet_test
 
 import (
+	"bytes"
 	"sort"
 	"testing"
 
@@ -103,3 +104,114 @@ func TestDedupeRowWriter(t *testing.T) {
 	n, _ := reader.Read(rows)
 	assertRowsEqual(t, dedupeRows, rows[:n])
 }
+
+func TestDeduplicateRows(t *testing.T) {
+	type Row struct {
+		Key     int64  `parquet:"key"`
+		Version int64  `parquet:"version"`
+		Value   string `parquet:"value"`
+	}
+
+	writeFile := func(t *testing.T, rows []Row, options ...parquet.WriterOption) *parquet.File {
+		t.Helper()
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](buf, options...)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	dedupe := func(t *testing.T, f *parquet.File, keys ...string) []Row {
+		t.Helper()
+		output := new(bytes.Buffer)
+		if err := parquet.DeduplicateRows(output, f, keys); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows
+	}
+
+	t.Run("ascending", func(t *testing.T) {
+		rows := make([]Row, 1000)
+		for i := range rows {
+			rows[i] = Row{Key: int64(i / 7), Version: int64(i % 7), Value: "v"}
+		}
+		// Small row groups make sure duplicates span across row groups.
+		f := writeFile(t, rows, parquet.MaxRowsPerRowGroup(10))
+
+		got := dedupe(t, f, "key")
+		want := make([]Row, 0, len(rows)/7+1)
+		for i, row := range rows {
+			if i == len(rows)-1 || rows[i+1].Key != row.Key {
+				want = append(want, row)
+			}
+		}
+		assertRowsEqual(t, want, got)
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		rows := []Row{
+			{Key: 3, Version: 1, Value: "a"},
+			{Key: 3, Version: 2, Value: "b"},
+			{Key: 2, Version: 1, Value: "c"},
+			{Key: 1, Version: 1, Value: "d"},
+			{Key: 1, Version: 2, Value: "e"},
+		}
+		f := writeFile(t, rows,
+			parquet.SortingWriterConfig(parquet.SortingColumns(parquet.Descending("key"))),
+		)
+		got := dedupe(t, f, "key")
+		want := []Row{
+			{Key: 3, Version: 2, Value: "b"},
+			{Key: 2, Version: 1, Value: "c"},
+			{Key: 1, Version: 2, Value: "e"},
+		}
+		assertRowsEqual(t, want, got)
+	})
+
+	t.Run("multiple keys", func(t *testing.T) {
+		rows := []Row{
+			{Key: 1, Version: 1, Value: "a"},
+			{Key: 1, Version: 1, Value: "b"},
+			{Key: 1, Version: 2, Value: "c"},
+			{Key: 2, Version: 1, Value: "d"},
+		}
+		got := dedupe(t, writeFile(t, rows), "key", "version")
+		want := []Row{
+			{Key: 1, Version: 1, Value: "b"},
+			{Key: 1, Version: 2, Value: "c"},
+			{Key: 2, Version: 1, Value: "d"},
+		}
+		assertRowsEqual(t, want, got)
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		f := writeFile(t, []Row{{Key: 2}, {Key: 1}})
+		output := new(bytes.Buffer)
+		if err := parquet.DeduplicateRows(output, f, []string{"key"}); err == nil {
+			t.Fatal("expected an error deduplicating unsorted rows")
+		}
+		// The output is closed, so it can be opened despite the error.
+		if _, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len())); err != nil {
+			t.Errorf("opening the output of a failed deduplication: %v", err)
+		}
+	})
+
+	t.Run("missing key column", func(t *testing.T) {
+		f := writeFile(t, []Row{{Key: 1}})
+		if err := parquet.DeduplicateRows(new(bytes.Buffer), f, []string{"nope"}); err == nil {
+			t.Fatal("expected an error deduplicating rows by a missing column")
+		}
+	})
+}
@@ -68,7 +68,9 @@ type Dictionary interface {
 	// is negative or greater than the highest index in the dictionary.
 	Lookup(indexes []int32, values []Value)
 
-	// Returns the min and max values found in the given indexes.
+	// Returns the min and max values found in the given indexes. NaN values
+	// of floating point dictionaries are ignored, the returned values are null
+	// if the indexes only reference NaN values.
 	Bounds(indexes []int32) (min, max Value)
 
 	// Resets the dictionary to its initial state, removing all values.
@@ -499,15 +501,20 @@ func (d *int96Dictionary) Page() Page {
 type floatDictionary struct {
 	floatPage
 	table *hashprobe.Float32Table
+	// Set when the dictionary holds NaN values, which are excluded from the
+	// bounds of pages.
+	hasNaN bool
 }
 
 func newFloatDictionary(typ Type, columnIndex int16, numValues int32, data encoding.Values) *floatDictionary {
+	values := data.Float()[:numValues]
 	return &floatDictionary{
 		floatPage: floatPage{
 			typ:         typ,
-			values:      data.Float()[:numValues],
+			values:      values,
 			columnIndex: ^columnIndex,
 		},
+		hasNaN: hasNaNFloat32(values),
 	}
 }
 
@@ -550,7 +557,9 @@ func (d *floatDictionary) insert(indexes []int32, rows sparse.Array) {
 		if d.table.ProbeArray(values.Slice(i, j), indexes[i:j:j]) > 0 {
 			for k, index := range indexes[i:j] {
 				if index == int32(len(d.values)) {
-					d.values = append(d.values, values.Index(i+k))
+					v := values.Index(i + k)
+					d.values = append(d.values, v)
+					d.hasNaN = d.hasNaN || v != v
 				}
 			}
 		}
@@ -564,16 +573,37 @@ func (d *floatDictionary) Lookup(indexes []int32, values []Value) {
 }
 
 func (d *floatDictionary) Bounds(indexes []int32) (min, max Value) {
-	if len(indexes) > 0 {
-		minValue, maxValue := d.bounds(indexes)
+	if minValue, maxValue, ok := d.boundsNonNaN(indexes); ok {
 		min = d.makeValue(minValue)
 		max = d.makeValue(maxValue)
 	}
 	return min, max
 }
 
+func (d *floatDictionary) boundsNonNaN(indexes []int32) (min, max float32, ok bool) {
+	if !d.hasNaN {
+		if ok = len(indexes) > 0; ok {
+			min, max = d.bounds(indexes)
+		}
+		return min, max, ok
+	}
+	for _, i := range indexes {
+		switch v := d.index(i); {
+		case v != v: // NaN
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 func (d *floatDictionary) Reset() {
 	d.values = d.values[:0]
+	d.hasNaN = false
 	if d.table != nil {
 		d.table.Reset()
 	}
@@ -586,15 +616,20 @@ func (d *floatDictionary) Page() Page {
 type doubleDictionary struct {
 	doublePage
 	table *hashprobe.Float64Table
+	// Set when the dictionary holds NaN values, which are excluded from the
+	// bounds of pages.
+	hasNaN bool
 }
 
 func newDoubleDictionary(typ Type, columnIndex int16, numValues int32, data encoding.Values) *doubleDictionary {
+	values := data.Double()[:numValues]
 	return &doubleDictionary{
 		doublePage: doublePage{
 			typ:         typ,
-			values:      data.Double()[:numValues],
+			values:      values,
 			columnIndex: ^columnIndex,
 		},
+		hasNaN: hasNaNFloat64(values),
 	}
 }
 
@@ -637,7 +672,9 @@ func (d *doubleDictionary) insert(indexes []int32, rows sparse.Array) {
 		if d.table.ProbeArray(values.Slice(i, j), indexes[i:j:j]) > 0 {
 			for k, index := range indexes[i:j] {
 				if index == int32(len(d.values)) {
-					d.values = append(d.values, values.Index(i+k))
+					v := values.Index(i + k)
+					d.values = append(d.values, v)
+					d.hasNaN = d.hasNaN || v != v
 				}
 			}
 		}
@@ -651,16 +688,37 @@ func (d *doubleDictionary) Lookup(indexes []int32, values []Value) {
 }
 
 func (d *doubleDictionary) Bounds(indexes []int32) (min, max Value) {
-	if len(indexes) > 0 {
-		minValue, maxValue := d.bounds(indexes)
+	if minValue, maxValue, ok := d.boundsNonNaN(indexes); ok {
 		min = d.makeValue(minValue)
 		max = d.makeValue(maxValue)
 	}
 	return min, max
 }
 
+func (d *doubleDictionary) boundsNonNaN(indexes []int32) (min, max float64, ok bool) {
+	if !d.hasNaN {
+		if ok = len(indexes) > 0; ok {
+			min, max = d.bounds(indexes)
+		}
+		return min, max, ok
+	}
+	for _, i := range indexes {
+		switch v := d.index(i); {
+		case v != v: // NaN
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
 func (d *doubleDictionary) Reset() {
 	d.values = d.values[:0]
+	d.hasNaN = false
 	if d.table != nil {
 		d.table.Reset()
 	}
@@ -1279,10 +1337,13 @@ func (page *indexedPage) Data() encoding.Values { return encoding.Int32Values(pa
 func (page *indexedPage) Values() ValueReader { return &indexedPageValues{page: page} }
 
 func (page *indexedPage) Bounds() (min, max Value, ok bool) {
-	if ok = len(page.values) > 0; ok {
+	if len(page.values) > 0 {
 		min, max = page.typ.dict.Bounds(page.values)
-		min.columnIndex = page.columnIndex
-		max.columnIndex = page.columnIndex
+		// The bounds are null when the page only holds NaN values.
+		if ok = !min.IsNull(); ok {
+			min.columnIndex = page.columnIndex
+			max.columnIndex = page.columnIndex
+		}
 	}
 	return min, max, ok
 }
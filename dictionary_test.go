@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -272,3 +274,106 @@ func TestIssue312(t *testing.T) {
 		})
 	}
 }
+
+func TestDictionaryValues(t *testing.T) {
+	type Row struct {
+		Color string `parquet:"color,dict"`
+		Name  string `parquet:"name"`
+	}
+
+	colors := []string{"red", "green", "blue"}
+	rows := make([]Row, 300)
+	for i := range rows {
+		rows[i] = Row{Color: colors[i%len(colors)], Name: fmt.Sprintf("name-%d", i)}
+	}
+
+	sortedStrings := func(values []parquet.Value) []string {
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = v.String()
+		}
+		sort.Strings(s)
+		return s
+	}
+
+	t.Run("file", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, parquet.PageBufferSize(256)); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks := f.RowGroups()[0].ColumnChunks()
+
+		values, ok, err := parquet.DictionaryValues(chunks[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("the dictionary encoded column has no dictionary values")
+		}
+		if got, want := sortedStrings(values), []string{"blue", "green", "red"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong dictionary values: want=%q got=%q", want, got)
+		}
+
+		if _, ok, err := parquet.DictionaryValues(chunks[1]); err != nil || ok {
+			t.Errorf("unexpected dictionary values of a plain column: ok=%t err=%v", ok, err)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		type Row struct {
+			Name string `parquet:"name,dict"`
+		}
+		rows := make([]Row, 1000)
+		for i := range rows {
+			rows[i].Name = fmt.Sprintf("name-%04d", i)
+		}
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows, parquet.PageBufferSize(256), parquet.MaxDictionarySize(1024)); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		values, ok, err := parquet.DictionaryValues(f.RowGroups()[0].ColumnChunks()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("the column chunk has no dictionary values")
+		}
+		if len(values) == 0 || len(values) >= len(rows) {
+			t.Errorf("expected an incomplete dictionary, got %d values for %d rows", len(values), len(rows))
+		}
+		for i, v := range values {
+			if want := rows[i].Name; v.String() != want {
+				t.Errorf("wrong dictionary value at index %d: want=%q got=%q", i, want, v)
+			}
+		}
+	})
+
+	t.Run("buffer", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		chunks := buffer.ColumnChunks()
+		values, ok, err := parquet.DictionaryValues(chunks[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("the dictionary encoded column has no dictionary values")
+		}
+		if got, want := sortedStrings(values), []string{"blue", "green", "red"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("wrong dictionary values: want=%q got=%q", want, got)
+		}
+		if _, ok, err := parquet.DictionaryValues(chunks[1]); err != nil || ok {
+			t.Errorf("unexpected dictionary values of a plain column: ok=%t err=%v", ok, err)
+		}
+	})
+}
@@ -30,7 +30,7 @@ func (e *LengthByteArrayEncoding) EncodeByteArray(dst []byte, src []byte, offset
 
 	dst = dst[:0]
 	dst = encodeInt32(dst, length.values)
-	dst = append(dst, src...)
+	dst = append(dst, src[offsets[0]:offsets[len(offsets)-1]]...)
 	return dst, nil
 }
 
@@ -32,3 +32,32 @@ func TestDecodeByteArrayLengths(t *testing.T) {
 		t.Fatalf("wrong last offset: want=%d got=%d", lastOffset, offsets[len(lengths)])
 	}
 }
+
+func TestEncodeByteArrayOffsets(t *testing.T) {
+	// The offsets reference a slice of the values, like the offsets of pages
+	// returned by the Slice method.
+	src := []byte("skipABCDEFskip")
+	offsets := []uint32{4, 5, 7, 10}
+
+	e := new(LengthByteArrayEncoding)
+	b, err := e.EncodeByteArray(nil, src, offsets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, decodedOffsets, err := e.DecodeByteArray(nil, b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(values) != "ABCDEF" {
+		t.Errorf("wrong values: want=%q got=%q", "ABCDEF", values)
+	}
+	want := []uint32{0, 1, 3, 6}
+	if len(decodedOffsets) != len(want) {
+		t.Fatalf("wrong offsets: want=%v got=%v", want, decodedOffsets)
+	}
+	for i := range want {
+		if decodedOffsets[i] != want[i] {
+			t.Fatalf("wrong offsets: want=%v got=%v", want, decodedOffsets)
+		}
+	}
+}
@@ -176,6 +176,7 @@ var byteArrayTests = [...][][]byte{
 	{[]byte("")},
 	{[]byte("A"), []byte("B"), []byte("C")},
 	{[]byte("hello world!"), bytes.Repeat([]byte("1234567890"), 100)},
+	{[]byte(""), []byte("A"), []byte(""), []byte("hello world!"), []byte(""), bytes.Repeat([]byte("1234567890"), 10), []byte("")},
 }
 
 var fixedLenByteArrayTests = [...]struct {
@@ -0,0 +1,268 @@
+package parquet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// Module types of the parquet modular encryption specification, they are
+// part of the additional authenticated data (AAD) of encrypted modules.
+//
+// See https://github.com/apache/parquet-format/blob/master/Encryption.md
+const (
+	footerModule         = 0
+	columnMetaDataModule = 1
+)
+
+const (
+	gcmNonceLength = 12
+	gcmTagLength   = 16
+	// Length of the signature appended to plaintext footers of encrypted files.
+	footerSignatureLength = gcmNonceLength + gcmTagLength
+)
+
+// fileDecryptor holds the state needed to decrypt the modules of a parquet
+// file encrypted with the modular encryption specification.
+type fileDecryptor struct {
+	keys      map[string][]byte
+	aadPrefix []byte
+	aadUnique []byte
+}
+
+func newFileDecryptor(algorithm *format.EncryptionAlgorithm, keys map[string][]byte) (*fileDecryptor, error) {
+	var aadPrefix, aadUnique []byte
+	var supplyAadPrefix bool
+
+	switch {
+	case algorithm.AesGcmV1 != nil:
+		aadPrefix = algorithm.AesGcmV1.AadPrefix
+		aadUnique = algorithm.AesGcmV1.AadFileUnique
+		supplyAadPrefix = algorithm.AesGcmV1.SupplyAadPrefix
+	case algorithm.AesGcmCtrV1 != nil:
+		aadPrefix = algorithm.AesGcmCtrV1.AadPrefix
+		aadUnique = algorithm.AesGcmCtrV1.AadFileUnique
+		supplyAadPrefix = algorithm.AesGcmCtrV1.SupplyAadPrefix
+	default:
+		return nil, fmt.Errorf("unsupported parquet encryption algorithm")
+	}
+
+	if supplyAadPrefix && len(aadPrefix) == 0 {
+		return nil, fmt.Errorf("parquet files encrypted with an externally supplied AAD prefix are not supported")
+	}
+
+	d := &fileDecryptor{
+		keys:      keys,
+		aadPrefix: aadPrefix,
+		aadUnique: aadUnique,
+	}
+	return d, nil
+}
+
+func (d *fileDecryptor) footerKey() ([]byte, error) {
+	key, ok := d.keys[""]
+	if !ok {
+		return nil, fmt.Errorf("decrypting parquet footer: %w", ErrMissingDecryptionKey)
+	}
+	return key, nil
+}
+
+func (d *fileDecryptor) columnKey(crypto *format.ColumnCryptoMetaData) ([]byte, error) {
+	if crypto.EncryptionWithColumnKey == nil {
+		return d.footerKey()
+	}
+	path := strings.Join(crypto.EncryptionWithColumnKey.PathInSchema, ".")
+	key, ok := d.keys[path]
+	if !ok {
+		return nil, fmt.Errorf("decrypting parquet column %q: %w", path, ErrMissingDecryptionKey)
+	}
+	return key, nil
+}
+
+// aad returns the additional authenticated data of the module of the given
+// type. Modules of column chunks are also identified by their row group and
+// column ordinals.
+func (d *fileDecryptor) aad(moduleType byte, ordinals ...int16) []byte {
+	aad := make([]byte, 0, len(d.aadPrefix)+len(d.aadUnique)+1+2*len(ordinals))
+	aad = append(aad, d.aadPrefix...)
+	aad = append(aad, d.aadUnique...)
+	aad = append(aad, moduleType)
+	for _, ordinal := range ordinals {
+		aad = binary.LittleEndian.AppendUint16(aad, uint16(ordinal))
+	}
+	return aad
+}
+
+// decryptFooter decrypts the footer module which follows the crypto metadata
+// in files with encrypted footers.
+func (d *fileDecryptor) decryptFooter(module []byte) ([]byte, error) {
+	key, err := d.footerKey()
+	if err != nil {
+		return nil, err
+	}
+	b, err := decryptModule(key, module, d.aad(footerModule))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting parquet footer: %w", err)
+	}
+	return b, nil
+}
+
+// verifyFooter checks the signature of plaintext footers in encrypted files.
+func (d *fileDecryptor) verifyFooter(footer, signature []byte) error {
+	key, err := d.footerKey()
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce, tag := signature[:gcmNonceLength], signature[gcmNonceLength:]
+	sealed := gcm.Seal(nil, nonce, footer, d.aad(footerModule))
+	if string(sealed[len(sealed)-gcmTagLength:]) != string(tag) {
+		return fmt.Errorf("verifying parquet footer signature: %w", ErrDecryptionFailed)
+	}
+	return nil
+}
+
+// decryptColumnMetaData decrypts the metadata of column chunks which have
+// been encrypted separately from the footer.
+func (d *fileDecryptor) decryptColumnMetaData(chunk *format.ColumnChunk, rowGroup, column int16) ([]byte, error) {
+	key, err := d.columnKey(&chunk.CryptoMetadata)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decryptModule(key, chunk.EncryptedColumnMetadata, d.aad(columnMetaDataModule, rowGroup, column))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting metadata of column chunk: rowGroup=%d columnChunk=%d: %w", rowGroup, column, err)
+	}
+	return b, nil
+}
+
+// decryptModule decrypts an AES-GCM module, laid out as a 4 bytes little-endian
+// length followed by the nonce, the cipher text, and the authentication tag.
+func decryptModule(key, module, aad []byte) ([]byte, error) {
+	if len(module) < 4 {
+		return nil, fmt.Errorf("encrypted module is too short: %d bytes", len(module))
+	}
+	length := int(binary.LittleEndian.Uint32(module))
+	module = module[4:]
+	if length != len(module) || length < gcmNonceLength+gcmTagLength {
+		return nil, fmt.Errorf("encrypted module has invalid length: %d/%d", length, len(module))
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, ciphertext := module[:gcmNonceLength], module[gcmNonceLength:]
+	b, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return b, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func isEncryptedFile(metadata *format.FileMetaData) bool {
+	return metadata.EncryptionAlgorithm.AesGcmV1 != nil || metadata.EncryptionAlgorithm.AesGcmCtrV1 != nil
+}
+
+func isEncryptedColumnChunk(chunk *format.ColumnChunk) bool {
+	return chunk.CryptoMetadata.EncryptionWithFooterKey != nil || chunk.CryptoMetadata.EncryptionWithColumnKey != nil
+}
+
+// decodeFooter decodes the file metadata from the footer of f. In encrypted
+// files, the footer is decrypted or its signature verified, and the metadata
+// of encrypted column chunks are decrypted as well when their keys were given.
+func (f *File) decodeFooter(footerData []byte, encryptedFooter bool) error {
+	if encryptedFooter {
+		r := bytes.NewReader(footerData)
+		crypto := format.FileCryptoMetaData{}
+		if err := thrift.NewDecoder(f.protocol.NewReader(r)).Decode(&crypto); err != nil {
+			return fmt.Errorf("reading parquet file crypto metadata: %w", err)
+		}
+		d, err := newFileDecryptor(&crypto.EncryptionAlgorithm, f.config.DecryptionKeys)
+		if err != nil {
+			return err
+		}
+		if footerData, err = d.decryptFooter(footerData[len(footerData)-r.Len():]); err != nil {
+			return err
+		}
+		if err := thrift.Unmarshal(&f.protocol, footerData, &f.metadata); err != nil {
+			return fmt.Errorf("reading parquet file metadata: %w", err)
+		}
+		f.decryptor = d
+	} else {
+		r := bytes.NewReader(footerData)
+		if err := thrift.NewDecoder(f.protocol.NewReader(r)).Decode(&f.metadata); err != nil {
+			return fmt.Errorf("reading parquet file metadata: %w", err)
+		}
+		if !isEncryptedFile(&f.metadata) {
+			return nil
+		}
+		// Only the footers of encrypted files are followed by a signature,
+		// the bytes trailing the metadata of other files are ignored.
+		if r.Len() != footerSignatureLength {
+			return fmt.Errorf("reading parquet file metadata: footer signature must be %d bytes but got %d", footerSignatureLength, r.Len())
+		}
+		d, err := newFileDecryptor(&f.metadata.EncryptionAlgorithm, f.config.DecryptionKeys)
+		if err != nil {
+			return err
+		}
+		// The footer signature can only be verified when the footer key was
+		// given, programs may still read the plaintext columns without it.
+		if _, ok := d.keys[""]; ok {
+			footerLength := len(footerData) - footerSignatureLength
+			if err := d.verifyFooter(footerData[:footerLength], footerData[footerLength:]); err != nil {
+				return err
+			}
+		}
+		f.decryptor = d
+	}
+
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			c := &f.metadata.RowGroups[i].Columns[j]
+			if len(c.EncryptedColumnMetadata) == 0 {
+				continue
+			}
+			b, err := f.decryptor.decryptColumnMetaData(c, int16(i), int16(j))
+			if err != nil {
+				// Programs may read the other columns without the key of
+				// this one, the column chunk remains encrypted and reading
+				// it reports an error.
+				if errors.Is(err, ErrMissingDecryptionKey) {
+					continue
+				}
+				return err
+			}
+			c.MetaData = format.ColumnMetaData{}
+			if err := thrift.Unmarshal(&f.protocol, b, &c.MetaData); err != nil {
+				return fmt.Errorf("decoding metadata of column chunk: rowGroup=%d columnChunk=%d: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// errorPages is an implementation of the Pages interface which reports the
+// same error on every operation.
+type errorPages struct{ err error }
+
+func (p *errorPages) ReadPage() (Page, error) { return nil, p.err }
+func (p *errorPages) SeekToRow(_ int64) error { return p.err }
+func (p *errorPages) Close() error            { return nil }
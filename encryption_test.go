@@ -0,0 +1,333 @@
+package parquet_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/segmentio/encoding/thrift"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+var (
+	testFooterKey = []byte("0123456789abcdef")
+	testColumnKey = []byte("fedcba9876543210")
+	testAadUnique = []byte("unique")
+)
+
+type encryptedRow struct {
+	Name   string `parquet:"name"`
+	Secret int64  `parquet:"secret"`
+}
+
+// encryptFooter rewrites the parquet file in b to use an encrypted footer,
+// encrypting the metadata of the "secret" column with its own key.
+//
+// Only the footer and column metadata are encrypted, which is all that the
+// package supports reading at this time.
+func encryptFooter(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	protocol := new(thrift.CompactProtocol)
+	metadata := *f.Metadata()
+	metadata.RowGroups = append([]format.RowGroup{}, metadata.RowGroups...)
+	for i := range metadata.RowGroups {
+		rowGroup := &metadata.RowGroups[i]
+		rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+		chunk := &rowGroup.Columns[1]
+
+		columnMetaData, err := thrift.Marshal(protocol, &chunk.MetaData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aad := append(append([]byte{}, testAadUnique...), 1, byte(i), 0, 1, 0)
+		chunk.CryptoMetadata.EncryptionWithColumnKey = &format.EncryptionWithColumnKey{
+			PathInSchema: chunk.MetaData.PathInSchema,
+		}
+		chunk.EncryptedColumnMetadata = encryptModule(t, testColumnKey, columnMetaData, aad)
+		chunk.MetaData = format.ColumnMetaData{}
+	}
+
+	footer, err := thrift.Marshal(protocol, &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crypto, err := thrift.Marshal(protocol, &format.FileCryptoMetaData{
+		EncryptionAlgorithm: format.EncryptionAlgorithm{
+			AesGcmV1: &format.AesGcmV1{AadFileUnique: testAadUnique},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	footerSize := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	footerModule := encryptModule(t, testFooterKey, footer, append(append([]byte{}, testAadUnique...), 0))
+
+	output := new(bytes.Buffer)
+	output.WriteString("PARE")
+	output.Write(b[4 : len(b)-(footerSize+8)])
+	output.Write(crypto)
+	output.Write(footerModule)
+	binary.Write(output, binary.LittleEndian, uint32(len(crypto)+len(footerModule)))
+	output.WriteString("PARE")
+	return output.Bytes()
+}
+
+func encryptModule(t *testing.T, key, data, aad []byte) []byte {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	sealed := gcm.Seal(nonce, nonce, data, aad)
+	module := binary.LittleEndian.AppendUint32(nil, uint32(len(sealed)))
+	return append(module, sealed...)
+}
+
+func createEncryptedFile(t *testing.T) []byte {
+	buffer := new(bytes.Buffer)
+	rows := []encryptedRow{
+		{Name: "A", Secret: 1},
+		{Name: "B", Secret: 2},
+		{Name: "C", Secret: 3},
+	}
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	return encryptFooter(t, buffer.Bytes())
+}
+
+func TestOpenFileEncryptedFooter(t *testing.T) {
+	b := createEncryptedFile(t)
+
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)),
+		parquet.DecryptionKeys(map[string][]byte{
+			"":       testFooterKey,
+			"secret": testColumnKey,
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if numRows := f.NumRows(); numRows != 3 {
+		t.Errorf("wrong number of rows: want=3 got=%d", numRows)
+	}
+
+	columnChunks := f.RowGroups()[0].ColumnChunks()
+	if numValues := columnChunks[1].NumValues(); numValues != 3 {
+		t.Errorf("wrong number of values in decrypted column metadata: want=3 got=%d", numValues)
+	}
+
+	pages := columnChunks[0].Pages()
+	defer pages.Close()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]parquet.Value, page.NumValues())
+	if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"A", "B", "C"} {
+		if got := values[i].String(); got != want {
+			t.Errorf("wrong value at index %d: want=%q got=%q", i, want, got)
+		}
+	}
+
+	encryptedPages := columnChunks[1].Pages()
+	defer encryptedPages.Close()
+	if _, err := encryptedPages.ReadPage(); !errors.Is(err, parquet.ErrEncryptedColumn) {
+		t.Errorf("reading pages of encrypted column: want=%v got=%v", parquet.ErrEncryptedColumn, err)
+	}
+}
+
+func TestOpenFileEncryptedFooterErrors(t *testing.T) {
+	b := createEncryptedFile(t)
+	wrongKey := []byte("0000000000000000")
+
+	tests := []struct {
+		scenario string
+		keys     map[string][]byte
+		err      error
+	}{
+		{
+			scenario: "no keys",
+			keys:     nil,
+			err:      parquet.ErrMissingDecryptionKey,
+		},
+		{
+			scenario: "wrong footer key",
+			keys:     map[string][]byte{"": wrongKey, "secret": testColumnKey},
+			err:      parquet.ErrDecryptionFailed,
+		},
+		{
+			scenario: "wrong column key",
+			keys:     map[string][]byte{"": testFooterKey, "secret": wrongKey},
+			err:      parquet.ErrDecryptionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)), parquet.DecryptionKeys(test.keys))
+			if !errors.Is(err, test.err) {
+				t.Errorf("error mismatch: want=%v got=%v", test.err, err)
+			}
+		})
+	}
+
+	t.Run("missing column key", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)),
+			parquet.DecryptionKeys(map[string][]byte{"": testFooterKey}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages := f.RowGroups()[0].ColumnChunks()[1].Pages()
+		defer pages.Close()
+		if _, err := pages.ReadPage(); !errors.Is(err, parquet.ErrEncryptedColumn) {
+			t.Errorf("reading pages of column without its key: want=%v got=%v", parquet.ErrEncryptedColumn, err)
+		}
+	})
+
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)),
+			parquet.DecryptionKeys(map[string][]byte{"": []byte("short")}),
+		)
+		if err == nil {
+			t.Error("expected an error when opening a file with an invalid key size")
+		}
+	})
+}
+
+func TestOpenFilePlaintextFooterTrailingBytes(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, []encryptedRow{{Name: "A", Secret: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	b := buffer.Bytes()
+	footerSize := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+
+	// Some writers leave padding after the file metadata, which is only
+	// followed by a signature in encrypted files.
+	output := new(bytes.Buffer)
+	output.Write(b[:len(b)-8])
+	output.Write(make([]byte, 4))
+	binary.Write(output, binary.LittleEndian, uint32(footerSize+4))
+	output.WriteString("PAR1")
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.NumRows(); n != 1 {
+		t.Errorf("wrong number of rows: want=1 got=%d", n)
+	}
+}
+
+func TestOpenFilePlaintextFooterWithoutKeys(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	rows := []encryptedRow{{Name: "A", Secret: 1}, {Name: "B", Secret: 2}}
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	b := buffer.Bytes()
+
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Encrypt the metadata of the "secret" column with the footer key, and
+	// sign the plaintext footer.
+	protocol := new(thrift.CompactProtocol)
+	metadata := *f.Metadata()
+	metadata.EncryptionAlgorithm = format.EncryptionAlgorithm{
+		AesGcmV1: &format.AesGcmV1{AadFileUnique: testAadUnique},
+	}
+	metadata.RowGroups = append([]format.RowGroup{}, metadata.RowGroups...)
+	rowGroup := &metadata.RowGroups[0]
+	rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+	chunk := &rowGroup.Columns[1]
+	columnMetaData, err := thrift.Marshal(protocol, &chunk.MetaData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aad := append(append([]byte{}, testAadUnique...), 1, 0, 0, 1, 0)
+	chunk.CryptoMetadata.EncryptionWithFooterKey = &format.EncryptionWithFooterKey{}
+	chunk.EncryptedColumnMetadata = encryptModule(t, testFooterKey, columnMetaData, aad)
+	chunk.MetaData = format.ColumnMetaData{}
+
+	footer, err := thrift.Marshal(protocol, &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := encryptModule(t, testFooterKey, footer, append(append([]byte{}, testAadUnique...), 0))
+	module = module[4:] // strip the length prefix
+	nonce, tag := module[:12], module[len(module)-16:]
+
+	footerSize := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	output := new(bytes.Buffer)
+	output.Write(b[:len(b)-(footerSize+8)])
+	output.Write(footer)
+	output.Write(nonce)
+	output.Write(tag)
+	binary.Write(output, binary.LittleEndian, uint32(len(footer)+len(nonce)+len(tag)))
+	output.WriteString("PAR1")
+
+	f, err = parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columnChunks := f.RowGroups()[0].ColumnChunks()
+
+	pages := columnChunks[0].Pages()
+	defer pages.Close()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]parquet.Value, page.NumValues())
+	if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	for i, row := range rows {
+		if got := values[i].String(); got != row.Name {
+			t.Errorf("wrong value at index %d: want=%q got=%q", i, row.Name, got)
+		}
+	}
+
+	encryptedPages := columnChunks[1].Pages()
+	defer encryptedPages.Close()
+	if _, err := encryptedPages.ReadPage(); !errors.Is(err, parquet.ErrEncryptedColumn) {
+		t.Errorf("reading pages of encrypted column: want=%v got=%v", parquet.ErrEncryptedColumn, err)
+	}
+
+	// The signature is verified when the footer key is given.
+	f, err = parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()),
+		parquet.DecryptionKeys(map[string][]byte{"": testFooterKey}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.RowGroups()[0].ColumnChunks()[1].NumValues(); n != int64(len(rows)) {
+		t.Errorf("wrong number of values in decrypted column metadata: want=%d got=%d", len(rows), n)
+	}
+}
@@ -12,6 +12,15 @@ var (
 	// data.
 	ErrCorrupted = errors.New("corrupted parquet page")
 
+	// ErrCorruptedPage is an error returned when reading a page of which the
+	// CRC32 checksum does not match its content. Errors wrapping
+	// ErrCorruptedPage also wrap ErrCorrupted.
+	ErrCorruptedPage = errors.New("parquet page checksum mismatch")
+
+	// ErrInvalidFile is an error wrapped by the violations of the parquet
+	// specification reported by Validate.
+	ErrInvalidFile = errors.New("invalid parquet file")
+
 	// ErrMissingRootColumn is an error returned when opening an invalid parquet
 	// file which does not have a root column.
 	ErrMissingRootColumn = errors.New("parquet file is missing a root column")
@@ -29,6 +38,24 @@ var (
 	// destination.
 	ErrRowGroupSortingColumnsMismatch = errors.New("cannot write row groups with mismatching sorting columns")
 
+	// ErrRowsNotSorted is an error returned by writers configured with the
+	// AssertSorted option when rows are not written in the order of the
+	// sorting columns.
+	ErrRowsNotSorted = errors.New("rows are not sorted by the sorting columns")
+
+	// ErrSchemaMismatch is an error returned by Schema.Compatible when two
+	// schemas are not compatible.
+	ErrSchemaMismatch = errors.New("incompatible parquet schemas")
+
+	// ErrDuplicateColumn is an error raised when constructing a schema where
+	// multiple columns of the same group have the same name.
+	ErrDuplicateColumn = errors.New("duplicate parquet column name")
+
+	// ErrCorruptedPageIndex is an error wrapped by the warnings reported when
+	// the page index of a parquet file is malformed; the page index is then
+	// ignored and the pages of the file are read sequentially.
+	ErrCorruptedPageIndex = errors.New("corrupted parquet page index")
+
 	// ErrSeekOutOfRange is an error returned when seeking to a row index which
 	// is less than the first row of a page.
 	ErrSeekOutOfRange = errors.New("seek to row index out of page range")
@@ -59,6 +86,21 @@ var (
 	// cannot be done because there are no rules to translate between their
 	// physical types.
 	ErrInvalidConversion = errors.New("invalid conversion between parquet values")
+
+	// ErrMissingDecryptionKey is returned when opening an encrypted parquet
+	// file without providing the key needed to decrypt its footer.
+	// Columns of which the key was not provided remain encrypted, reading
+	// them returns ErrEncryptedColumn.
+	ErrMissingDecryptionKey = errors.New("missing parquet decryption key")
+
+	// ErrDecryptionFailed is returned when an encrypted module of a parquet
+	// file could not be authenticated, which indicates that the decryption
+	// key is wrong or that the encrypted data was tampered with.
+	ErrDecryptionFailed = errors.New("parquet decryption failed")
+
+	// ErrEncryptedColumn is returned when attempting to read the pages of an
+	// encrypted column chunk, which is not supported yet.
+	ErrEncryptedColumn = errors.New("reading pages of encrypted parquet columns is not supported")
 )
 
 type errno int
@@ -1,6 +1,7 @@
 package parquet_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -59,3 +60,38 @@ func Example() {
 	fmt.Println(addrs[0].Owner)
 	// Output: UserA
 }
+
+func ExampleMaxRowsPerRowGroup() {
+	type Event struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	events := make([]Event, 2500)
+	for i := range events {
+		events[i] = Event{ID: int64(i), Name: fmt.Sprintf("event-%d", i)}
+	}
+
+	// The writer flushes a row group each time it reaches 1000 rows, there is
+	// no need to call Flush while writing the rows.
+	output := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Event](output, parquet.MaxRowsPerRowGroup(1000))
+	if _, err := writer.Write(events); err != nil {
+		log.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, rowGroup := range f.RowGroups() {
+		fmt.Println(rowGroup.NumRows())
+	}
+	// Output:
+	// 1000
+	// 1000
+	// 500
+}
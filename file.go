@@ -32,6 +32,8 @@ type File struct {
 	offsetIndexes []format.OffsetIndex
 	rowGroups     []RowGroup
 	config        *FileConfig
+	decryptor     *fileDecryptor
+	buffers       *bufferPool
 }
 
 // OpenFile opens a parquet file and reads the content between offset 0 and the given
@@ -40,6 +42,13 @@ type File struct {
 // Only the parquet magic bytes and footer are read, column chunks and other
 // parts of the file are left untouched; this means that successfully opening
 // a file does not validate that the pages have valid checksums.
+//
+// A malformed page index does not prevent opening the file, it is ignored for
+// the column chunks that it describes, and reported to the OnWarning callback
+// of the file configuration.
+//
+// The function returns an error wrapping ErrDuplicateColumn if a group of the
+// file schema has multiple columns of the same name.
 func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	b := make([]byte, 8)
 	c, err := NewFileConfig(options...)
@@ -47,25 +56,36 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		return nil, err
 	}
 	f := &File{reader: r, size: size, config: c}
+	if c.MemoryPool != nil {
+		f.buffers = &bufferPool{memory: c.MemoryPool}
+	}
 
 	if _, err := readAt(r, b[:4], 0); err != nil {
 		return nil, fmt.Errorf("reading magic header of parquet file: %w", err)
 	}
-	if string(b[:4]) != "PAR1" {
+	// Files with encrypted footers use the "PARE" magic instead of "PAR1".
+	magic := string(b[:4])
+	if magic != "PAR1" && magic != "PARE" {
 		return nil, fmt.Errorf("invalid magic header of parquet file: %q", b[:4])
 	}
 
 	if cast, ok := f.reader.(interface{ SetMagicFooterSection(offset, length int64) }); ok {
 		cast.SetMagicFooterSection(size-8, 8)
 	}
-	if n, err := r.ReadAt(b[:8], size-8); n != 8 {
+	if _, err := readAt(r, b[:8], size-8); err != nil {
 		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
 	}
-	if string(b[4:8]) != "PAR1" {
+	if string(b[4:8]) != magic {
 		return nil, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
 	}
 
+	// The footer is read with the length recorded before the magic footer, it
+	// may be much larger than any read-ahead buffer for files with thousands
+	// of columns.
 	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
+	if footerSize > size-12 {
+		return nil, fmt.Errorf("invalid footer of parquet file: %d bytes footer exceeds the size of the file of %d bytes", footerSize, size)
+	}
 	footerData := make([]byte, footerSize)
 
 	if cast, ok := f.reader.(interface{ SetFooterSection(offset, length int64) }); ok {
@@ -74,16 +94,20 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	if _, err := f.readAt(footerData, size-(footerSize+8)); err != nil {
 		return nil, fmt.Errorf("reading footer of parquet file: %w", err)
 	}
-	if err := thrift.Unmarshal(&f.protocol, footerData, &f.metadata); err != nil {
-		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+	if err := f.decodeFooter(footerData, magic == "PARE"); err != nil {
+		return nil, err
 	}
 	if len(f.metadata.Schema) == 0 {
 		return nil, ErrMissingRootColumn
 	}
 
 	if !c.SkipPageIndex {
+		// The page index is only used to search and seek through the pages
+		// of column chunks; when it is malformed, the file remains readable
+		// by decoding its pages sequentially.
 		if f.columnIndexes, f.offsetIndexes, err = f.ReadPageIndex(); err != nil {
-			return nil, fmt.Errorf("reading page index of parquet file: %w", err)
+			c.warn(fmt.Errorf("%w: reading page index of parquet file: %w", ErrCorruptedPageIndex, err))
+			f.columnIndexes, f.offsetIndexes = nil, nil
 		}
 	}
 
@@ -95,7 +119,12 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	if c.Schema != nil {
 		schema = c.Schema
 	} else {
-		schema = NewSchema(f.root.Name(), f.root)
+		// The schema of the file is not trusted, duplicate column names are
+		// reported as errors instead of causing NewSchema to panic.
+		if err := checkDuplicateColumns(f.root); err != nil {
+			return nil, fmt.Errorf("reading schema of parquet file: %w", err)
+		}
+		schema = newSchema(f.root.Name(), f.root)
 	}
 	columns := make([]*Column, 0, numLeafColumnsOf(f.root))
 	f.schema = schema
@@ -125,6 +154,10 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 			for j := range g.columns {
 				c := g.columns[j].(*fileColumnChunk)
 
+				if isEncryptedColumnChunk(c.chunk) {
+					continue
+				}
+
 				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
 					section.Seek(offset, io.SeekStart)
 					rbuf.Reset(section)
@@ -143,7 +176,7 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 						cast.SetBloomFilterSection(bloomFilterOffset, bloomFilterLength)
 					}
 
-					c.bloomFilter = newBloomFilter(r, offset, &header)
+					c.bloomFilter = newBloomFilter(r, offset, c.Type(), &header)
 				}
 			}
 		}
@@ -153,6 +186,128 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	return f, nil
 }
 
+// OpenFileSeeker opens a parquet file from a reader which supports seeking but
+// whose size is not known in advance, for example a stream returned by a
+// library which does not expose the size of its source.
+//
+// The size of the file is determined by seeking to the end of rs; the parquet
+// file must span from offset zero to the end of rs. The position of rs is
+// restored when the function returns successfully, and after each read of the
+// returned File, so programs can keep using rs after opening the file.
+//
+// When rs also implements io.ReaderAt, reads of the returned File use its
+// ReadAt method. Otherwise the reads are serialized and each of them seeks rs
+// to the offset being read, which is less efficient; programs which have
+// access to an io.ReaderAt should prefer using OpenFile.
+func OpenFileSeeker(rs io.ReadSeeker, options ...FileOption) (*File, error) {
+	pos, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rs.Seek(pos, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r, ok := rs.(io.ReaderAt)
+	if !ok {
+		r = &seekReaderAt{rs: rs}
+	}
+	return OpenFile(r, size, options...)
+}
+
+// seekReaderAt adapts an io.ReadSeeker to the io.ReaderAt interface. Reads are
+// serialized and restore the position of the underlying reader.
+type seekReaderAt struct {
+	mutex sync.Mutex
+	rs    io.ReadSeeker
+}
+
+func (r *seekReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	pos, err := r.rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.rs.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.ReadFull(r.rs, b)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	if _, seekErr := r.rs.Seek(pos, io.SeekStart); seekErr != nil && err == nil {
+		err = seekErr
+	}
+	return n, err
+}
+
+// NumRows returns the number of rows of the parquet file of the given size in r.
+//
+// The function only reads the magic bytes and footer of the file and sums the
+// number of rows of its row groups. Unlike OpenFile, it does not read the page
+// index or bloom filter headers, nor does it construct the schema and columns
+// of the file, which makes it much cheaper when programs only need to know the
+// number of rows.
+//
+// Files with encrypted footers cannot be inspected without being decrypted,
+// the function returns an error wrapping ErrMissingDecryptionKey for them.
+func NumRows(r io.ReaderAt, size int64) (int64, error) {
+	b := make([]byte, 8)
+	if _, err := readAt(r, b[:4], 0); err != nil {
+		return 0, fmt.Errorf("reading magic header of parquet file: %w", err)
+	}
+	magic := string(b[:4])
+	switch magic {
+	case "PAR1":
+	case "PARE":
+		return 0, fmt.Errorf("reading number of rows of parquet file with encrypted footer: %w", ErrMissingDecryptionKey)
+	default:
+		return 0, fmt.Errorf("invalid magic header of parquet file: %q", b[:4])
+	}
+	if n, err := r.ReadAt(b[:8], size-8); n != 8 {
+		return 0, fmt.Errorf("reading magic footer of parquet file: %w", err)
+	}
+	if string(b[4:8]) != magic {
+		return 0, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
+	if footerSize > size-12 {
+		return 0, fmt.Errorf("invalid footer size of parquet file: %d", footerSize)
+	}
+	footerData := make([]byte, footerSize)
+	if _, err := readAt(r, footerData, size-(footerSize+8)); err != nil {
+		return 0, fmt.Errorf("reading footer of parquet file: %w", err)
+	}
+
+	// Only the number of rows of the row groups are needed, the other fields
+	// of the file metadata are skipped, except those holding boolean fields
+	// since the thrift decoder cannot skip them in the compact protocol.
+	metadata := struct {
+		Schema    []format.SchemaElement `thrift:"2"`
+		RowGroups []struct {
+			NumRows        int64                  `thrift:"3"`
+			SortingColumns []format.SortingColumn `thrift:"4"`
+		} `thrift:"4"`
+		EncryptionAlgorithm format.EncryptionAlgorithm `thrift:"8"`
+	}{}
+	protocol := thrift.CompactProtocol{}
+	if err := thrift.Unmarshal(&protocol, footerData, &metadata); err != nil {
+		return 0, fmt.Errorf("reading parquet file metadata: %w", err)
+	}
+
+	numRows := int64(0)
+	for _, rowGroup := range metadata.RowGroups {
+		numRows += rowGroup.NumRows
+	}
+	return numRows, nil
+}
+
 // ReadPageIndex reads the page index section of the parquet file f.
 //
 // If the file did not contain a page index, the method returns two empty slices
@@ -234,9 +389,12 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 			//
 			// An example of this file is testdata/alltypes_tiny_pages_plain.parquet
 			// which was added in https://github.com/apache/parquet-testing/pull/24.
-			if c.ColumnIndexOffset > 0 {
+			if c.ColumnIndexOffset > 0 && !isEncryptedColumnChunk(c) {
 				offset := c.ColumnIndexOffset - columnIndexOffset
 				length := int64(c.ColumnIndexLength)
+				if offset < 0 || length < 0 || offset+length > columnIndexLength {
+					return fmt.Errorf("column index out of bounds: rowGroup=%d columnChunk=%d/%d: offset=%d length=%d", i, j, numColumns, c.ColumnIndexOffset, length)
+				}
 				buffer := columnIndexData[offset : offset+length]
 				if err := thrift.Unmarshal(&f.protocol, buffer, &columnIndexes[(i*numColumns)+j]); err != nil {
 					return fmt.Errorf("decoding column index: rowGroup=%d columnChunk=%d/%d: %w", i, j, numColumns, err)
@@ -260,9 +418,12 @@ func (f *File) ReadPageIndex() ([]format.ColumnIndex, []format.OffsetIndex, erro
 		}
 
 		err := forEachColumnChunk(func(i, j int, c *format.ColumnChunk) error {
-			if c.OffsetIndexOffset > 0 {
+			if c.OffsetIndexOffset > 0 && !isEncryptedColumnChunk(c) {
 				offset := c.OffsetIndexOffset - offsetIndexOffset
 				length := int64(c.OffsetIndexLength)
+				if offset < 0 || length < 0 || offset+length > offsetIndexLength {
+					return fmt.Errorf("offset index out of bounds: rowGroup=%d columnChunk=%d/%d: offset=%d length=%d", i, j, numColumns, c.OffsetIndexOffset, length)
+				}
 				buffer := offsetIndexData[offset : offset+length]
 				if err := thrift.Unmarshal(&f.protocol, buffer, &offsetIndexes[(i*numColumns)+j]); err != nil {
 					return fmt.Errorf("decoding column index: rowGroup=%d columnChunk=%d/%d: %w", i, j, numColumns, err)
@@ -284,6 +445,75 @@ func (f *File) NumRows() int64 { return f.metadata.NumRows }
 // RowGroups returns the list of row groups in the file.
 func (f *File) RowGroups() []RowGroup { return f.rowGroups }
 
+// RowGroupsMatching returns the row groups of f which may contain rows where
+// the values of the column at the given path match pred. The path is made of
+// the names of the column and its parent groups joined by dots.
+//
+// The row groups are selected using the min and max statistics of their column
+// chunks, which is cheaper but coarser than filtering pages with the column
+// index (see the Where reader option). Row groups of which the column chunk
+// has no statistics are always returned, while row groups of which the column
+// chunk only holds null values are never returned.
+//
+// The method returns an error if the column does not exist in the schema of f.
+func (f *File) RowGroupsMatching(column string, pred Predicate) ([]RowGroup, error) {
+	leaf, ok := f.schema.Lookup(strings.Split(column, ".")...)
+	if !ok {
+		return nil, fmt.Errorf("cannot filter row groups of parquet schema %s: column %q does not exist", f.schema.Name(), column)
+	}
+
+	rowGroups := make([]RowGroup, 0, len(f.rowGroups))
+	for _, rowGroup := range f.rowGroups {
+		chunk := rowGroup.ColumnChunks()[leaf.ColumnIndex].(*fileColumnChunk)
+		if chunk.mayMatch(leaf.Node.Type(), pred) {
+			rowGroups = append(rowGroups, rowGroup)
+		}
+	}
+	return rowGroups, nil
+}
+
+// ColumnBounds returns the min and max values of the column at the given path
+// across all the row groups of f. The path is made of the names of the column
+// and its parent groups joined by dots.
+//
+// The bounds are computed from the statistics of the column chunks, no pages
+// are read. The values are ordered according to the type of the column, which
+// accounts for its logical type, for example unsigned integers. Column chunks
+// which only hold null values do not contribute to the bounds.
+//
+// The method returns ok=false if any of the other column chunks has no min and
+// max statistics, since the bounds could then be wrong, or if the column only
+// holds null values.
+//
+// The method returns an error if the column does not exist in the schema of f.
+func (f *File) ColumnBounds(column string) (min, max Value, ok bool, err error) {
+	leaf, exists := f.schema.Lookup(strings.Split(column, ".")...)
+	if !exists {
+		return Value{}, Value{}, false, fmt.Errorf("cannot compute bounds of parquet schema %s: column %q does not exist", f.schema.Name(), column)
+	}
+	typ := leaf.Node.Type()
+
+	for _, rowGroup := range f.rowGroups {
+		chunk := rowGroup.ColumnChunks()[leaf.ColumnIndex].(*fileColumnChunk)
+		metaData := &chunk.chunk.MetaData
+		if metaData.Statistics.NullCount == metaData.NumValues {
+			continue
+		}
+		chunkMin, chunkMax, hasBounds := ColumnChunkMinMax(chunk)
+		if !hasBounds {
+			return Value{}, Value{}, false, nil
+		}
+		if !ok || typ.Compare(chunkMin, min) < 0 {
+			min = chunkMin
+		}
+		if !ok || typ.Compare(chunkMax, max) > 0 {
+			max = chunkMax
+		}
+		ok = true
+	}
+	return min, max, ok, nil
+}
+
 // Root returns the root column of f.
 func (f *File) Root() *Column { return f.root }
 
@@ -335,6 +565,57 @@ func (f *File) Lookup(key string) (value string, ok bool) {
 	return lookupKeyValueMetadata(f.metadata.KeyValueMetadata, key)
 }
 
+// KeyValueMetadata returns the key/value metadata of the file as a map.
+//
+// The map is a copy, it may be modified by the program. When keys are repeated
+// in the file, the map contains the value that Lookup returns.
+func (f *File) KeyValueMetadata() map[string]string {
+	metadata := make(map[string]string, len(f.metadata.KeyValueMetadata))
+	for _, kv := range f.metadata.KeyValueMetadata {
+		if _, ok := metadata[kv.Key]; !ok {
+			metadata[kv.Key] = kv.Value
+		}
+	}
+	return metadata
+}
+
+// Version returns the version of the parquet format declared in the footer of
+// the file.
+func (f *File) Version() int { return int(f.metadata.Version) }
+
+// FileFeatures describes the optional features used by a parquet file.
+//
+// The features are derived from the footer metadata of the file; for example
+// files written with the page index have column indexes and offset indexes
+// even if they were opened with the SkipPageIndex option.
+type FileFeatures struct {
+	// True if at least one column chunk of the file has a column index.
+	ColumnIndexes bool
+	// True if at least one column chunk of the file has an offset index.
+	OffsetIndexes bool
+	// True if at least one column chunk of the file has a bloom filter.
+	BloomFilters bool
+	// True if the footer or column chunks of the file are encrypted.
+	Encryption bool
+}
+
+// Features returns the optional features used by the file.
+func (f *File) Features() FileFeatures {
+	features := FileFeatures{
+		Encryption: f.decryptor != nil,
+	}
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			c := &f.metadata.RowGroups[i].Columns[j]
+			features.ColumnIndexes = features.ColumnIndexes || c.ColumnIndexOffset != 0
+			features.OffsetIndexes = features.OffsetIndexes || c.OffsetIndexOffset != 0
+			features.BloomFilters = features.BloomFilters || c.MetaData.BloomFilterOffset != 0
+			features.Encryption = features.Encryption || isEncryptedColumnChunk(c)
+		}
+	}
+	return features
+}
+
 func (f *File) hasIndexes() bool {
 	return f.columnIndexes != nil && f.offsetIndexes != nil
 }
@@ -381,17 +662,37 @@ func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGr
 	fileColumnChunks := make([]fileColumnChunk, len(rowGroup.Columns))
 
 	for i := range g.columns {
+		column := columns[i]
+		// The compression codec of columns is the one of their first chunk,
+		// but each column chunk may use a different codec (e.g. in files that
+		// were produced by merging others). When it happens, the column chunk
+		// gets its own copy of the column to decompress its pages.
+		if codec := rowGroup.Columns[i].MetaData.Codec; column.compression != nil && column.compression.CompressionCodec() != codec {
+			c := *column
+			c.compression = LookupCompressionCodec(codec)
+			column = &c
+		}
+
 		fileColumnChunks[i] = fileColumnChunk{
 			file:     file,
-			column:   columns[i],
+			column:   column,
 			rowGroup: rowGroup,
 			chunk:    &rowGroup.Columns[i],
 		}
 
-		if file.hasIndexes() {
+		if chunk := &rowGroup.Columns[i]; file.hasIndexes() && !isEncryptedColumnChunk(chunk) {
 			j := (int(rowGroup.Ordinal) * len(columns)) + i
-			fileColumnChunks[i].columnIndex = &file.columnIndexes[j]
-			fileColumnChunks[i].offsetIndex = &file.offsetIndexes[j]
+			columnIndex, offsetIndex := &file.columnIndexes[j], &file.offsetIndexes[j]
+			if err := validatePageIndex(rowGroup, chunk, columnIndex, offsetIndex); err != nil {
+				file.config.warn(fmt.Errorf("%w: rowGroup=%d columnChunk=%d/%d: %w", ErrCorruptedPageIndex, rowGroup.Ordinal, i, len(columns), err))
+			} else {
+				fileColumnChunks[i].columnIndex = columnIndex
+				// Column chunks without an offset index are read sequentially
+				// when seeking to a row.
+				if chunk.OffsetIndexOffset > 0 {
+					fileColumnChunks[i].offsetIndex = offsetIndex
+				}
+			}
 		}
 
 		g.columns[i] = &fileColumnChunks[i]
@@ -406,6 +707,52 @@ func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGr
 	}
 }
 
+// validatePageIndex returns an error if the column and offset indexes of a
+// column chunk are inconsistent with each other or with the column chunk, in
+// which case they cannot be used to search or seek through its pages.
+func validatePageIndex(rowGroup *format.RowGroup, chunk *format.ColumnChunk, columnIndex *format.ColumnIndex, offsetIndex *format.OffsetIndex) error {
+	numPages := len(offsetIndex.PageLocations)
+
+	if chunk.ColumnIndexOffset > 0 {
+		n := len(columnIndex.NullPages)
+		switch {
+		case len(columnIndex.MinValues) != n || len(columnIndex.MaxValues) != n:
+			return fmt.Errorf("column index has %d pages but %d min values and %d max values", n, len(columnIndex.MinValues), len(columnIndex.MaxValues))
+		case len(columnIndex.NullCounts) != 0 && len(columnIndex.NullCounts) != n:
+			return fmt.Errorf("column index has %d pages but %d null counts", n, len(columnIndex.NullCounts))
+		case chunk.OffsetIndexOffset > 0 && numPages != n:
+			return fmt.Errorf("column index has %d pages but offset index has %d pages", n, numPages)
+		}
+	}
+
+	if chunk.OffsetIndexOffset > 0 {
+		metaData := &chunk.MetaData
+		if numPages == 0 && metaData.NumValues > 0 {
+			return fmt.Errorf("offset index has no pages but the column chunk has %d values", metaData.NumValues)
+		}
+		chunkOffset := metaData.DataPageOffset
+		if metaData.DictionaryPageOffset > 0 && metaData.DictionaryPageOffset < chunkOffset {
+			chunkOffset = metaData.DictionaryPageOffset
+		}
+		chunkEnd := chunkOffset + metaData.TotalCompressedSize
+		firstRowIndex := int64(0)
+
+		for i, page := range offsetIndex.PageLocations {
+			switch {
+			case page.Offset < chunkOffset || page.CompressedPageSize < 0 || page.Offset+int64(page.CompressedPageSize) > chunkEnd:
+				return fmt.Errorf("page %d at offset %d of %d bytes is outside of the column chunk", i, page.Offset, page.CompressedPageSize)
+			case page.FirstRowIndex < firstRowIndex || (i == 0 && page.FirstRowIndex != 0):
+				return fmt.Errorf("page %d has first row index %d but the previous page starts at row %d", i, page.FirstRowIndex, firstRowIndex)
+			case page.FirstRowIndex >= rowGroup.NumRows:
+				return fmt.Errorf("page %d has first row index %d but the row group has %d rows", i, page.FirstRowIndex, rowGroup.NumRows)
+			}
+			firstRowIndex = page.FirstRowIndex
+		}
+	}
+
+	return nil
+}
+
 func (g *fileRowGroup) Schema() *Schema                 { return g.schema }
 func (g *fileRowGroup) NumRows() int64                  { return g.rowGroup.NumRows }
 func (g *fileRowGroup) ColumnChunks() []ColumnChunk     { return g.columns }
@@ -455,6 +802,9 @@ func (c *fileColumnChunk) Column() int {
 }
 
 func (c *fileColumnChunk) Pages() Pages {
+	if isEncryptedColumnChunk(c.chunk) {
+		return &errorPages{err: fmt.Errorf("%s: %w", columnPath(c.column.Path()), ErrEncryptedColumn)}
+	}
 	r := new(filePages)
 	r.init(c)
 	return r
@@ -485,6 +835,65 @@ func (c *fileColumnChunk) NumValues() int64 {
 	return c.chunk.MetaData.NumValues
 }
 
+// readDictionary reads the dictionary page of c, returning a nil dictionary if
+// the column chunk does not start with a dictionary page.
+func (c *fileColumnChunk) readDictionary() (Dictionary, error) {
+	if isEncryptedColumnChunk(c.chunk) {
+		return nil, fmt.Errorf("%s: %w", columnPath(c.column.Path()), ErrEncryptedColumn)
+	}
+	metaData := &c.chunk.MetaData
+	if metaData.DictionaryPageOffset == 0 && !hasDictionaryEncoding(metaData.Encoding) {
+		return nil, nil
+	}
+
+	pages := new(filePages)
+	pages.init(c)
+	defer pages.Close()
+
+	header := new(format.PageHeader)
+	if err := pages.decoder.Decode(header); err != nil {
+		return nil, fmt.Errorf("decoding page header of column %q: %w", pages.columnPath(), err)
+	}
+	if header.Type != format.DictionaryPage {
+		return nil, nil
+	}
+	data, err := pages.readPage(header, pages.rbuf)
+	if err != nil {
+		return nil, err
+	}
+	defer data.unref()
+
+	if err := pages.readDictionaryPage(header, data); err != nil {
+		return nil, fmt.Errorf("decoding dictionary page of column %q: %w", pages.columnPath(), err)
+	}
+	return pages.dictionary, nil
+}
+
+func hasDictionaryEncoding(encodings []format.Encoding) bool {
+	for _, e := range encodings {
+		if isDictionaryFormat(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// mayMatch returns true if the min and max statistics of c indicate that the
+// column chunk may contain values matching pred.
+func (c *fileColumnChunk) mayMatch(typ Type, pred Predicate) bool {
+	metaData := &c.chunk.MetaData
+	stats := &metaData.Statistics
+	if stats.NullCount == metaData.NumValues {
+		// Predicates never match null values.
+		return false
+	}
+	if stats.MinValue == nil || stats.MaxValue == nil {
+		return true
+	}
+	kind := typ.Kind()
+	return pred.MatchBounds(typ, kind.Value(stats.MinValue), kind.Value(stats.MaxValue))
+}
+
 type filePages struct {
 	chunk    *fileColumnChunk
 	rbuf     *bufio.Reader
@@ -607,12 +1016,11 @@ func (f *filePages) readDictionary() error {
 		return err
 	}
 
-	page := buffers.get(int(header.CompressedPageSize))
-	defer page.unref()
-
-	if _, err := io.ReadFull(rbuf, page.data); err != nil {
+	page, err := f.readPage(header, rbuf)
+	if err != nil {
 		return err
 	}
+	defer page.unref()
 
 	return f.readDictionaryPage(header, page)
 }
@@ -657,14 +1065,14 @@ func (f *filePages) readDataPageV2(header *format.PageHeader, page *buffer) (Pag
 }
 
 func (f *filePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*buffer, error) {
-	page := buffers.get(int(header.CompressedPageSize))
+	page := f.chunk.column.pool().get(int(header.CompressedPageSize))
 	defer page.unref()
 
 	if _, err := io.ReadFull(reader, page.data); err != nil {
 		return nil, err
 	}
 
-	if header.CRC != 0 {
+	if header.CRC != 0 && !f.chunk.file.config.SkipPageChecksums {
 		headerChecksum := uint32(header.CRC)
 		bufferChecksum := crc32.ChecksumIEEE(page.data)
 
@@ -677,10 +1085,11 @@ func (f *filePages) readPage(header *format.PageHeader, reader *bufio.Reader) (*
 			// For now, we assume these errors to be fatal, but we may
 			// revisit later and improve error handling to be more resilient
 			// to data corruption.
-			return nil, fmt.Errorf("crc32 checksum mismatch in page of column %q: want=0x%08X got=0x%08X: %w",
+			return nil, fmt.Errorf("crc32 checksum mismatch in page of column %q: want=0x%08X got=0x%08X: %w: %w",
 				f.columnPath(),
 				headerChecksum,
 				bufferChecksum,
+				ErrCorruptedPage,
 				ErrCorrupted,
 			)
 		}
@@ -780,6 +1189,18 @@ func (f *File) readAt(p []byte, off int64) (int, error) {
 
 func readAt(r io.ReaderAt, p []byte, off int64) (n int, err error) {
 	n, err = r.ReadAt(p, off)
+	// The io.ReaderAt contract requires returning an error on short reads, but
+	// some implementations, for example readers of remote objects, return
+	// fewer bytes than requested without error. The reads are repeated until
+	// p is filled, or the reader stops making progress.
+	for n < len(p) && err == nil {
+		var m int
+		m, err = r.ReadAt(p[n:], off+int64(n))
+		if m == 0 && err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		n += m
+	}
 	if n == len(p) {
 		err = nil
 		// p was fully read.There is no further need to check for errors. This
@@ -1,13 +1,21 @@
 package parquet_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
 )
 
 var testdataFiles []string
@@ -145,3 +153,899 @@ func TestFileKeyValueMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestFileRowGroupSortingColumns(t *testing.T) {
+	type Row struct {
+		Name  string `parquet:"name"`
+		Score *int64 `parquet:"score,optional"`
+		Other int64  `parquet:"other"`
+	}
+
+	score := func(v int64) *int64 { return &v }
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.SortingWriterConfig(
+			parquet.SortingColumns(
+				parquet.Ascending("name"),
+				parquet.NullsFirst(parquet.Descending("score")),
+			),
+		),
+	)
+	if _, err := w.Write([]Row{
+		{Name: "A", Score: nil},
+		{Name: "A", Score: score(2)},
+		{Name: "B", Score: score(1)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroups := f.RowGroups()
+	if len(rowGroups) != 1 {
+		t.Fatalf("wrong number of row groups: %d", len(rowGroups))
+	}
+
+	sortingColumns := rowGroups[0].SortingColumns()
+	want := []struct {
+		path       string
+		descending bool
+		nullsFirst bool
+	}{
+		{path: "name"},
+		{path: "score", descending: true, nullsFirst: true},
+	}
+	if len(sortingColumns) != len(want) {
+		t.Fatalf("wrong number of sorting columns: want=%d got=%d", len(want), len(sortingColumns))
+	}
+	for i, s := range sortingColumns {
+		path := strings.Join(s.Path(), ".")
+		if path != want[i].path || s.Descending() != want[i].descending || s.NullsFirst() != want[i].nullsFirst {
+			t.Errorf("sorting column %d mismatch: want=%+v got=%s", i, want[i], s)
+		}
+	}
+}
+
+func TestFileFeatures(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{Name: "A"}, {Name: "B"}, {Name: "C"}}
+
+	tests := []struct {
+		scenario string
+		options  []parquet.WriterOption
+		fileOpts []parquet.FileOption
+		want     parquet.FileFeatures
+	}{
+		{
+			scenario: "default",
+			want:     parquet.FileFeatures{ColumnIndexes: true, OffsetIndexes: true},
+		},
+		{
+			scenario: "bloom filters",
+			options:  []parquet.WriterOption{parquet.BloomFilters(parquet.SplitBlockFilter(10, "name"))},
+			want:     parquet.FileFeatures{ColumnIndexes: true, OffsetIndexes: true, BloomFilters: true},
+		},
+		{
+			scenario: "skip page index",
+			fileOpts: []parquet.FileOption{parquet.SkipPageIndex(true)},
+			want:     parquet.FileFeatures{ColumnIndexes: true, OffsetIndexes: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, test.options...); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), test.fileOpts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version := f.Version(); version != 1 {
+				t.Errorf("wrong file version: want=1 got=%d", version)
+			}
+			if features := f.Features(); features != test.want {
+				t.Errorf("wrong file features:\nwant: %+v\ngot:  %+v", test.want, features)
+			}
+		})
+	}
+
+	t.Run("encryption", func(t *testing.T) {
+		b := createEncryptedFile(t)
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)),
+			parquet.DecryptionKeys(map[string][]byte{
+				"":       testFooterKey,
+				"secret": testColumnKey,
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if features := f.Features(); !features.Encryption {
+			t.Errorf("encryption was not reported in the file features: %+v", features)
+		}
+	})
+}
+
+func TestFileKeyValueMetadataMap(t *testing.T) {
+	type Row struct {
+		Name string
+	}
+
+	f, err := createParquetFile(
+		makeRows([]Row{{Name: "A"}}),
+		parquet.KeyValueMetadataMap(map[string]string{"schema_version": "1", "source": "ingest"}),
+		parquet.KeyValueMetadataMap(map[string]string{"schema_version": "2"}),
+		parquet.KeyValueMetadata("answer", "42"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"schema_version": "2", "source": "ingest", "answer": "42"}
+	metadata := f.KeyValueMetadata()
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("key/value metadata mismatch:\nwant: %v\ngot:  %v", want, metadata)
+	}
+
+	metadata["answer"] = "0"
+	if value, _ := f.Lookup("answer"); value != "42" {
+		t.Errorf("modifying the returned map changed the file metadata: %q", value)
+	}
+}
+
+func TestFileColumnChunkCounts(t *testing.T) {
+	type Row struct {
+		ID   int64   `parquet:"id"`
+		Name *string `parquet:"name,optional"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		if i%3 != 0 {
+			name := fmt.Sprint(i)
+			rows[i].Name = &name
+		}
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf, parquet.DataPageVersion(version), parquet.PageBufferSize(128))
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks := f.RowGroups()[0].ColumnChunks()
+
+			// A null count of zero is not written to the statistics.
+			for i, want := range []int64{0, 34} {
+				n, ok := parquet.ColumnChunkNullCount(chunks[i])
+				if ok != (want != 0) {
+					t.Errorf("column %d: wrong presence of the null count: want=%t got=%t", i, want != 0, ok)
+				} else if n != want {
+					t.Errorf("column %d: wrong null count: want=%d got=%d", i, want, n)
+				}
+				if _, ok := parquet.ColumnChunkDistinctCount(chunks[i]); ok {
+					t.Errorf("column %d: unexpected distinct count", i)
+				}
+			}
+		})
+	}
+
+	t.Run("absent", func(t *testing.T) {
+		f, err := os.Open("testdata/alltypes_plain.parquet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		s, err := f.Stat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := parquet.OpenFile(f, s.Size())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, chunk := range p.RowGroups()[0].ColumnChunks() {
+			if _, ok := parquet.ColumnChunkNullCount(chunk); ok {
+				t.Errorf("column %d: unexpected null count", i)
+			}
+		}
+	})
+}
+
+func TestFileColumnChunkMinMax(t *testing.T) {
+	type Row struct {
+		Name  string    `parquet:"name"`
+		Price int64     `parquet:"price,decimal(2:18)"`
+		Time  time.Time `parquet:"time,timestamp(millisecond)"`
+		Empty *string   `parquet:"empty,optional"`
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			Name:  fmt.Sprintf("name-%02d", i),
+			Price: int64(1000 - i),
+			Time:  base.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.MaxRowsPerRowGroup(50))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroups := f.RowGroups()
+	if len(rowGroups) != 2 {
+		t.Fatalf("wrong number of row groups: %d", len(rowGroups))
+	}
+
+	check := func(t *testing.T, rowGroup parquet.RowGroup, first, last int) {
+		t.Helper()
+		chunks := rowGroup.ColumnChunks()
+
+		min, max, ok := parquet.ColumnChunkMinMax(chunks[0])
+		if !ok {
+			t.Fatal("missing min and max of the name column")
+		}
+		if min.String() != rows[first].Name || max.String() != rows[last].Name {
+			t.Errorf("wrong bounds of the name column: [%s:%s]", min, max)
+		}
+
+		min, max, ok = parquet.ColumnChunkMinMax(chunks[1])
+		if !ok {
+			t.Fatal("missing min and max of the price column")
+		}
+		if min.Int64() != rows[last].Price || max.Int64() != rows[first].Price {
+			t.Errorf("wrong bounds of the price column: [%s:%s]", min, max)
+		}
+
+		min, max, ok = parquet.ColumnChunkMinMax(chunks[2])
+		if !ok {
+			t.Fatal("missing min and max of the time column")
+		}
+		var minTime, maxTime time.Time
+		typ := chunks[2].Type()
+		if err := typ.AssignValue(reflect.ValueOf(&minTime).Elem(), min); err != nil {
+			t.Fatal(err)
+		}
+		if err := typ.AssignValue(reflect.ValueOf(&maxTime).Elem(), max); err != nil {
+			t.Fatal(err)
+		}
+		if !minTime.Equal(rows[first].Time) || !maxTime.Equal(rows[last].Time) {
+			t.Errorf("wrong bounds of the time column: [%s:%s]", minTime, maxTime)
+		}
+
+		if _, _, ok := parquet.ColumnChunkMinMax(chunks[3]); ok {
+			t.Error("unexpected min and max of a column of null values")
+		}
+	}
+
+	t.Run("row groups", func(t *testing.T) {
+		check(t, rowGroups[0], 0, 49)
+		check(t, rowGroups[1], 50, 99)
+	})
+
+	t.Run("multi row group", func(t *testing.T) {
+		check(t, parquet.MultiRowGroup(rowGroups...), 0, 99)
+	})
+
+	t.Run("buffer", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		for i, chunk := range buffer.ColumnChunks() {
+			if _, _, ok := parquet.ColumnChunkMinMax(chunk); ok {
+				t.Errorf("column %d: unexpected min and max of a buffer", i)
+			}
+		}
+	})
+}
+
+func TestOpenFileLargeFooter(t *testing.T) {
+	const numColumns = 5000
+	group := make(parquet.Group, numColumns)
+	for i := 0; i < numColumns; i++ {
+		group[fmt.Sprintf("column_%04d", i)] = parquet.Optional(parquet.Int(64))
+	}
+	schema := parquet.NewSchema("wide", group)
+
+	row := make(parquet.Row, numColumns)
+	for i := range row {
+		row[i] = parquet.Int64Value(int64(i)).Level(0, 1, i)
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, schema)
+	if _, err := w.WriteRows([]parquet.Row{row}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b := buf.Bytes()
+	footerSize := binary.LittleEndian.Uint32(b[len(b)-8:])
+
+	// The reader returns at most 4 KiB per read without error, which is not
+	// compliant with io.ReaderAt but common with readers of remote objects.
+	r := &shortReaderAt{ReaderAt: bytes.NewReader(b), max: 4096}
+	f, err := parquet.OpenFile(r, int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.Schema().Columns()); n != numColumns {
+		t.Errorf("wrong number of columns: want=%d got=%d", numColumns, n)
+	}
+	if int64(footerSize) <= r.max {
+		t.Errorf("the footer of %d bytes fits in a single read", footerSize)
+	}
+
+	rows := make([]parquet.Row, 1)
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	if n, err := reader.ReadRows(rows); n != 1 {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	if !rows[0].Equal(row) {
+		t.Error("the row read from the file does not match the row written")
+	}
+
+	t.Run("invalid size", func(t *testing.T) {
+		corrupted := append([]byte{}, b...)
+		binary.LittleEndian.PutUint32(corrupted[len(corrupted)-8:], uint32(len(corrupted)))
+		if _, err := parquet.OpenFile(bytes.NewReader(corrupted), int64(len(corrupted))); err == nil {
+			t.Error("expected an error opening a file with a footer larger than the file")
+		}
+	})
+}
+
+func TestOpenFileDuplicateColumns(t *testing.T) {
+	type Row struct {
+		A int64 `parquet:"a"`
+		B int64 `parquet:"b"`
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []Row{{A: 1, B: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	// Rename the second column of the footer to give it the same name as the
+	// first one, like a third-party writer could do.
+	metadata := *openFile(t, b).Metadata()
+	metadata.Schema = append([]format.SchemaElement{}, metadata.Schema...)
+	metadata.Schema[2].Name = "a"
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerSize := binary.LittleEndian.Uint32(b[len(b)-8:])
+	b = append([]byte{}, b[:len(b)-8-int(footerSize)]...)
+	b = append(b, footer...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(footer)))
+	b = append(b, "PAR1"...)
+
+	_, err = parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if !errors.Is(err, parquet.ErrDuplicateColumn) {
+		t.Fatalf("expected a duplicate column error, got %v", err)
+	}
+}
+
+type shortReaderAt struct {
+	io.ReaderAt
+	max int64
+}
+
+func (r *shortReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	if int64(len(b)) > r.max {
+		b = b[:r.max]
+	}
+	return r.ReaderAt.ReadAt(b, off)
+}
+
+type readAtRecorder struct {
+	io.ReaderAt
+	reads [][2]int64
+}
+
+func (r *readAtRecorder) ReadAt(b []byte, off int64) (int, error) {
+	r.reads = append(r.reads, [2]int64{off, off + int64(len(b))})
+	return r.ReaderAt.ReadAt(b, off)
+}
+
+func TestFileSeekToRowSkipsPages(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,snappy"`
+		Name string `parquet:"name,zstd"`
+	}
+
+	rows := make([]Row, 10_000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+	output := new(bytes.Buffer)
+	if err := parquet.Write(output, rows, parquet.PageBufferSize(1024)); err != nil {
+		t.Fatal(err)
+	}
+
+	input := &readAtRecorder{ReaderAt: bytes.NewReader(output.Bytes())}
+	f, err := parquet.OpenFile(input, int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const seekTo = 7000
+	// The byte ranges of the pages which precede the page containing the row
+	// that the reader seeks to, in all columns.
+	var skipped [][2]int64
+	for _, chunk := range f.RowGroups()[0].ColumnChunks() {
+		offsetIndex := chunk.OffsetIndex()
+		if offsetIndex.NumPages() < 10 {
+			t.Fatalf("too few pages to test seeking: %d", offsetIndex.NumPages())
+		}
+		i := 1
+		for i < offsetIndex.NumPages() && offsetIndex.FirstRowIndex(i) <= seekTo {
+			i++
+		}
+		skipped = append(skipped, [2]int64{offsetIndex.Offset(0), offsetIndex.Offset(i - 1)})
+	}
+
+	input.reads = nil
+	reader := parquet.NewGenericReader[Row](f)
+	defer reader.Close()
+	if err := reader.SeekToRow(seekTo); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]Row, 100)
+	if n, err := reader.Read(got); n != len(got) {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	if want := rows[seekTo : seekTo+len(got)]; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong rows after seeking:\nwant: %+v\ngot:  %+v", want[0], got[0])
+	}
+
+	for _, read := range input.reads {
+		for _, pages := range skipped {
+			if read[0] < pages[1] && read[1] > pages[0] {
+				t.Errorf("read of bytes [%d:%d] overlaps pages [%d:%d] which precede row %d", read[0], read[1], pages[0], pages[1], seekTo)
+			}
+		}
+	}
+}
+
+func TestFilePageChecksums(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id,plain"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].ID = int64(i)
+	}
+
+	write := func(options ...parquet.WriterOption) []byte {
+		output := new(bytes.Buffer)
+		if err := parquet.Write(output, rows, options...); err != nil {
+			t.Fatal(err)
+		}
+		return output.Bytes()
+	}
+	open := func(data []byte, options ...parquet.FileOption) *parquet.File {
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	read := func(f *parquet.File) error {
+		r := parquet.NewGenericReader[Row](f)
+		defer r.Close()
+		_, err := r.Read(make([]Row, len(rows)))
+		if err == io.EOF {
+			err = nil
+		}
+		return err
+	}
+	firstPageCRC := func(f *parquet.File) int32 {
+		pages := parquet.NewRawPageReader(f.RowGroups()[0].ColumnChunks()[0])
+		defer pages.Close()
+		header, _, err := pages.ReadRawPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return header.CRC
+	}
+
+	data := write()
+	if firstPageCRC(open(data)) == 0 {
+		t.Error("pages have no checksums by default")
+	}
+	if crc := firstPageCRC(open(write(parquet.PageChecksums(false)))); crc != 0 {
+		t.Errorf("pages have checksums when disabled: 0x%08X", crc)
+	}
+	if firstPageCRC(open(write(&parquet.WriterConfig{CreatedBy: "test"}))) == 0 {
+		t.Error("pages have no checksums when configured with a WriterConfig option")
+	}
+	if crc := firstPageCRC(open(write(parquet.PageChecksums(false), &parquet.WriterConfig{CreatedBy: "test"}))); crc != 0 {
+		t.Errorf("a WriterConfig option enabled checksums disabled by a previous option: 0x%08X", crc)
+	}
+
+	// Flip the last byte of the first page, which is part of the page data
+	// following the page header.
+	location := open(data).OffsetIndexes()[0].PageLocations[0]
+	corrupted := append([]byte(nil), data...)
+	corrupted[location.Offset+int64(location.CompressedPageSize)-1] ^= 0xFF
+
+	err := read(open(corrupted))
+	if !errors.Is(err, parquet.ErrCorruptedPage) || !errors.Is(err, parquet.ErrCorrupted) {
+		t.Errorf("wrong error reading a corrupted page: %v", err)
+	}
+	if err := read(open(corrupted, parquet.SkipPageChecksums(true))); err != nil {
+		t.Errorf("unexpected error reading a corrupted page without verifying checksums: %v", err)
+	}
+	if err := read(open(data)); err != nil {
+		t.Errorf("unexpected error reading valid pages: %v", err)
+	}
+}
+
+func TestNumRows(t *testing.T) {
+	for _, path := range testdataFiles {
+		t.Run(path, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			s, err := f.Stat()
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := parquet.OpenFile(f, s.Size())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := int64(0)
+			for _, rowGroup := range p.RowGroups() {
+				want += rowGroup.NumRows()
+			}
+			numRows, err := parquet.NumRows(f, s.Size())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if numRows != want {
+				t.Errorf("wrong number of rows: want=%d got=%d", want, numRows)
+			}
+		})
+	}
+
+	t.Run("row groups", func(t *testing.T) {
+		// The timestamp logical type and the sorting columns hold boolean
+		// fields, which the file metadata decoder must not skip.
+		type Row struct {
+			ID int64     `parquet:"id"`
+			At time.Time `parquet:"at,timestamp"`
+		}
+		rows := make([]Row, 25)
+		output := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Row](output,
+			parquet.MaxRowsPerRowGroup(10),
+			parquet.SortingWriterConfig(parquet.SortingColumns(parquet.Descending("id"))),
+		)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		numRows, err := parquet.NumRows(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if numRows != 25 {
+			t.Errorf("wrong number of rows: want=25 got=%d", numRows)
+		}
+	})
+
+	t.Run("invalid magic", func(t *testing.T) {
+		b := []byte("PAR2\x00\x00\x00\x00PAR2")
+		if _, err := parquet.NumRows(bytes.NewReader(b), int64(len(b))); err == nil {
+			t.Fatal("expected an error reading the number of rows of an invalid file")
+		}
+	})
+
+	t.Run("encrypted footer", func(t *testing.T) {
+		b := createEncryptedFile(t)
+		if _, err := parquet.NumRows(bytes.NewReader(b), int64(len(b))); !errors.Is(err, parquet.ErrMissingDecryptionKey) {
+			t.Fatalf("expected an error wrapping ErrMissingDecryptionKey, got %v", err)
+		}
+	})
+}
+
+func TestOpenFileSeeker(t *testing.T) {
+	type Row struct {
+		ID   int64
+		Name string
+	}
+	rows := []Row{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}, {ID: 3, Name: "three"}}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// The struct hides the ReadAt method of bytes.Reader so only the
+	// io.ReadSeeker interface is available.
+	rs := struct{ io.ReadSeeker }{bytes.NewReader(buf.Bytes())}
+	if _, err := rs.Seek(4, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFileSeeker(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Size() != int64(buf.Len()) {
+		t.Errorf("wrong file size: want=%d got=%d", buf.Len(), f.Size())
+	}
+	if pos, _ := rs.Seek(0, io.SeekCurrent); pos != 4 {
+		t.Errorf("the position of the reader was not restored after opening the file: %d", pos)
+	}
+
+	r := parquet.NewGenericReader[Row](f)
+	defer r.Close()
+	got := make([]Row, len(rows))
+	if n, err := r.Read(got); n != len(rows) {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	assertRowsEqual(t, rows, got)
+
+	if pos, _ := rs.Seek(0, io.SeekCurrent); pos != 4 {
+		t.Errorf("the position of the reader was not restored after reading rows: %d", pos)
+	}
+
+	t.Run("invalid file", func(t *testing.T) {
+		if _, err := parquet.OpenFileSeeker(strings.NewReader("not a parquet file")); err == nil {
+			t.Error("expected an error opening an invalid file")
+		}
+	})
+}
+
+func TestFileColumnBounds(t *testing.T) {
+	type Row struct {
+		Count uint32  `parquet:"count"`
+		Name  string  `parquet:"name"`
+		Note  *string `parquet:"note,optional"`
+	}
+	note := "x"
+	rows := []Row{
+		{Count: 10, Name: "m", Note: &note},
+		{Count: 20, Name: "n"},
+		{Count: 3_000_000_000, Name: "b"},
+		{Count: 5, Name: "z"},
+		{Count: 7, Name: "k"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.MaxRowsPerRowGroup(2)); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	f := openFile(t, b)
+	if n := len(f.RowGroups()); n != 3 {
+		t.Fatalf("wrong number of row groups: %d", n)
+	}
+
+	tests := []struct {
+		column   string
+		min, max parquet.Value
+	}{
+		// The values of the count column are unsigned, the largest one would
+		// be the smallest if they were compared as signed integers.
+		{"count", parquet.Int32Value(5), parquet.Int32Value(-1294967296)},
+		{"name", parquet.ByteArrayValue([]byte("b")), parquet.ByteArrayValue([]byte("z"))},
+		// The row groups where the note column only holds nulls are ignored.
+		{"note", parquet.ByteArrayValue([]byte("x")), parquet.ByteArrayValue([]byte("x"))},
+	}
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			min, max, ok, err := f.ColumnBounds(test.column)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("missing bounds")
+			}
+			if !parquet.Equal(min, test.min) || !parquet.Equal(max, test.max) {
+				t.Errorf("wrong bounds: want=[%v,%v] got=[%v,%v]", test.min, test.max, min, max)
+			}
+		})
+	}
+
+	t.Run("missing statistics", func(t *testing.T) {
+		metadata := *f.Metadata()
+		metadata.RowGroups = append([]format.RowGroup{}, metadata.RowGroups...)
+		rowGroup := &metadata.RowGroups[1]
+		rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+		rowGroup.Columns[1].MetaData.Statistics = format.Statistics{}
+
+		footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		footerSize := binary.LittleEndian.Uint32(b[len(b)-8:])
+		c := append([]byte{}, b[:len(b)-8-int(footerSize)]...)
+		c = append(c, footer...)
+		c = binary.LittleEndian.AppendUint32(c, uint32(len(footer)))
+		c = append(c, "PAR1"...)
+
+		f := openFile(t, c)
+		if min, max, ok, err := f.ColumnBounds("name"); err != nil || ok {
+			t.Errorf("unexpected bounds of a column with missing statistics: [%v,%v] (err=%v)", min, max, err)
+		}
+		if _, _, ok, err := f.ColumnBounds("count"); err != nil || !ok {
+			t.Errorf("missing bounds of a column with statistics (err=%v)", err)
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		if _, _, _, err := f.ColumnBounds("missing"); err == nil {
+			t.Error("expected an error computing the bounds of a column which does not exist")
+		}
+	})
+}
+
+func TestOpenFileCorruptedPageIndex(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("row-%04d", i)}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256), parquet.MaxRowsPerRowGroup(500)); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	f := openFile(t, b)
+	if f.ColumnIndexes() == nil {
+		t.Fatal("the file has no page index")
+	}
+
+	// rewrite appends the column indexes modified by the function to the file,
+	// then a footer pointing to them.
+	rewrite := func(modify func(*format.FileMetaData, []format.ColumnIndex)) []byte {
+		metadata := *f.Metadata()
+		metadata.RowGroups = append([]format.RowGroup{}, metadata.RowGroups...)
+		for i := range metadata.RowGroups {
+			rowGroup := &metadata.RowGroups[i]
+			rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+		}
+		columnIndexes := append([]format.ColumnIndex{}, f.ColumnIndexes()...)
+
+		footerSize := binary.LittleEndian.Uint32(b[len(b)-8:])
+		c := append([]byte{}, b[:len(b)-8-int(footerSize)]...)
+		numColumns := len(metadata.RowGroups[0].Columns)
+		modify(&metadata, columnIndexes)
+
+		for i := range columnIndexes {
+			data, err := thrift.Marshal(new(thrift.CompactProtocol), &columnIndexes[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunk := &metadata.RowGroups[i/numColumns].Columns[i%numColumns]
+			length := int32(len(data))
+			if chunk.ColumnIndexLength < length {
+				length = chunk.ColumnIndexLength
+			}
+			chunk.ColumnIndexOffset = int64(len(c))
+			chunk.ColumnIndexLength = length
+			c = append(c, data...)
+		}
+
+		footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c = append(c, footer...)
+		c = binary.LittleEndian.AppendUint32(c, uint32(len(footer)))
+		return append(c, "PAR1"...)
+	}
+
+	tests := []struct {
+		scenario string
+		modify   func(*format.FileMetaData, []format.ColumnIndex)
+		// indexes of the column chunks expected to have a column index
+		indexed []int
+	}{
+		{
+			scenario: "truncated column index",
+			modify: func(metadata *format.FileMetaData, _ []format.ColumnIndex) {
+				metadata.RowGroups[1].Columns[1].ColumnIndexLength /= 2
+			},
+		},
+		{
+			scenario: "missing max values",
+			modify: func(_ *format.FileMetaData, columnIndexes []format.ColumnIndex) {
+				columnIndexes[0].MaxValues = columnIndexes[0].MaxValues[:1]
+			},
+			indexed: []int{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			c := rewrite(test.modify)
+
+			var warnings []error
+			f, err := parquet.OpenFile(bytes.NewReader(c), int64(len(c)), parquet.OnWarning(func(err error) {
+				warnings = append(warnings, err)
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) != 1 || !errors.Is(warnings[0], parquet.ErrCorruptedPageIndex) {
+				t.Fatalf("wrong warnings: %v", warnings)
+			}
+
+			var indexed []int
+			for i, rowGroup := range f.RowGroups() {
+				for j, chunk := range rowGroup.ColumnChunks() {
+					if chunk.ColumnIndex() != nil {
+						indexed = append(indexed, i*2+j)
+					}
+				}
+			}
+			if !reflect.DeepEqual(indexed, test.indexed) {
+				t.Errorf("wrong column chunks with a column index: want=%v got=%v", test.indexed, indexed)
+			}
+
+			reader := parquet.NewGenericReader[Row](f)
+			defer reader.Close()
+			if err := reader.SeekToRow(250); err != nil {
+				t.Fatal(err)
+			}
+			got := make([]Row, 500)
+			n, err := reader.Read(got)
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			if n == 0 || !reflect.DeepEqual(got[:n], rows[250:250+n]) {
+				t.Errorf("wrong rows read after seeking to row 250: %+v", got[:n])
+			}
+		})
+	}
+}
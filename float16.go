@@ -0,0 +1,341 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+// Float16 constructs a leaf node of FLOAT16 logical type.
+//
+// FLOAT16 values are IEEE 754 half precision floating point numbers stored as
+// little-endian FIXED_LEN_BYTE_ARRAY(2) values. Go values of uint16 types are
+// exchanged with the raw 2 bytes representation of the numbers, while float32
+// and float64 values are converted from and to half precision.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#float16
+func Float16() Node { return Leaf(&float16Type{}) }
+
+type float16Type format.Float16Type
+
+var float16BaseType = fixedLenByteArrayType{length: 2}
+
+func (t *float16Type) String() string { return (*format.Float16Type)(t).String() }
+
+func (t *float16Type) Kind() Kind { return float16BaseType.Kind() }
+
+func (t *float16Type) Length() int { return float16BaseType.Length() }
+
+func (t *float16Type) EstimateSize(n int) int { return float16BaseType.EstimateSize(n) }
+
+func (t *float16Type) EstimateNumValues(n int) int { return float16BaseType.EstimateNumValues(n) }
+
+func (t *float16Type) Compare(a, b Value) int {
+	return compareFloat32(float16ValueOf(a), float16ValueOf(b))
+}
+
+func (t *float16Type) ColumnOrder() *format.ColumnOrder { return &typeDefinedColumnOrder }
+
+func (t *float16Type) PhysicalType() *format.Type { return &physicalTypes[FixedLenByteArray] }
+
+func (t *float16Type) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Float16: (*format.Float16Type)(t)}
+}
+
+func (t *float16Type) ConvertedType() *deprecated.ConvertedType { return nil }
+
+func (t *float16Type) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	// Truncating values of two bytes would break the ordering of the numbers,
+	// the size limit is ignored.
+	return &float16ColumnIndexer{newFixedLenByteArrayColumnIndexer(2, 0)}
+}
+
+func (t *float16Type) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return &float16Dictionary{newFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)}
+}
+
+func (t *float16Type) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return &float16ColumnBuffer{newFixedLenByteArrayColumnBuffer(t, makeColumnIndex(columnIndex), makeNumValues(numValues))}
+}
+
+func (t *float16Type) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return &float16Page{newFixedLenByteArrayPage(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)}
+}
+
+func (t *float16Type) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return float16BaseType.NewValues(values, offsets)
+}
+
+func (t *float16Type) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return float16BaseType.Encode(dst, src, enc)
+}
+
+func (t *float16Type) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return float16BaseType.Decode(dst, src, enc)
+}
+
+func (t *float16Type) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return float16BaseType.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *float16Type) AssignValue(dst reflect.Value, src Value) error {
+	if b := src.byteArray(); len(b) == 2 {
+		switch dst.Kind() {
+		case reflect.Uint16:
+			dst.SetUint(uint64(binary.LittleEndian.Uint16(b)))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(float16ToFloat32(binary.LittleEndian.Uint16(b))))
+			return nil
+		}
+	}
+	return float16BaseType.AssignValue(dst, src)
+}
+
+func (t *float16Type) ConvertValue(val Value, typ Type) (Value, error) {
+	switch typ.(type) {
+	case *float16Type:
+		return val, nil
+	case *stringType:
+		return convertStringToFloat16(val)
+	}
+	switch typ.Kind() {
+	case Float:
+		return convertFloatToFloat16(val)
+	case Double:
+		return convertDoubleToFloat16(val)
+	}
+	return float16BaseType.ConvertValue(val, typ)
+}
+
+func makeValueFloat16(bits uint16) Value {
+	return makeValueBytes(FixedLenByteArray, float16Bytes(bits))
+}
+
+func float16Bytes(bits uint16) []byte {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, bits)
+	return b
+}
+
+// float16ValueOf returns the float32 representation of the FLOAT16 value v.
+func float16ValueOf(v Value) float32 {
+	if b := v.byteArray(); len(b) == 2 {
+		return float16ToFloat32(binary.LittleEndian.Uint16(b))
+	}
+	return 0
+}
+
+// float16Bounds returns the indexes of the smallest and largest FLOAT16
+// values of data, ignoring NaN values unless they are the only values.
+func float16Bounds(count int, valueAt func(int) float32) (minIndex, maxIndex int) {
+	minIndex, maxIndex = -1, -1
+	minValue, maxValue := float32(0), float32(0)
+	for i := 0; i < count; i++ {
+		v := valueAt(i)
+		if v != v { // NaN
+			continue
+		}
+		if minIndex < 0 || v < minValue {
+			minIndex, minValue = i, v
+		}
+		if maxIndex < 0 || v > maxValue {
+			maxIndex, maxValue = i, v
+		}
+	}
+	if minIndex < 0 {
+		minIndex, maxIndex = 0, 0
+	}
+	return minIndex, maxIndex
+}
+
+func float16At(data []byte, i int) float32 {
+	return float16ToFloat32(binary.LittleEndian.Uint16(data[2*i:]))
+}
+
+type float16Page struct{ *fixedLenByteArrayPage }
+
+func (page *float16Page) Bounds() (min, max Value, ok bool) {
+	if ok = len(page.data) > 0; ok {
+		minIndex, maxIndex := float16Bounds(len(page.data)/2, func(i int) float32 {
+			return float16At(page.data, i)
+		})
+		min = page.makeValueBytes(page.data[2*minIndex : 2*minIndex+2])
+		max = page.makeValueBytes(page.data[2*maxIndex : 2*maxIndex+2])
+	}
+	return min, max, ok
+}
+
+func (page *float16Page) Slice(i, j int64) Page {
+	return &float16Page{page.fixedLenByteArrayPage.Slice(i, j).(*fixedLenByteArrayPage)}
+}
+
+type float16ColumnBuffer struct{ *fixedLenByteArrayColumnBuffer }
+
+func (col *float16ColumnBuffer) Clone() ColumnBuffer {
+	return &float16ColumnBuffer{col.fixedLenByteArrayColumnBuffer.Clone().(*fixedLenByteArrayColumnBuffer)}
+}
+
+func (col *float16ColumnBuffer) Pages() Pages { return onePage(col.Page()) }
+
+func (col *float16ColumnBuffer) Page() Page {
+	return &float16Page{&col.fixedLenByteArrayPage}
+}
+
+func (col *float16ColumnBuffer) Less(i, j int) bool {
+	return float16At(col.data, i) < float16At(col.data, j)
+}
+
+type float16Dictionary struct{ *fixedLenByteArrayDictionary }
+
+func (d *float16Dictionary) Type() Type { return newIndexedType(d.typ, d) }
+
+func (d *float16Dictionary) Page() Page {
+	return &float16Page{&d.fixedLenByteArrayPage}
+}
+
+func (d *float16Dictionary) Bounds(indexes []int32) (min, max Value) {
+	if len(indexes) > 0 {
+		minIndex, maxIndex := float16Bounds(len(indexes), func(i int) float32 {
+			return float16At(d.data, int(indexes[i]))
+		})
+		min = d.makeValueBytes(d.index(indexes[minIndex]))
+		max = d.makeValueBytes(d.index(indexes[maxIndex]))
+	}
+	return min, max
+}
+
+type float16ColumnIndexer struct {
+	*fixedLenByteArrayColumnIndexer
+}
+
+func (i *float16ColumnIndexer) ColumnIndex() format.ColumnIndex {
+	minValues := splitFixedLenByteArrays(i.minValues, 2)
+	maxValues := splitFixedLenByteArrays(i.maxValues, 2)
+	return i.columnIndex(
+		minValues,
+		maxValues,
+		orderOfFloat32(float16ValuesOf(minValues)),
+		orderOfFloat32(float16ValuesOf(maxValues)),
+	)
+}
+
+func float16ValuesOf(values [][]byte) []float32 {
+	floats := make([]float32, len(values))
+	for i, v := range values {
+		if len(v) == 2 {
+			floats[i] = float16ToFloat32(binary.LittleEndian.Uint16(v))
+		}
+	}
+	return floats
+}
+
+func writeRowsFuncOfFloat16(t reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	elemType := reflect.TypeOf([2]byte{})
+	elemSize := uintptr(elemType.Size())
+	writeRows := writeRowsFuncOf(elemType, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		for i := 0; i < rows.Len(); i++ {
+			var bits uint16
+			switch t.Kind() {
+			case reflect.Float32:
+				bits = float32ToFloat16(*(*float32)(rows.Index(i)))
+			case reflect.Float64:
+				bits = float32ToFloat16(float32(*(*float64)(rows.Index(i))))
+			default:
+				bits = *(*uint16)(rows.Index(i))
+			}
+
+			b := [2]byte{}
+			binary.LittleEndian.PutUint16(b[:], bits)
+			a := makeArray(unsafecast.PointerOf(b[:]), 1, elemSize)
+			if err := writeRows(columns, a, levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// float16ToFloat32 converts the IEEE 754 half precision number of the given
+// bits to a float32, which represents all half precision numbers exactly.
+func float16ToFloat32(bits uint16) float32 {
+	sign := uint32(bits&0x8000) << 16
+	exp := uint32(bits>>10) & 0x1F
+	mant := uint32(bits & 0x3FF)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// Subnormal half precision numbers are normal float32 numbers, shift
+		// the mantissa until the implicit bit is set.
+		exp = 127 - 15 + 1
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+		mant &= 0x3FF
+		return math.Float32frombits(sign | exp<<23 | mant<<13)
+	case 0x1F: // Inf or NaN
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	}
+}
+
+// float32ToFloat16 converts f to the bits of the nearest IEEE 754 half
+// precision number, rounding ties to even. Values too large to be represented
+// become infinities and values too small become zeros.
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23) & 0xFF
+	mant := bits & 0x7FFFFF
+
+	if exp == 0xFF { // Inf or NaN
+		if mant != 0 {
+			return sign | 0x7E00
+		}
+		return sign | 0x7C00
+	}
+
+	exp = exp - 127 + 15
+	switch {
+	case exp >= 0x1F:
+		return sign | 0x7C00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint32(1) << (shift - 1)
+		h := mant >> shift
+		r := mant & (1<<shift - 1)
+		if r > half || (r == half && h&1 != 0) {
+			h++
+		}
+		return sign | uint16(h)
+	default:
+		h := uint32(exp)<<10 | mant>>13
+		r := mant & 0x1FFF
+		if r > 0x1000 || (r == 0x1000 && h&1 != 0) {
+			h++ // may carry into the exponent, which yields the correct result
+		}
+		return sign | uint16(h)
+	}
+}
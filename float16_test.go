@@ -0,0 +1,212 @@
+package parquet_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type half uint16
+
+type float16Row struct {
+	Raw   half    `parquet:"raw,float16"`
+	Value float32 `parquet:"value,float16"`
+}
+
+func TestFloat16RoundTrip(t *testing.T) {
+	rows := []float16Row{
+		{Raw: 0x3c00, Value: 1},       // 1.0
+		{Raw: 0xc000, Value: -2},      // -2.0
+		{Raw: 0x7bff, Value: 65504},   // largest normal number
+		{Raw: 0x0001, Value: 0x1p-24}, // smallest subnormal number
+		{Raw: 0x7c00, Value: float32(math.Inf(+1))},
+		{Raw: 0xfc00, Value: float32(math.Inf(-1))},
+		{Raw: 0x3555, Value: 0.33325195}, // nearest half precision number of 1/3
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"raw", "value"} {
+		leaf, _ := f.Schema().Lookup(name)
+		typ := leaf.Node.Type()
+		if lt := typ.LogicalType(); lt == nil || lt.Float16 == nil {
+			t.Errorf("%s: column is not a FLOAT16: %v", name, typ)
+		}
+		if typ.Kind() != parquet.FixedLenByteArray || typ.Length() != 2 {
+			t.Errorf("%s: wrong physical type: %v(%d)", name, typ.Kind(), typ.Length())
+		}
+	}
+
+	got, err := parquet.Read[float16Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %v\ngot:  %v", rows, got)
+	}
+}
+
+func TestFloat16Conversion(t *testing.T) {
+	values := []float32{1, 0.1, 1e-8, 65519, 65520, -1e6}
+	rows := make([]float16Row, len(values))
+	for i, v := range values {
+		rows[i].Value = v
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	type float32Row struct {
+		Raw   uint16  `parquet:"raw,float16"`
+		Value float32 `parquet:"value"`
+	}
+
+	got, err := parquet.Read[float32Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []float32{
+		1,
+		0.099975586, // nearest half precision number
+		0,           // underflows to zero
+		65504,       // rounds down to the largest number
+		float32(math.Inf(+1)),
+		float32(math.Inf(-1)),
+	}
+	for i, row := range got {
+		if row.Value != want[i] {
+			t.Errorf("row %d: wrong value converted from %g: want=%g got=%g", i, values[i], want[i], row.Value)
+		}
+	}
+
+	nan := []float16Row{{Value: float32(math.NaN())}}
+	buf.Reset()
+	if err := parquet.Write(buf, nan); err != nil {
+		t.Fatal(err)
+	}
+	got, err = parquet.Read[float32Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := got[0].Value; v == v {
+		t.Errorf("NaN was not preserved: got=%g", v)
+	}
+}
+
+func TestFloat16Statistics(t *testing.T) {
+	// The byte representations of these numbers are not ordered like the
+	// numbers, the statistics must compare the values numerically.
+	values := []float32{0.5, -3, 2, float32(math.NaN()), -0.25}
+
+	for _, test := range []struct {
+		scenario string
+		options  []parquet.WriterOption
+	}{
+		{scenario: "plain"},
+		{scenario: "dictionary", options: []parquet.WriterOption{parquet.SchemaOf(struct {
+			Value float32 `parquet:"value,float16,dict"`
+		}{})}},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			type row struct {
+				Value float32 `parquet:"value,float16"`
+			}
+			rows := make([]row, len(values))
+			for i, v := range values {
+				rows[i].Value = v
+			}
+
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[row](buf, test.options...)
+			if _, err := w.Write(rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunk := f.RowGroups()[0].ColumnChunks()[0]
+			typ := chunk.Type()
+
+			index := chunk.ColumnIndex()
+			min, max := index.MinValue(0), index.MaxValue(0)
+			if !bytes.Equal(min.ByteArray(), []byte{0x00, 0xc2}) { // -3.0
+				t.Errorf("wrong min value: %v", min)
+			}
+			if !bytes.Equal(max.ByteArray(), []byte{0x00, 0x40}) { // 2.0
+				t.Errorf("wrong max value: %v", max)
+			}
+			if typ.Compare(min, max) >= 0 {
+				t.Errorf("min value is not lower than max value: %v >= %v", min, max)
+			}
+		})
+	}
+}
+
+func TestFloat16Float64(t *testing.T) {
+	type Row struct {
+		Value   float64  `parquet:"value,float16"`
+		Pointer *float64 `parquet:"pointer,float16"`
+	}
+	half := 0.5
+	rows := []Row{
+		{Value: 1, Pointer: &half},
+		{Value: -2.5},
+		{Value: 0.1}, // rounded to the nearest half precision number
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	// The rows are also written one value at a time by the untyped writer.
+	untyped := new(bytes.Buffer)
+	w := parquet.NewWriter(untyped, parquet.SchemaOf(Row{}))
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := append([]Row{}, rows...)
+	want[2].Value = float64(float32(0.099975586))
+
+	for _, b := range [][]byte{buf.Bytes(), untyped.Bytes()} {
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaf, _ := f.Schema().Lookup("value")
+		if lt := leaf.Node.Type().LogicalType(); lt == nil || lt.Float16 == nil {
+			t.Errorf("column is not a FLOAT16: %v", leaf.Node.Type())
+		}
+
+		got, err := parquet.Read[Row](bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+		}
+	}
+}
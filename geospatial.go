@@ -0,0 +1,179 @@
+package parquet
+
+import (
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Geometry constructs a leaf node of GEOMETRY logical type.
+//
+// GEOMETRY values are geospatial features in the Well-Known Binary (WKB)
+// format, stored as BYTE_ARRAY values; the package does not interpret them,
+// programs are responsible for encoding and decoding the WKB representation.
+//
+// The crs argument is the Coordinate Reference System of the geometries, the
+// default OGC:CRS84 is used when it is empty. The specification allows the CRS
+// to reference a key/value metadata entry of the file holding its definition,
+// for example "projjson:crs_key", programs must then also set the metadata of
+// the file with the KeyValueMetadata writer option.
+//
+// The parquet specification does not define a sort order for geospatial
+// values, the min and max statistics of GEOMETRY columns are not written.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#geometry
+func Geometry(crs string) Node { return Leaf(&geometryType{CRS: crs}) }
+
+type geometryType format.GeometryType
+
+func (t *geometryType) String() string { return (*format.GeometryType)(t).String() }
+
+func (t *geometryType) Kind() Kind { return byteArrayType{}.Kind() }
+
+func (t *geometryType) Length() int { return byteArrayType{}.Length() }
+
+func (t *geometryType) EstimateSize(n int) int { return byteArrayType{}.EstimateSize(n) }
+
+func (t *geometryType) EstimateNumValues(n int) int { return byteArrayType{}.EstimateNumValues(n) }
+
+func (t *geometryType) Compare(a, b Value) int { return byteArrayType{}.Compare(a, b) }
+
+// ColumnOrder returns nil, the parquet specification defines no sort order for
+// GEOMETRY values.
+func (t *geometryType) ColumnOrder() *format.ColumnOrder { return nil }
+
+func (t *geometryType) PhysicalType() *format.Type { return byteArrayType{}.PhysicalType() }
+
+func (t *geometryType) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Geometry: (*format.GeometryType)(t)}
+}
+
+func (t *geometryType) ConvertedType() *deprecated.ConvertedType { return nil }
+
+func (t *geometryType) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newUnorderedColumnIndexer()
+}
+
+func (t *geometryType) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return byteArrayType{}.NewDictionary(columnIndex, numValues, data)
+}
+
+func (t *geometryType) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return byteArrayType{}.NewColumnBuffer(columnIndex, numValues)
+}
+
+func (t *geometryType) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return byteArrayType{}.NewPage(columnIndex, numValues, data)
+}
+
+func (t *geometryType) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return byteArrayType{}.NewValues(values, offsets)
+}
+
+func (t *geometryType) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return byteArrayType{}.Encode(dst, src, enc)
+}
+
+func (t *geometryType) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return byteArrayType{}.Decode(dst, src, enc)
+}
+
+func (t *geometryType) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return byteArrayType{}.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *geometryType) AssignValue(dst reflect.Value, src Value) error {
+	return byteArrayType{}.AssignValue(dst, src)
+}
+
+func (t *geometryType) ConvertValue(val Value, typ Type) (Value, error) {
+	switch typ.(type) {
+	case *byteArrayType, *geometryType:
+		return val, nil
+	default:
+		return val, invalidConversion(val, "GEOMETRY", typ.String())
+	}
+}
+
+// Geography constructs a leaf node of GEOGRAPHY logical type.
+//
+// GEOGRAPHY values are like GEOMETRY values, except that their edges are
+// interpolated on the surface of the earth instead of a plane, and that their
+// Coordinate Reference System must be geographic. The edges of values are
+// interpolated with the spherical algorithm.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#geography
+func Geography(crs string) Node { return Leaf(&geographyType{CRS: crs}) }
+
+type geographyType format.GeographyType
+
+func (t *geographyType) String() string { return (*format.GeographyType)(t).String() }
+
+func (t *geographyType) Kind() Kind { return byteArrayType{}.Kind() }
+
+func (t *geographyType) Length() int { return byteArrayType{}.Length() }
+
+func (t *geographyType) EstimateSize(n int) int { return byteArrayType{}.EstimateSize(n) }
+
+func (t *geographyType) EstimateNumValues(n int) int { return byteArrayType{}.EstimateNumValues(n) }
+
+func (t *geographyType) Compare(a, b Value) int { return byteArrayType{}.Compare(a, b) }
+
+// ColumnOrder returns nil, the parquet specification defines no sort order for
+// GEOGRAPHY values.
+func (t *geographyType) ColumnOrder() *format.ColumnOrder { return nil }
+
+func (t *geographyType) PhysicalType() *format.Type { return byteArrayType{}.PhysicalType() }
+
+func (t *geographyType) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Geography: (*format.GeographyType)(t)}
+}
+
+func (t *geographyType) ConvertedType() *deprecated.ConvertedType { return nil }
+
+func (t *geographyType) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newUnorderedColumnIndexer()
+}
+
+func (t *geographyType) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return byteArrayType{}.NewDictionary(columnIndex, numValues, data)
+}
+
+func (t *geographyType) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return byteArrayType{}.NewColumnBuffer(columnIndex, numValues)
+}
+
+func (t *geographyType) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return byteArrayType{}.NewPage(columnIndex, numValues, data)
+}
+
+func (t *geographyType) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return byteArrayType{}.NewValues(values, offsets)
+}
+
+func (t *geographyType) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return byteArrayType{}.Encode(dst, src, enc)
+}
+
+func (t *geographyType) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return byteArrayType{}.Decode(dst, src, enc)
+}
+
+func (t *geographyType) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return byteArrayType{}.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *geographyType) AssignValue(dst reflect.Value, src Value) error {
+	return byteArrayType{}.AssignValue(dst, src)
+}
+
+func (t *geographyType) ConvertValue(val Value, typ Type) (Value, error) {
+	switch typ.(type) {
+	case *byteArrayType, *geographyType:
+		return val, nil
+	default:
+		return val, invalidConversion(val, "GEOGRAPHY", typ.String())
+	}
+}
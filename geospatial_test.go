@@ -0,0 +1,126 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// WKB representation of POINT(1 2) in little-endian byte order.
+var wkbPoint = []byte{
+	0x01, 0x01, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xF0, 0x3F,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x40,
+}
+
+type geospatialRow struct {
+	Geom      []byte  `parquet:"geom,geometry"`
+	Projected []byte  `parquet:"projected,geometry(EPSG:3857)"`
+	Geog      string  `parquet:"geog,geography"`
+	Optional  *string `parquet:"opt,optional,geography(OGC:CRS83)"`
+}
+
+func TestGeospatialRoundTrip(t *testing.T) {
+	s := "wkb"
+	rows := []geospatialRow{
+		{Geom: wkbPoint, Projected: wkbPoint, Geog: string(wkbPoint)},
+		{Geom: []byte{}, Projected: wkbPoint[:5], Geog: "", Optional: &s},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.KeyValueMetadata("geo", `{"version":"1.1.0"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `message geospatialRow {
+	required binary geom (GEOMETRY);
+	required binary projected (GEOMETRY(EPSG:3857));
+	required binary geog (GEOGRAPHY);
+	optional binary opt (GEOGRAPHY(OGC:CRS83));
+}`
+	if got := f.Schema().String(); got != want {
+		t.Errorf("wrong schema:\nwant: %s\ngot:  %s", want, got)
+	}
+
+	elements := f.Metadata().Schema[1:]
+	if lt := elements[0].LogicalType; lt == nil || lt.Geometry == nil || lt.Geometry.CRS != "" {
+		t.Errorf("wrong logical type of the geom column: %v", lt)
+	}
+	if lt := elements[1].LogicalType; lt == nil || lt.Geometry == nil || lt.Geometry.CRS != "EPSG:3857" {
+		t.Errorf("wrong logical type of the projected column: %v", lt)
+	}
+	if lt := elements[2].LogicalType; lt == nil || lt.Geography == nil {
+		t.Errorf("wrong logical type of the geog column: %v", lt)
+	}
+	leaf, _ := f.Schema().Lookup("projected")
+	if lt := leaf.Node.Type().LogicalType(); lt.Geometry == nil || lt.Geometry.CRS != "EPSG:3857" {
+		t.Errorf("wrong logical type of the column read from the file: %v", lt)
+	}
+
+	got, err := parquet.Read[geospatialRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestGeospatialInvalidTag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic using the geometry tag on an int64 field")
+		}
+	}()
+	type Row struct {
+		Geom int64 `parquet:"geom,geometry"`
+	}
+	parquet.SchemaOf(Row{})
+}
+
+func TestGeospatialStatistics(t *testing.T) {
+	s := "wkb"
+	rows := []geospatialRow{
+		{Geom: wkbPoint, Projected: wkbPoint, Geog: string(wkbPoint), Optional: &s},
+		{Geom: wkbPoint[:5], Projected: wkbPoint[:9], Geog: "point"},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.DataPageStatistics(true)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The parquet specification defines no sort order for geospatial values,
+	// the min and max values of the columns must not be written.
+	for i, order := range f.Metadata().ColumnOrders {
+		if order.TypeOrder != nil {
+			t.Errorf("column %d: unexpected type defined column order", i)
+		}
+	}
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		stats := f.Metadata().RowGroups[0].Columns[i].MetaData.Statistics
+		if stats.MinValue != nil || stats.MaxValue != nil || stats.Min != nil || stats.Max != nil {
+			t.Errorf("column %d: unexpected min and max statistics: %+v", i, stats)
+		}
+		if _, _, ok := parquet.ColumnChunkMinMax(chunk); ok {
+			t.Errorf("column %d: unexpected bounds of the column chunk", i)
+		}
+		columnIndex := f.ColumnIndexes()[i]
+		for j := range columnIndex.NullPages {
+			if len(columnIndex.MinValues[j]) != 0 || len(columnIndex.MaxValues[j]) != 0 {
+				t.Errorf("column %d: unexpected bounds of page %d in the column index", i, j)
+			}
+		}
+	}
+}
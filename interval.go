@@ -0,0 +1,171 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/parquet-go/parquet-go/internal/unsafecast"
+	"github.com/parquet-go/parquet-go/sparse"
+)
+
+// Interval is the Go representation of values of the INTERVAL converted type.
+//
+// The three components of intervals are independent unsigned integers: a
+// duration of one month is not the same as a duration of 30 days, and a
+// duration of one day is not the same as a duration of 86,400,000 milliseconds.
+// Programs are responsible for normalizing the components if needed, the
+// values are written and read back unchanged.
+//
+// Interval values are mapped to columns of INTERVAL type by default; the
+// "interval" struct tag may also be used on fields of type [12]byte to
+// exchange the raw representation of the values.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#interval
+type Interval struct {
+	Months, Days, Millis uint32
+}
+
+// String returns a human-readable representation of i.
+func (i Interval) String() string {
+	return fmt.Sprintf("%d months %d days %d ms", i.Months, i.Days, i.Millis)
+}
+
+// IntervalType is the type of parquet columns holding values of the INTERVAL
+// converted type.
+//
+// INTERVAL values are stored as FIXED_LEN_BYTE_ARRAY(12) values made of three
+// little-endian unsigned 32 bits integers, representing the number of months,
+// days, and milliseconds of the intervals. The parquet specification does not
+// define a sort order for intervals, the min and max statistics of INTERVAL
+// columns are not written.
+var IntervalType Type = &intervalType{}
+
+const intervalSize = 12
+
+var intervalBaseType = fixedLenByteArrayType{length: intervalSize}
+
+type intervalType struct{}
+
+func (t *intervalType) String() string { return "INTERVAL" }
+
+func (t *intervalType) Kind() Kind { return intervalBaseType.Kind() }
+
+func (t *intervalType) Length() int { return intervalBaseType.Length() }
+
+func (t *intervalType) EstimateSize(n int) int { return intervalBaseType.EstimateSize(n) }
+
+func (t *intervalType) EstimateNumValues(n int) int { return intervalBaseType.EstimateNumValues(n) }
+
+func (t *intervalType) Compare(a, b Value) int { return intervalBaseType.Compare(a, b) }
+
+// ColumnOrder returns nil, the parquet specification defines no sort order for
+// INTERVAL values.
+func (t *intervalType) ColumnOrder() *format.ColumnOrder { return nil }
+
+func (t *intervalType) PhysicalType() *format.Type { return &physicalTypes[FixedLenByteArray] }
+
+func (t *intervalType) LogicalType() *format.LogicalType { return nil }
+
+func (t *intervalType) ConvertedType() *deprecated.ConvertedType {
+	return &convertedTypes[deprecated.Interval]
+}
+
+func (t *intervalType) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newUnorderedColumnIndexer()
+}
+
+func (t *intervalType) NewDictionary(columnIndex, numValues int, data encoding.Values) Dictionary {
+	return newFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *intervalType) NewColumnBuffer(columnIndex, numValues int) ColumnBuffer {
+	return newFixedLenByteArrayColumnBuffer(t, makeColumnIndex(columnIndex), makeNumValues(numValues))
+}
+
+func (t *intervalType) NewPage(columnIndex, numValues int, data encoding.Values) Page {
+	return newFixedLenByteArrayPage(t, makeColumnIndex(columnIndex), makeNumValues(numValues), data)
+}
+
+func (t *intervalType) NewValues(values []byte, offsets []uint32) encoding.Values {
+	return intervalBaseType.NewValues(values, offsets)
+}
+
+func (t *intervalType) Encode(dst []byte, src encoding.Values, enc encoding.Encoding) ([]byte, error) {
+	return intervalBaseType.Encode(dst, src, enc)
+}
+
+func (t *intervalType) Decode(dst encoding.Values, src []byte, enc encoding.Encoding) (encoding.Values, error) {
+	return intervalBaseType.Decode(dst, src, enc)
+}
+
+func (t *intervalType) EstimateDecodeSize(numValues int, src []byte, enc encoding.Encoding) int {
+	return intervalBaseType.EstimateDecodeSize(numValues, src, enc)
+}
+
+func (t *intervalType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == reflect.TypeOf(Interval{}) {
+		if src.IsNull() {
+			dst.Set(reflect.ValueOf(Interval{}))
+			return nil
+		}
+		b := src.byteArray()
+		if len(b) != intervalSize {
+			return fmt.Errorf("cannot assign INTERVAL value of %d bytes to %s: %w", len(b), dst.Type(), ErrInvalidConversion)
+		}
+		dst.Set(reflect.ValueOf(intervalOf(b)))
+		return nil
+	}
+	return intervalBaseType.AssignValue(dst, src)
+}
+
+func (t *intervalType) ConvertValue(val Value, typ Type) (Value, error) {
+	return intervalBaseType.ConvertValue(val, typ)
+}
+
+// intervalBytes returns the INTERVAL representation of i.
+func intervalBytes(i Interval) []byte {
+	b := make([]byte, intervalSize)
+	putInterval(b, i)
+	return b
+}
+
+func putInterval(b []byte, i Interval) {
+	binary.LittleEndian.PutUint32(b[0:], i.Months)
+	binary.LittleEndian.PutUint32(b[4:], i.Days)
+	binary.LittleEndian.PutUint32(b[8:], i.Millis)
+}
+
+func intervalOf(b []byte) Interval {
+	return Interval{
+		Months: binary.LittleEndian.Uint32(b[0:]),
+		Days:   binary.LittleEndian.Uint32(b[4:]),
+		Millis: binary.LittleEndian.Uint32(b[8:]),
+	}
+}
+
+func writeRowsFuncOfInterval(_ reflect.Type, schema *Schema, path columnPath) writeRowsFunc {
+	elemType := reflect.TypeOf([intervalSize]byte{})
+	elemSize := uintptr(elemType.Size())
+	writeRows := writeRowsFuncOf(elemType, schema, path)
+
+	return func(columns []ColumnBuffer, rows sparse.Array, levels columnLevels) error {
+		if rows.Len() == 0 {
+			return writeRows(columns, rows, levels)
+		}
+
+		for i := 0; i < rows.Len(); i++ {
+			b := [intervalSize]byte{}
+			putInterval(b[:], *(*Interval)(rows.Index(i)))
+			a := makeArray(unsafecast.PointerOf(b[:]), 1, elemSize)
+			if err := writeRows(columns, a, levels); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+type intervalRow struct {
+	Duration parquet.Interval  `parquet:"dur,interval"`
+	Raw      [12]byte          `parquet:"raw,interval"`
+	Optional *parquet.Interval `parquet:"opt,optional"`
+}
+
+func TestIntervalRoundTrip(t *testing.T) {
+	rows := []intervalRow{
+		{Duration: parquet.Interval{Months: 1, Days: 2, Millis: 3}},
+		{Duration: parquet.Interval{Months: 0xFFFFFFFF, Days: 0x01020304, Millis: 1<<31 + 1}},
+		{Raw: [12]byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, Optional: &parquet.Interval{Days: 30}},
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, rows)
+			},
+		},
+		{
+			scenario: "rows",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, parquet.SchemaOf(intervalRow{}))
+				for _, row := range rows {
+					if err := writer.Write(row); err != nil {
+						return err
+					}
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, element := range f.Metadata().Schema[1:] {
+				if element.ConvertedType == nil || *element.ConvertedType != deprecated.Interval {
+					t.Errorf("%s: wrong converted type: %v", element.Name, element.ConvertedType)
+				}
+				if element.TypeLength == nil || *element.TypeLength != 12 {
+					t.Errorf("%s: wrong type length: %v", element.Name, element.TypeLength)
+				}
+			}
+			// The parquet specification defines no sort order for intervals,
+			// the min and max values of the columns must not be written.
+			for i, column := range f.Metadata().RowGroups[0].Columns {
+				if stats := column.MetaData.Statistics; stats.MinValue != nil || stats.MaxValue != nil || stats.Min != nil || stats.Max != nil {
+					t.Errorf("%s: unexpected min and max statistics: %+v", column.MetaData.PathInSchema, stats)
+				}
+				if f.Metadata().ColumnOrders[i].TypeOrder != nil {
+					t.Errorf("%s: unexpected type defined column order", column.MetaData.PathInSchema)
+				}
+			}
+			leaf, _ := f.Schema().Lookup("dur")
+			if typ := leaf.Node.Type(); typ != parquet.IntervalType {
+				t.Errorf("wrong type of interval column read from the file: %v", typ)
+			}
+
+			// The components of intervals are little-endian.
+			pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+			defer pages.Close()
+			page, err := pages.ReadPage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			values := make([]parquet.Value, page.NumValues())
+			if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			want := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x04, 0x03, 0x02, 0x01, 0x01, 0x00, 0x00, 0x80}
+			if got := values[1].ByteArray(); !bytes.Equal(got, want) {
+				t.Errorf("wrong interval representation:\nwant: %v\ngot:  %v", want, got)
+			}
+
+			got, err := parquet.Read[intervalRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("rows mismatch:\nwant: %v\ngot:  %v", rows, got)
+			}
+		})
+	}
+}
+
+func TestIntervalInvalidTag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic using the interval tag on an int64 field")
+		}
+	}()
+	type Row struct {
+		Duration int64 `parquet:"dur,interval"`
+	}
+	parquet.SchemaOf(Row{})
+}
@@ -0,0 +1,352 @@
+package parquet
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+// JSONOption is an interface implemented by options configuring the conversion
+// of parquet files to JSON by ToJSON.
+type JSONOption interface {
+	ConfigureJSON(*JSONConfig)
+}
+
+// The JSONConfig type carries configuration options for JSON conversions.
+type JSONConfig struct {
+	// The layout of timestamps, defaults to time.RFC3339Nano.
+	TimeFormat string
+	// The function encoding byte arrays to JSON strings, defaults to
+	// base64.StdEncoding.EncodeToString.
+	EncodeBytes func([]byte) string
+}
+
+// ConfigureJSON applies configuration options from c to config.
+func (c *JSONConfig) ConfigureJSON(config *JSONConfig) {
+	if c.TimeFormat != "" {
+		config.TimeFormat = c.TimeFormat
+	}
+	if c.EncodeBytes != nil {
+		config.EncodeBytes = c.EncodeBytes
+	}
+}
+
+type jsonOption func(*JSONConfig)
+
+func (opt jsonOption) ConfigureJSON(config *JSONConfig) { opt(config) }
+
+// JSONTimeFormat creates a configuration option which sets the layout of the
+// timestamps converted by ToJSON, for example time.RFC3339 to omit fractions
+// of seconds.
+func JSONTimeFormat(layout string) JSONOption {
+	return jsonOption(func(config *JSONConfig) { config.TimeFormat = layout })
+}
+
+// JSONBytesEncoding creates a configuration option which sets the function
+// encoding byte arrays to JSON strings in ToJSON, for example hex.EncodeToString.
+func JSONBytesEncoding(encode func([]byte) string) JSONOption {
+	return jsonOption(func(config *JSONConfig) { config.EncodeBytes = encode })
+}
+
+// jsonBatchSize is the number of rows read by ToJSON between writes.
+const jsonBatchSize = 1024
+
+// ToJSON writes the rows of f to dst as newline-delimited JSON, each row being
+// a JSON object holding the columns of the file in the order of its schema.
+//
+// Groups are converted to nested objects, repeated columns and LIST groups to
+// arrays, and MAP groups to objects, with keys that are not strings converted
+// to their JSON representation. Null values are written as null.
+//
+// The values of leaf columns are converted according to their logical types:
+//   - TIMESTAMP values, including legacy INT96 timestamps, are strings in the
+//     UTC time zone, formatted with the layout set by JSONTimeFormat
+//   - DATE values are strings in the "2006-01-02" format, and TIME values are
+//     strings in the "15:04:05.999999999" format
+//   - DECIMAL values are JSON numbers holding the exact decimal representation
+//     of the values
+//   - UUID values are strings in their canonical representation
+//   - JSON values are embedded in the output
+//   - STRING and ENUM values are strings, other byte arrays are strings encoded
+//     with the function set by JSONBytesEncoding
+//   - NaN and infinite floating point values, which JSON numbers cannot
+//     represent, are the strings "NaN", "+Inf" and "-Inf"
+func ToJSON(dst io.Writer, f *File, options ...JSONOption) error {
+	config := &JSONConfig{
+		TimeFormat:  time.RFC3339Nano,
+		EncodeBytes: base64.StdEncoding.EncodeToString,
+	}
+	for _, opt := range options {
+		opt.ConfigureJSON(config)
+	}
+
+	w := bufio.NewWriter(dst)
+	r := NewGenericReader[any](f)
+	defer r.Close()
+
+	schema := r.Schema()
+	rows := make([]any, jsonBatchSize)
+	var line []byte
+	for {
+		// The rows are cleared because values read into non-nil maps would be
+		// merged with their previous content.
+		for i := range rows {
+			rows[i] = nil
+		}
+		n, err := r.Read(rows)
+		for _, row := range rows[:n] {
+			var convErr error
+			if line, convErr = config.appendValue(line[:0], schema, row); convErr != nil {
+				return convErr
+			}
+			line = append(line, '\n')
+			if _, err := w.Write(line); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (c *JSONConfig) appendValue(b []byte, node Node, value any) ([]byte, error) {
+	switch {
+	case value == nil:
+		return append(b, "null"...), nil
+	case node.Repeated():
+		values, ok := value.([]any)
+		if !ok {
+			return b, fmt.Errorf("cannot convert value of type %T to JSON array", value)
+		}
+		return c.appendArray(b, Required(node), values, nil)
+	case node.Leaf():
+		return c.appendLeaf(b, node.Type(), value)
+	}
+
+	group, ok := value.(map[string]any)
+	if !ok {
+		return b, fmt.Errorf("cannot convert value of type %T to JSON object", value)
+	}
+	fields := node.Fields()
+
+	if len(fields) == 1 && !fields[0].Leaf() && fields[0].Repeated() {
+		repeated := fields[0]
+		entries, _ := group[repeated.Name()].([]any)
+
+		list, mapping := jsonGroupKind(node)
+		switch entry := repeated.Fields(); {
+		case list && len(entry) == 1:
+			elem := entry[0]
+			return c.appendArray(b, elem, entries, func(entry map[string]any) any {
+				return entry[elem.Name()]
+			})
+		case mapping && len(entry) == 2:
+			return c.appendMap(b, entry[0], entry[1], entries)
+		}
+	}
+
+	b = append(b, '{')
+	for i, field := range fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, field.Name())
+		b = append(b, ':')
+		var err error
+		if b, err = c.appendValue(b, field, group[field.Name()]); err != nil {
+			return b, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// jsonGroupKind reports whether node is a LIST or a MAP group. The columns of
+// files do not carry the logical types of groups, so their schema elements are
+// inspected as well, including the legacy converted types.
+func jsonGroupKind(node Node) (list, mapping bool) {
+	switch {
+	case isList(node):
+		return true, false
+	case isMap(node):
+		return false, true
+	}
+	if c, ok := node.(*Column); ok {
+		if lt := c.schema.LogicalType; lt != nil {
+			return lt.List != nil, lt.Map != nil
+		}
+		if ct := c.schema.ConvertedType; ct != nil {
+			return *ct == deprecated.List, *ct == deprecated.Map || *ct == deprecated.MapKeyValue
+		}
+	}
+	return false, false
+}
+
+// appendArray appends values to b as a JSON array of elements of the given
+// node. When elementOf is not nil, the values are the entries of a LIST group,
+// from which the function extracts the elements.
+func (c *JSONConfig) appendArray(b []byte, elem Node, values []any, elementOf func(map[string]any) any) ([]byte, error) {
+	b = append(b, '[')
+	for i, value := range values {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		if elementOf != nil {
+			entry, _ := value.(map[string]any)
+			value = elementOf(entry)
+		}
+		var err error
+		if b, err = c.appendValue(b, elem, value); err != nil {
+			return b, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+func (c *JSONConfig) appendMap(b []byte, key, value Field, entries []any) ([]byte, error) {
+	b = append(b, '{')
+	for i, entry := range entries {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		keyValue, _ := entry.(map[string]any)
+
+		if k, ok := keyValue[key.Name()].(string); ok {
+			b = appendJSONString(b, k)
+		} else {
+			k, err := c.appendValue(nil, key, keyValue[key.Name()])
+			if err != nil {
+				return b, err
+			}
+			if len(k) > 0 && k[0] == '"' {
+				b = append(b, k...)
+			} else {
+				b = appendJSONString(b, string(k))
+			}
+		}
+
+		b = append(b, ':')
+		var err error
+		if b, err = c.appendValue(b, value, keyValue[value.Name()]); err != nil {
+			return b, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func (c *JSONConfig) appendLeaf(b []byte, typ Type, value any) ([]byte, error) {
+	lt := typ.LogicalType()
+
+	switch v := value.(type) {
+	case bool:
+		return strconv.AppendBool(b, v), nil
+
+	case int32:
+		switch {
+		case lt != nil && lt.Date != nil:
+			date := time.Unix(int64(v)*(nanosecondsPerDay/1e9), 0).UTC()
+			return appendJSONString(b, date.Format("2006-01-02")), nil
+		case lt != nil && lt.Time != nil:
+			return appendJSONTime(b, time.Duration(v)*time.Millisecond), nil
+		case lt != nil && lt.Decimal != nil:
+			return appendDecimal(b, big.NewInt(int64(v)), int(lt.Decimal.Scale)), nil
+		case lt != nil && lt.Integer != nil && !lt.Integer.IsSigned:
+			return strconv.AppendUint(b, uint64(uint32(v)), 10), nil
+		default:
+			return strconv.AppendInt(b, int64(v), 10), nil
+		}
+
+	case int64:
+		switch {
+		case lt != nil && lt.Timestamp != nil:
+			perSecond := int64(time.Second / timeUnitDuration(lt.Timestamp.Unit))
+			nanos := (v % perSecond) * int64(timeUnitDuration(lt.Timestamp.Unit))
+			t := time.Unix(v/perSecond, nanos).UTC()
+			return appendJSONString(b, t.Format(c.TimeFormat)), nil
+		case lt != nil && lt.Time != nil:
+			return appendJSONTime(b, time.Duration(v)*timeUnitDuration(lt.Time.Unit)), nil
+		case lt != nil && lt.Decimal != nil:
+			return appendDecimal(b, big.NewInt(v), int(lt.Decimal.Scale)), nil
+		case lt != nil && lt.Integer != nil && !lt.Integer.IsSigned:
+			return strconv.AppendUint(b, uint64(v), 10), nil
+		default:
+			return strconv.AppendInt(b, v, 10), nil
+		}
+
+	case deprecated.Int96:
+		return appendJSONString(b, int96ToTime(v).Format(c.TimeFormat)), nil
+
+	case float32:
+		return appendJSONFloat(b, float64(v), 32), nil
+
+	case float64:
+		return appendJSONFloat(b, v, 64), nil
+
+	case string:
+		// Byte arrays are read as strings when they have no logical type, they
+		// are converted the same way as values read into byte slices.
+		if lt == nil || (lt.UTF8 == nil && lt.Enum == nil && lt.Json == nil) {
+			return c.appendLeaf(b, typ, []byte(v))
+		}
+		return appendJSONString(b, v), nil
+
+	case []byte:
+		switch {
+		case lt != nil && lt.Decimal != nil:
+			unscaled := decimalUnscaledFromValue(ByteArrayValue(v))
+			return appendDecimal(b, unscaled, int(lt.Decimal.Scale)), nil
+		case lt != nil && lt.UUID != nil && len(v) == 16:
+			return appendJSONString(b, uuid.UUID(v).String()), nil
+		case lt != nil && lt.Float16 != nil && len(v) == 2:
+			return appendJSONFloat(b, float64(float16ToFloat32(uint16(v[0])|uint16(v[1])<<8)), 32), nil
+		case lt != nil && lt.Json != nil && json.Valid(v):
+			return append(b, v...), nil
+		case lt != nil && (lt.UTF8 != nil || lt.Enum != nil):
+			return appendJSONString(b, string(v)), nil
+		default:
+			return appendJSONString(b, c.EncodeBytes(v)), nil
+		}
+	}
+
+	// Values of the JSON logical type are decoded when read into values of
+	// type any, they are encoded back to JSON.
+	j, err := json.Marshal(value)
+	if err != nil {
+		return b, fmt.Errorf("cannot convert value of type %T to JSON: %w", value, err)
+	}
+	return append(b, j...), nil
+}
+
+func appendJSONTime(b []byte, d time.Duration) []byte {
+	return appendJSONString(b, time.Time{}.Add(d).Format("15:04:05.999999999"))
+}
+
+func appendDecimal(b []byte, unscaled *big.Int, scale int) []byte {
+	return append(b, new(big.Rat).SetFrac(unscaled, pow10(scale)).FloatString(scale)...)
+}
+
+func appendJSONFloat(b []byte, f float64, bitSize int) []byte {
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	switch s {
+	case "NaN", "+Inf", "-Inf":
+		return appendJSONString(b, s)
+	}
+	return append(b, s...)
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	j, _ := json.Marshal(s)
+	return append(b, j...)
+}
@@ -0,0 +1,98 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+type jsonAddress struct {
+	City string `parquet:"city"`
+	Zip  *int32 `parquet:"zip,optional"`
+}
+
+type jsonRow struct {
+	ID       int64            `parquet:"id"`
+	Name     string           `parquet:"name"`
+	Active   bool             `parquet:"active"`
+	Score    float64          `parquet:"score"`
+	Count    uint32           `parquet:"count"`
+	Created  time.Time        `parquet:"created,timestamp(millisecond)"`
+	Birthday int32            `parquet:"birthday,date"`
+	Price    int64            `parquet:"price,decimal(2:10)"`
+	Balance  [9]byte          `parquet:"balance,decimal(3:20)"`
+	UUID     uuid.UUID        `parquet:"uuid"`
+	Payload  []byte           `parquet:"payload"`
+	Meta     string           `parquet:"meta,json"`
+	Tags     []string         `parquet:"tags"`
+	Scores   []int32          `parquet:"scores,list"`
+	Labels   map[string]int64 `parquet:"labels"`
+	Address  *jsonAddress     `parquet:"address,optional"`
+	Nickname *string          `parquet:"nickname,optional"`
+}
+
+func TestToJSON(t *testing.T) {
+	created := time.Date(2024, time.March, 4, 5, 6, 7, 890000000, time.UTC)
+	id := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	rows := []jsonRow{
+		{
+			ID: 1, Name: "Luke", Active: true, Score: 0.5, Count: math.MaxUint32,
+			Created: created, Birthday: 1, Price: -1234, Balance: [9]byte{6: 0x01, 7: 0xe2, 8: 0x40},
+			UUID: id, Payload: []byte{0xde, 0xad}, Meta: `{"b":[1,2],"a":true}`,
+			Tags: []string{"pilot", "jedi"}, Scores: []int32{1, 2}, Labels: map[string]int64{"x": 1},
+			Address: &jsonAddress{City: "Anchorhead", Zip: newPointer(int32(42))},
+		},
+		{
+			ID: 2, Name: "Leia \"Organa\"", Score: math.Inf(-1),
+			Created: created.Add(time.Hour), Meta: `null`,
+			Address: &jsonAddress{City: "Alderaan"},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	f := openFile(t, buf.Bytes())
+
+	want := []string{
+		`{"id":1,"name":"Luke","active":true,"score":0.5,"count":4294967295,` +
+			`"created":"2024-03-04T05:06:07.89Z","birthday":"1970-01-02","price":-12.34,"balance":123.456,` +
+			`"uuid":"00112233-4455-6677-8899-aabbccddeeff","payload":"3q0=","meta":{"a":true,"b":[1,2]},` +
+			`"tags":["pilot","jedi"],"scores":[1,2],"labels":{"x":1},` +
+			`"address":{"city":"Anchorhead","zip":42},"nickname":null}`,
+		`{"id":2,"name":"Leia \"Organa\"","active":false,"score":"-Inf","count":0,` +
+			`"created":"2024-03-04T06:06:07.89Z","birthday":"1970-01-01","price":0.00,"balance":0.000,` +
+			`"uuid":"00000000-0000-0000-0000-000000000000","payload":"","meta":null,` +
+			`"tags":[],"scores":[],"labels":{},` +
+			`"address":{"city":"Alderaan","zip":null},"nickname":null}`,
+	}
+
+	out := new(strings.Builder)
+	if err := parquet.ToJSON(out, f); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("wrong number of lines: want=%d got=%d\n%s", len(want), len(lines), out)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("wrong JSON of row %d:\nwant: %s\ngot:  %s", i, want[i], lines[i])
+		}
+	}
+
+	out.Reset()
+	if err := parquet.ToJSON(out, f, parquet.JSONTimeFormat(time.RFC3339), parquet.JSONBytesEncoding(hex.EncodeToString)); err != nil {
+		t.Fatal(err)
+	}
+	if s := out.String(); !strings.Contains(s, `"created":"2024-03-04T05:06:07Z"`) || !strings.Contains(s, `"payload":"dead"`) {
+		t.Errorf("the JSON options were not applied:\n%s", s)
+	}
+}
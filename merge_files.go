@@ -0,0 +1,277 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// MergeFiles writes to dst a parquet file containing the concatenation of the
+// row groups of all the files passed as arguments.
+//
+// When all files have the same schema, the encoded column chunks, bloom
+// filters, and page indexes are copied verbatim to the output, only the file
+// metadata is rewritten. This avoids decoding and re-encoding the pages, which
+// makes merging files a lot cheaper than reading and writing their rows.
+// Column chunks are self-describing, so files using different encodings or
+// compression codecs can still be merged this way.
+//
+// If the schemas differ, the function falls back to reading the rows of each
+// file and writing them to dst, converting them to the schema of the first
+// file (or the schema passed in the options).
+//
+// The key/value metadata of the files is merged in the output, when the same
+// key exists in multiple files the value from the first file is retained.
+// Metadata set with the KeyValueMetadata option takes precedence over the
+// metadata of the input files.
+func MergeFiles(dst io.Writer, files []*File, options ...WriterOption) error {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("cannot merge empty list of parquet files")
+	}
+
+	keyValueMetadata := make(map[string]string)
+	for i := len(files) - 1; i >= 0; i-- {
+		for _, kv := range files[i].metadata.KeyValueMetadata {
+			keyValueMetadata[kv.Key] = kv.Value
+		}
+	}
+	for k, v := range config.KeyValueMetadata {
+		keyValueMetadata[k] = v
+	}
+
+	schema := config.Schema
+	if schema == nil {
+		schema = files[0].Schema()
+	}
+	for _, f := range files {
+		if !nodesAreEqual(schema, f.Schema()) {
+			return mergeFilesRows(dst, files, schema, keyValueMetadata, config)
+		}
+	}
+
+	m := fileMerger{
+		config:           config,
+		keyValueMetadata: keyValueMetadata,
+	}
+	m.writer.Reset(dst)
+	for _, f := range files {
+		if err := m.writeFile(f); err != nil {
+			return err
+		}
+	}
+	return m.writeFooter(files[0])
+}
+
+// mergeFilesRows is the slow path of MergeFiles, used when the schemas of the
+// input files differ.
+func mergeFilesRows(dst io.Writer, files []*File, schema *Schema, keyValueMetadata map[string]string, config *WriterConfig) error {
+	w := NewWriter(dst, schema, config)
+	for k, v := range keyValueMetadata {
+		w.SetKeyValueMetadata(k, v)
+	}
+
+	for _, f := range files {
+		for _, rowGroup := range f.RowGroups() {
+			if rowGroupSchema := rowGroup.Schema(); !nodesAreEqual(schema, rowGroupSchema) {
+				conv, err := Convert(schema, rowGroupSchema)
+				if err != nil {
+					return fmt.Errorf("cannot merge parquet files: %w", err)
+				}
+				rowGroup = ConvertRowGroup(rowGroup, conv)
+			}
+			rows := rowGroup.Rows()
+			_, err := CopyRows(w, rows)
+			rows.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Close()
+}
+
+type fileMerger struct {
+	config           *WriterConfig
+	keyValueMetadata map[string]string
+	writer           offsetTrackingWriter
+	rowGroups        []format.RowGroup
+	columnIndexes    [][]format.ColumnIndex
+	offsetIndexes    [][]format.OffsetIndex
+}
+
+func (m *fileMerger) writeFile(f *File) error {
+	if m.writer.offset == 0 {
+		if _, err := m.writer.WriteString("PAR1"); err != nil {
+			return err
+		}
+	}
+
+	columnIndexes, offsetIndexes := f.columnIndexes, f.offsetIndexes
+	if columnIndexes == nil && offsetIndexes == nil {
+		var err error
+		if columnIndexes, offsetIndexes, err = f.ReadPageIndex(); err != nil {
+			return fmt.Errorf("reading page index of parquet file: %w", err)
+		}
+	}
+
+	for i := range f.metadata.RowGroups {
+		if len(m.rowGroups) == MaxRowGroups {
+			return ErrTooManyRowGroups
+		}
+
+		rowGroup := f.metadata.RowGroups[i]
+		rowGroup.Columns = make([]format.ColumnChunk, len(rowGroup.Columns))
+		copy(rowGroup.Columns, f.metadata.RowGroups[i].Columns)
+		rowGroup.Ordinal = int16(len(m.rowGroups))
+		rowGroup.FileOffset = m.writer.offset
+
+		numColumns := len(rowGroup.Columns)
+		rowGroupColumnIndexes := make([]format.ColumnIndex, numColumns)
+		rowGroupOffsetIndexes := make([]format.OffsetIndex, numColumns)
+
+		for j := range rowGroup.Columns {
+			c := &rowGroup.Columns[j]
+			if isEncryptedColumnChunk(c) {
+				return fmt.Errorf("cannot merge parquet files with encrypted columns")
+			}
+			if c.MetaData.BloomFilterOffset > 0 {
+				offset, err := m.copyBloomFilter(f, c.MetaData.BloomFilterOffset)
+				if err != nil {
+					return fmt.Errorf("copying bloom filter of row group %d column %d: %w", i, j, err)
+				}
+				c.MetaData.BloomFilterOffset = offset
+			}
+		}
+
+		for j := range rowGroup.Columns {
+			c := &rowGroup.Columns[j]
+			start := c.MetaData.DataPageOffset
+			if c.MetaData.DictionaryPageOffset > 0 {
+				start = c.MetaData.DictionaryPageOffset
+			}
+			size := c.MetaData.TotalCompressedSize
+			delta := m.writer.offset - start
+
+			if _, err := io.Copy(&m.writer, io.NewSectionReader(f, start, size)); err != nil {
+				return fmt.Errorf("copying row group %d column %d: %w", i, j, err)
+			}
+
+			c.MetaData.DataPageOffset += delta
+			if c.MetaData.DictionaryPageOffset > 0 {
+				c.MetaData.DictionaryPageOffset += delta
+			}
+			if c.MetaData.IndexPageOffset > 0 {
+				c.MetaData.IndexPageOffset += delta
+			}
+			if c.FileOffset > 0 {
+				c.FileOffset += delta
+			}
+
+			if k := i*numColumns + j; k < len(offsetIndexes) && c.OffsetIndexOffset > 0 {
+				pageLocations := make([]format.PageLocation, len(offsetIndexes[k].PageLocations))
+				copy(pageLocations, offsetIndexes[k].PageLocations)
+				for p := range pageLocations {
+					pageLocations[p].Offset += delta
+				}
+				rowGroupOffsetIndexes[j] = format.OffsetIndex{PageLocations: pageLocations}
+			}
+			if k := i*numColumns + j; k < len(columnIndexes) && c.ColumnIndexOffset > 0 {
+				rowGroupColumnIndexes[j] = columnIndexes[k]
+			}
+		}
+
+		m.rowGroups = append(m.rowGroups, rowGroup)
+		m.columnIndexes = append(m.columnIndexes, rowGroupColumnIndexes)
+		m.offsetIndexes = append(m.offsetIndexes, rowGroupOffsetIndexes)
+	}
+
+	return nil
+}
+
+// copyBloomFilter copies the header and bitset of the bloom filter at the given
+// offset of f, returning the offset where the filter was written.
+func (m *fileMerger) copyBloomFilter(f *File, offset int64) (int64, error) {
+	section := io.NewSectionReader(f, offset, f.size-offset)
+	header := format.BloomFilterHeader{}
+	protocol := thrift.CompactProtocol{}
+	if err := thrift.NewDecoder(protocol.NewReader(section)).Decode(&header); err != nil {
+		return 0, fmt.Errorf("decoding bloom filter header: %w", err)
+	}
+	headerLength, _ := section.Seek(0, io.SeekCurrent)
+	newOffset := m.writer.offset
+	_, err := io.Copy(&m.writer, io.NewSectionReader(f, offset, headerLength+int64(header.NumBytes)))
+	return newOffset, err
+}
+
+func (m *fileMerger) writeFooter(first *File) error {
+	protocol := new(thrift.CompactProtocol)
+	encoder := thrift.NewEncoder(protocol.NewWriter(&m.writer))
+
+	for i, columnIndexes := range m.columnIndexes {
+		for j := range columnIndexes {
+			column := &m.rowGroups[i].Columns[j]
+			if column.ColumnIndexOffset == 0 {
+				continue
+			}
+			column.ColumnIndexOffset = m.writer.offset
+			if err := encoder.Encode(&columnIndexes[j]); err != nil {
+				return err
+			}
+			column.ColumnIndexLength = int32(m.writer.offset - column.ColumnIndexOffset)
+		}
+	}
+
+	for i, offsetIndexes := range m.offsetIndexes {
+		for j := range offsetIndexes {
+			column := &m.rowGroups[i].Columns[j]
+			if column.OffsetIndexOffset == 0 {
+				continue
+			}
+			column.OffsetIndexOffset = m.writer.offset
+			if err := encoder.Encode(&offsetIndexes[j]); err != nil {
+				return err
+			}
+			column.OffsetIndexLength = int32(m.writer.offset - column.OffsetIndexOffset)
+		}
+	}
+
+	numRows := int64(0)
+	for i := range m.rowGroups {
+		numRows += m.rowGroups[i].NumRows
+	}
+
+	keyValueMetadata := make([]format.KeyValue, 0, len(m.keyValueMetadata))
+	for k, v := range m.keyValueMetadata {
+		keyValueMetadata = append(keyValueMetadata, format.KeyValue{Key: k, Value: v})
+	}
+	sortKeyValueMetadata(keyValueMetadata)
+
+	footer, err := thrift.Marshal(protocol, &format.FileMetaData{
+		Version:          first.metadata.Version,
+		Schema:           first.metadata.Schema,
+		NumRows:          numRows,
+		RowGroups:        m.rowGroups,
+		KeyValueMetadata: keyValueMetadata,
+		CreatedBy:        m.config.CreatedBy,
+		ColumnOrders:     first.metadata.ColumnOrders,
+	})
+	if err != nil {
+		return err
+	}
+
+	length := len(footer)
+	footer = append(footer, 0, 0, 0, 0)
+	footer = append(footer, "PAR1"...)
+	binary.LittleEndian.PutUint32(footer[length:], uint32(length))
+
+	_, err = m.writer.Write(footer)
+	return err
+}
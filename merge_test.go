@@ -663,3 +663,148 @@ func BenchmarkMergeFiles(b *testing.B) {
 		})
 	}
 }
+
+func TestMergeFiles(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict,bloom"`
+	}
+
+	writeFile := func(rows []Row, options ...parquet.WriterOption) *parquet.File {
+		t.Helper()
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](buffer, options...)
+		if _, err := writer.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	var want []Row
+	files := make([]*parquet.File, 3)
+	for i := range files {
+		rows := make([]Row, 1000)
+		for j := range rows {
+			rows[j] = Row{ID: int64(i*len(rows) + j), Name: fmt.Sprintf("name-%d", j%10)}
+		}
+		want = append(want, rows...)
+
+		options := []parquet.WriterOption{
+			parquet.PageBufferSize(1024),
+			parquet.MaxRowsPerRowGroup(400),
+			parquet.KeyValueMetadata("file", fmt.Sprint(i)),
+			parquet.KeyValueMetadata(fmt.Sprintf("file-%d", i), "true"),
+		}
+		if i == 1 {
+			options = append(options, parquet.Compression(&parquet.Snappy))
+		}
+		files[i] = writeFile(rows, options...)
+	}
+
+	output := new(bytes.Buffer)
+	if err := parquet.MergeFiles(output, files, parquet.KeyValueMetadata("merged", "true")); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if numRowGroups := len(f.RowGroups()); numRowGroups != 9 {
+		t.Errorf("wrong number of row groups: want=9 got=%d", numRowGroups)
+	}
+	if len(f.OffsetIndexes()) != 9*2 || len(f.ColumnIndexes()) != 9*2 {
+		t.Errorf("wrong number of page indexes: offset=%d column=%d", len(f.OffsetIndexes()), len(f.ColumnIndexes()))
+	}
+
+	for i, rowGroup := range f.RowGroups() {
+		bloomFilter := rowGroup.ColumnChunks()[1].BloomFilter()
+		if bloomFilter == nil {
+			t.Fatalf("bloom filter of row group %d was not copied", i)
+		}
+		if ok, err := bloomFilter.Check(parquet.ValueOf("name-3")); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("bloom filter of row group %d does not contain expected value", i)
+		}
+	}
+
+	for key, value := range map[string]string{
+		"file":   "0",
+		"file-0": "true",
+		"file-1": "true",
+		"file-2": "true",
+		"merged": "true",
+	} {
+		if v, ok := f.Lookup(key); !ok || v != value {
+			t.Errorf("wrong value for key %q: want=%q got=%q (found=%t)", key, value, v, ok)
+		}
+	}
+
+	reader := parquet.NewGenericReader[Row](f)
+	defer reader.Close()
+
+	got := make([]Row, len(want))
+	if n, err := reader.Read(got); err != nil && !errors.Is(err, io.EOF) {
+		t.Fatal(err)
+	} else if n != len(want) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(want), n)
+	}
+	assertRowsEqual(t, want, got)
+
+	if err := reader.SeekToRow(2500); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Read(got[:1]); err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, want[2500:2501], got[:1])
+}
+
+func TestMergeFilesSchemaMismatch(t *testing.T) {
+	type RowA struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	type RowB struct {
+		ID    int64  `parquet:"id"`
+		Name  string `parquet:"name"`
+		Extra string `parquet:"extra"`
+	}
+
+	bufferA, bufferB := new(bytes.Buffer), new(bytes.Buffer)
+	if err := parquet.Write(bufferA, []RowA{{ID: 1, Name: "a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := parquet.Write(bufferB, []RowB{{ID: 2, Name: "b", Extra: "x"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	fileA, err := parquet.OpenFile(bytes.NewReader(bufferA.Bytes()), int64(bufferA.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileB, err := parquet.OpenFile(bytes.NewReader(bufferB.Bytes()), int64(bufferB.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	if err := parquet.MergeFiles(output, []*parquet.File{fileA, fileB}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := parquet.Read[RowA](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, []RowA{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, rows)
+}
@@ -0,0 +1,77 @@
+package parquet
+
+// OrderedMap is the representation of parquet groups read into Go values of
+// type any when the OrderedMaps reader option is enabled.
+//
+// Unlike maps, the entries of OrderedMap values are in the order that the
+// columns are declared in the schema, which gives programs a deterministic
+// iteration order, for example to export rows of arbitrary files to CSV.
+type OrderedMap []OrderedMapEntry
+
+// OrderedMapEntry is an entry of an OrderedMap, holding the value of a
+// column and its name.
+type OrderedMapEntry struct {
+	Key   string
+	Value any
+}
+
+// Get returns the value of the entry with the given key in m, and a boolean
+// indicating whether the entry was found.
+func (m OrderedMap) Get(key string) (any, bool) {
+	for _, entry := range m {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns the list of keys of m, in order.
+func (m OrderedMap) Keys() []string {
+	keys := make([]string, len(m))
+	for i, entry := range m {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// orderedMapOf converts the maps of the value reconstructed from node into
+// OrderedMap values ordered like the fields of the node.
+//
+// Values of type any are reconstructed with the structure of the parquet
+// schema, so the groups of LIST and MAP logical types are also converted to
+// OrderedMap values, with entries named after the fields of their groups.
+func orderedMapOf(node Node, value any) any {
+	switch {
+	case value == nil || node.Leaf():
+		return value
+	case node.Repeated():
+		return orderedMapsOf(Required(node), value)
+	}
+
+	m, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	fields := node.Fields()
+	entries := make(OrderedMap, 0, len(fields))
+	for _, field := range fields {
+		if v, ok := m[field.Name()]; ok {
+			entries = append(entries, OrderedMapEntry{
+				Key:   field.Name(),
+				Value: orderedMapOf(field, v),
+			})
+		}
+	}
+	return entries
+}
+
+func orderedMapsOf(elem Node, value any) any {
+	values, ok := value.([]any)
+	if ok {
+		for i, v := range values {
+			values[i] = orderedMapOf(elem, v)
+		}
+	}
+	return value
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/parquet-go/parquet-go/deprecated"
 	"github.com/parquet-go/parquet-go/encoding"
@@ -840,15 +841,25 @@ func (page *floatPage) Data() encoding.Values { return encoding.FloatValues(page
 
 func (page *floatPage) Values() ValueReader { return &floatPageValues{page: page} }
 
-func (page *floatPage) min() float32 { return minFloat32(page.values) }
+func (page *floatPage) min() float32 { min, _ := page.bounds(); return min }
 
-func (page *floatPage) max() float32 { return maxFloat32(page.values) }
+func (page *floatPage) max() float32 { _, max := page.bounds(); return max }
 
-func (page *floatPage) bounds() (min, max float32) { return boundsFloat32(page.values) }
+// bounds returns the min and max values of the page, which are NaN if the page
+// only holds NaN values, like in the column indexes written to parquet files.
+func (page *floatPage) bounds() (min, max float32) {
+	min, max, ok := boundsNonNaNFloat32(page.values)
+	if !ok && len(page.values) > 0 {
+		min, max = float32(math.NaN()), float32(math.NaN())
+	}
+	return min, max
+}
 
+// Bounds returns the min and max values of the page, ignoring NaN values. The
+// boolean is false if the page holds no values other than NaN.
 func (page *floatPage) Bounds() (min, max Value, ok bool) {
-	if ok = len(page.values) > 0; ok {
-		minFloat32, maxFloat32 := page.bounds()
+	minFloat32, maxFloat32, ok := boundsNonNaNFloat32(page.values)
+	if ok {
 		min = page.makeValue(minFloat32)
 		max = page.makeValue(maxFloat32)
 	}
@@ -905,15 +916,24 @@ func (page *doublePage) Data() encoding.Values { return encoding.DoubleValues(pa
 
 func (page *doublePage) Values() ValueReader { return &doublePageValues{page: page} }
 
-func (page *doublePage) min() float64 { return minFloat64(page.values) }
+func (page *doublePage) min() float64 { min, _ := page.bounds(); return min }
 
-func (page *doublePage) max() float64 { return maxFloat64(page.values) }
+func (page *doublePage) max() float64 { _, max := page.bounds(); return max }
 
-func (page *doublePage) bounds() (min, max float64) { return boundsFloat64(page.values) }
+// bounds is like floatPage.bounds but for float64 values.
+func (page *doublePage) bounds() (min, max float64) {
+	min, max, ok := boundsNonNaNFloat64(page.values)
+	if !ok && len(page.values) > 0 {
+		min, max = math.NaN(), math.NaN()
+	}
+	return min, max
+}
 
+// Bounds returns the min and max values of the page, ignoring NaN values. The
+// boolean is false if the page holds no values other than NaN.
 func (page *doublePage) Bounds() (min, max Value, ok bool) {
-	if ok = len(page.values) > 0; ok {
-		minFloat64, maxFloat64 := page.bounds()
+	minFloat64, maxFloat64, ok := boundsNonNaNFloat64(page.values)
+	if ok {
 		min = page.makeValue(minFloat64)
 		max = page.makeValue(maxFloat64)
 	}
@@ -23,3 +23,70 @@ func boundsFixedLenByteArray(data []byte, size int) (min, max []byte) {
 	}
 	return min, max
 }
+
+// boundsNonNaNFloat32 returns the min and max values of data, ignoring NaN
+// values as required by the parquet specification. The boolean is false if
+// data holds no values other than NaN.
+//
+// NaN values are rare, so the bounds are computed with the optimized function
+// when data has none, the values are only compared one by one otherwise.
+func boundsNonNaNFloat32(data []float32) (min, max float32, ok bool) {
+	if !hasNaNFloat32(data) {
+		if ok = len(data) > 0; ok {
+			min, max = boundsFloat32(data)
+		}
+		return min, max, ok
+	}
+	for _, v := range data {
+		switch {
+		case v != v: // NaN
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
+// boundsNonNaNFloat64 is like boundsNonNaNFloat32 but for float64 values.
+func boundsNonNaNFloat64(data []float64) (min, max float64, ok bool) {
+	if !hasNaNFloat64(data) {
+		if ok = len(data) > 0; ok {
+			min, max = boundsFloat64(data)
+		}
+		return min, max, ok
+	}
+	for _, v := range data {
+		switch {
+		case v != v: // NaN
+		case !ok:
+			min, max, ok = v, v, true
+		case v < min:
+			min = v
+		case v > max:
+			max = v
+		}
+	}
+	return min, max, ok
+}
+
+func hasNaNFloat32(data []float32) bool {
+	for _, v := range data {
+		if v != v {
+			return true
+		}
+	}
+	return false
+}
+
+func hasNaNFloat64(data []float64) bool {
+	for _, v := range data {
+		if v != v {
+			return true
+		}
+	}
+	return false
+}
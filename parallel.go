@@ -0,0 +1,128 @@
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// RowBatch is a batch of rows read from a row group of a parquet file by
+// ReadRowGroupsParallel.
+type RowBatch[T any] struct {
+	// Index of the row group that the rows were read from.
+	RowGroup int
+	// The rows of the batch. The slice is owned by the receiver of the batch.
+	Rows []T
+}
+
+// Number of rows read in each batch by ReadRowGroupsParallel.
+const defaultReadBatchSize = 1024
+
+// ReadRowGroupsParallel reads the row groups of f concurrently, using up to
+// the given number of goroutines, and streams the rows in batches on the
+// returned channel.
+//
+// Batches of a given row group are delivered in order, but batches of distinct
+// row groups may be interleaved; programs which need to restore the order of
+// rows must use the RowGroup field of batches.
+//
+// The channel of batches is closed when all the rows have been read, or when
+// an error occurred. The error channel receives at most one error, the first
+// one encountered, and is closed after the channel of batches. Canceling the
+// context stops the reads, in which case the error channel receives the error
+// of the context.
+//
+// A concurrency lower than one is treated as one. The options are applied to
+// the readers of each row group.
+func ReadRowGroupsParallel[T any](ctx context.Context, f *File, concurrency int, options ...ReaderOption) (<-chan RowBatch[T], <-chan error) {
+	batches := make(chan RowBatch[T])
+	errs := make(chan error, 1)
+
+	config, err := NewReaderConfig(options...)
+	if err != nil {
+		close(batches)
+		errs <- err
+		close(errs)
+		return batches, errs
+	}
+
+	rowGroups := f.RowGroups()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(rowGroups) {
+		concurrency = len(rowGroups)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	queue := make(chan int, len(rowGroups))
+	for i := range rowGroups {
+		queue <- i
+	}
+	close(queue)
+
+	once := sync.Once{}
+	fail := func(err error) {
+		once.Do(func() { errs <- err })
+		cancel()
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for rowGroup := range queue {
+				if err := readRowGroupBatches(ctx, rowGroup, rowGroups[rowGroup], config, batches); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(batches)
+		close(errs)
+	}()
+
+	return batches, errs
+}
+
+func readRowGroupBatches[T any](ctx context.Context, index int, rowGroup RowGroup, config *ReaderConfig, batches chan<- RowBatch[T]) (err error) {
+	defer func() {
+		// Readers panic when the options are not compatible with the schema of
+		// the row group, report it as an error to the caller.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("reading parquet row group %d: %v", index, r)
+		}
+	}()
+
+	reader := NewGenericRowGroupReader[T](rowGroup, config)
+	defer reader.Close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows := make([]T, defaultReadBatchSize)
+		n, err := reader.Read(rows)
+		if n > 0 {
+			select {
+			case batches <- RowBatch[T]{RowGroup: index, Rows: rows[:n]}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("reading parquet row group %d: %w", index, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package parquet_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReadRowGroupsParallel(t *testing.T) {
+	const numRows = 10000
+	const numRowGroups = 8
+
+	rows := make([]predicateRow, numRows)
+	for i := range rows {
+		rows[i] = predicateRow{Timestamp: int64(i), Name: "row"}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[predicateRow](buf, parquet.MaxRowsPerRowGroup(numRows/numRowGroups))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != numRowGroups {
+		t.Fatalf("wrong number of row groups: want=%d got=%d", numRowGroups, n)
+	}
+
+	t.Run("read all rows", func(t *testing.T) {
+		batches, errs := parquet.ReadRowGroupsParallel[predicateRow](context.Background(), f, 4)
+
+		offsets := make([]int64, numRowGroups)
+		count := 0
+		for batch := range batches {
+			for _, row := range batch.Rows {
+				want := int64(batch.RowGroup)*(numRows/numRowGroups) + offsets[batch.RowGroup]
+				if row.Timestamp != want {
+					t.Fatalf("row group %d: wrong row: want=%d got=%d", batch.RowGroup, want, row.Timestamp)
+				}
+				offsets[batch.RowGroup]++
+			}
+			count += len(batch.Rows)
+		}
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+		if count != numRows {
+			t.Errorf("wrong number of rows: want=%d got=%d", numRows, count)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		batches, errs := parquet.ReadRowGroupsParallel[predicateRow](ctx, f, 2)
+		<-batches
+		cancel()
+		for range batches {
+		}
+		if err := <-errs; !errors.Is(err, context.Canceled) {
+			t.Errorf("wrong error: want=%v got=%v", context.Canceled, err)
+		}
+	})
+
+	t.Run("invalid column", func(t *testing.T) {
+		batches, errs := parquet.ReadRowGroupsParallel[predicateRow](context.Background(), f, 2, parquet.Columns("nope"))
+		for range batches {
+		}
+		if err := <-errs; err == nil {
+			t.Error("expected an error reading an unknown column")
+		}
+	})
+}
@@ -10,6 +10,7 @@ package parquet
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 )
 
@@ -23,22 +24,8 @@ import (
 // This function is provided for convenience to facilitate reading of parquet
 // files from arbitrary locations in cases where the data set fit in memory.
 func Read[T any](r io.ReaderAt, size int64, options ...ReaderOption) (rows []T, err error) {
-	config, err := NewReaderConfig(options...)
-	if err != nil {
-		return nil, err
-	}
-	file, err := OpenFile(r, size)
-	if err != nil {
-		return nil, err
-	}
-	rows = make([]T, file.NumRows())
-	reader := NewGenericReader[T](file, config)
-	n, err := reader.Read(rows)
-	if err == io.EOF {
-		err = nil
-	}
-	reader.Close()
-	return rows[:n], err
+	rows, _, err = read[T](r, size, options)
+	return rows, err
 }
 
 // ReadFile reads rows of the parquet file at the given path.
@@ -51,16 +38,50 @@ func Read[T any](r io.ReaderAt, size int64, options ...ReaderOption) (rows []T,
 // This function is provided for convenience to facilitate reading of parquet
 // files from the file system in cases where the data set fit in memory.
 func ReadFile[T any](path string, options ...ReaderOption) (rows []T, err error) {
+	rows, _, err = readFile[T](path, options)
+	return rows, err
+}
+
+// ReadFileWithSchema is like ReadFile but also returns the schema of the
+// parquet file at the given path.
+//
+// The schema is useful when reading rows of type any, which are returned as
+// maps: it gives the columns of the rows in the order that they are declared
+// in the file, for example to display them.
+func ReadFileWithSchema[T any](path string, options ...ReaderOption) (rows []T, schema *Schema, err error) {
+	return readFile[T](path, options)
+}
+
+func readFile[T any](path string, options []ReaderOption) ([]T, *Schema, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer f.Close()
 	s, err := f.Stat()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return Read[T](f, s.Size())
+	return read[T](f, s.Size(), options)
+}
+
+func read[T any](r io.ReaderAt, size int64, options []ReaderOption) ([]T, *Schema, error) {
+	config, err := NewReaderConfig(options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := OpenFile(r, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]T, file.NumRows())
+	reader := NewGenericReader[T](file, config)
+	n, err := reader.Read(rows)
+	if err == io.EOF {
+		err = nil
+	}
+	reader.Close()
+	return rows[:n], file.Schema(), err
 }
 
 // Write writes the given list of rows to a parquet file written to w.
@@ -79,10 +100,62 @@ func Write[T any](w io.Writer, rows []T, options ...WriterOption) error {
 	return writer.Close()
 }
 
+// WriteFromChannel writes the rows received from ch to a parquet file written
+// to w, until the channel is closed.
+//
+// Rows are buffered in row groups which are flushed to w as they fill up, so
+// programs producing rows lazily do not need to hold the entire data set in
+// memory. The size of row groups is configured by the writer options, for
+// example with MaxRowsPerRowGroup or MaxRowGroupBytes.
+//
+// The function stops consuming the channel when it encounters an error and
+// returns it; producers sending rows to the channel must not block
+// indefinitely when it happens, for example by selecting on a context which
+// is canceled when the function returns.
+func WriteFromChannel[T any](w io.Writer, ch <-chan T, options ...WriterOption) error {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return err
+	}
+	writer := NewGenericWriter[T](w, config)
+	rows := make([]T, 0, defaultWriteChannelBatchSize)
+
+	for row := range ch {
+		rows = append(rows, row)
+		// Drain the rows that are already available in the channel to write
+		// them in batches, without waiting for the producer.
+	drain:
+		for len(rows) < cap(rows) {
+			select {
+			case row, ok := <-ch:
+				if !ok {
+					break drain
+				}
+				rows = append(rows, row)
+			default:
+				break drain
+			}
+		}
+		if _, err := writer.Write(rows); err != nil {
+			return err
+		}
+		var zero T
+		for i := range rows {
+			rows[i] = zero
+		}
+		rows = rows[:0]
+	}
+
+	return writer.Close()
+}
+
+const defaultWriteChannelBatchSize = 64
+
 // Write writes the given list of rows to a parquet file written to w.
 //
 // This function is provided for convenience to facilitate writing parquet
-// files to the file system.
+// files to the file system. The file is written in place, programs which need
+// readers to never observe partially written files should use WriteFileAtomic.
 func WriteFile[T any](path string, rows []T, options ...WriterOption) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -92,6 +165,61 @@ func WriteFile[T any](path string, rows []T, options ...WriterOption) error {
 	return Write(f, rows, options...)
 }
 
+// WriteFileAtomic is like WriteFile but guarantees that programs reading the
+// file at the given path never observe a partially written parquet file.
+//
+// The rows are written to a temporary file created in the same directory as
+// path, which is renamed to path once the parquet file was completely written
+// and synced to stable storage. The temporary file is removed if an error
+// occurs, leaving any existing file at path untouched. The file retains the
+// permissions of the file it replaces, or is created with permissions 0644.
+//
+// The writes are only atomic on file systems where renaming a file within a
+// directory is atomic, which is the case of most local file systems but may
+// not be true of network or object storage file systems.
+func WriteFileAtomic[T any](path string, rows []T, options ...WriterOption) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		return Write(w, rows, options...)
+	})
+}
+
+func writeFileAtomic(path string, write func(io.Writer) error) (err error) {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		// os.CreateTemp uses the default directory for temporary files when
+		// dir is empty, which may be on a different file system.
+		dir = "."
+	}
+	f, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := f.Chmod(mode); err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
 func atLeastOne(size int) int {
 	return atLeast(size, 1)
 }
@@ -2,11 +2,13 @@ package parquet_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/parquet-go/parquet-go/format"
 	"github.com/parquet-go/parquet-go/internal/quick"
 )
 
@@ -337,6 +340,167 @@ func TestIssue377(t *testing.T) {
 	assertRowsEqual(t, rows, ods)
 }
 
+func TestDeeplyNestedRepeatedGroups(t *testing.T) {
+	type Other struct {
+		Value int32
+		Tags  []string
+	}
+	type Inner struct {
+		Inner []Other
+	}
+	type Nested struct {
+		ID     int64
+		Matrix [][]Other
+		Groups []Inner
+		Cubes  [][][]int32 `parquet:",list"`
+	}
+
+	rows := []Nested{
+		{
+			ID: 1,
+			Matrix: [][]Other{
+				{{Value: 1, Tags: []string{"a", "b"}}, {Value: 2, Tags: []string{}}},
+				{},
+				{{Value: 3, Tags: []string{"c"}}},
+			},
+			Groups: []Inner{
+				{Inner: []Other{{Value: 4, Tags: []string{"x"}}}},
+				{Inner: []Other{}},
+				{Inner: []Other{{Value: 5, Tags: []string{}}, {Value: 6, Tags: []string{"y", "z"}}}},
+			},
+			Cubes: [][][]int32{{{1, 2}, {}}, {}, {{3}}},
+		},
+		{
+			ID:     2,
+			Matrix: [][]Other{},
+			Groups: []Inner{},
+			Cubes:  [][][]int32{},
+		},
+		{
+			ID:     3,
+			Matrix: [][]Other{{}, {{Value: 7, Tags: []string{}}}},
+			Groups: []Inner{{Inner: []Other{}}},
+			Cubes:  [][][]int32{{{}}},
+		},
+	}
+
+	schema := parquet.SchemaOf(Nested{})
+	for _, test := range []struct {
+		path               []string
+		maxRepetitionLevel int
+		maxDefinitionLevel int
+	}{
+		{[]string{"Matrix", "list", "element", "Value"}, 2, 2},
+		{[]string{"Matrix", "list", "element", "Tags"}, 3, 3},
+		{[]string{"Groups", "Inner", "Value"}, 2, 2},
+		{[]string{"Groups", "Inner", "Tags"}, 3, 3},
+		{[]string{"Cubes", "list", "element", "list", "element", "list", "element"}, 3, 3},
+	} {
+		column, ok := schema.Lookup(test.path...)
+		if !ok {
+			t.Fatalf("column %q not found in schema:\n%s", test.path, schema)
+		}
+		if column.MaxRepetitionLevel != test.maxRepetitionLevel || column.MaxDefinitionLevel != test.maxDefinitionLevel {
+			t.Errorf("column %q: wrong levels: want=(%d,%d) got=(%d,%d)", test.path,
+				test.maxRepetitionLevel, test.maxDefinitionLevel,
+				column.MaxRepetitionLevel, column.MaxDefinitionLevel)
+		}
+	}
+
+	t.Run("generic writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewGenericWriter[Nested](buf)
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := parquet.Read[Nested](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, rows, got)
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, schema)
+		for _, row := range rows {
+			if err := w.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := parquet.Read[Nested](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, rows, got)
+	})
+}
+
+func TestNilAndEmptySlices(t *testing.T) {
+	type Row struct {
+		Optional []int `parquet:"optional,list,optional"`
+		List     []int `parquet:"list,list"`
+		Repeated []int `parquet:"repeated"`
+	}
+
+	rows := []Row{
+		{Optional: nil, List: nil, Repeated: nil},
+		{Optional: []int{}, List: []int{}, Repeated: []int{}},
+		{Optional: []int{1, 2}, List: []int{3}, Repeated: []int{4, 5}},
+	}
+	// Only optional lists distinguish nil from empty slices.
+	want := []Row{
+		{Optional: nil, List: []int{}, Repeated: []int{}},
+		{Optional: []int{}, List: []int{}, Repeated: []int{}},
+		{Optional: []int{1, 2}, List: []int{3}, Repeated: []int{4, 5}},
+	}
+
+	read := func(t *testing.T, b []byte) {
+		t.Helper()
+		got, err := parquet.Read[Row](bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, want, got)
+		if got[0].Optional != nil {
+			t.Error("nil slice was not read back as nil")
+		}
+		if got[1].Optional == nil {
+			t.Error("empty slice was read back as nil")
+		}
+	}
+
+	t.Run("generic writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		read(t, buf.Bytes())
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := parquet.NewWriter(buf, parquet.SchemaOf(Row{}))
+		for _, row := range rows {
+			if err := w.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		read(t, buf.Bytes())
+	})
+}
+
 func TestIssue423(t *testing.T) {
 	type Inner struct {
 		Value string `parquet:","`
@@ -416,6 +580,316 @@ func TestIssue423(t *testing.T) {
 	assertRowsEqual(t, writeRows, readRows)
 }
 
+func TestJSONLogicalType(t *testing.T) {
+	type Payload struct {
+		Value int `json:"value"`
+	}
+	type Row struct {
+		Struct   Payload `parquet:"struct,json"`
+		String   string  `parquet:"string,json"`
+		Bytes    []byte  `parquet:"bytes,json"`
+		Optional []byte  `parquet:"optional,json,optional"`
+	}
+
+	rows := []Row{
+		{Struct: Payload{Value: 1}, String: `{"a":1}`, Bytes: []byte(`[1,2]`), Optional: []byte(`null`)},
+		{Struct: Payload{Value: 2}, String: `"b"`, Bytes: []byte(`{}`), Optional: nil},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, element := range f.Metadata().Schema[1:] {
+		if element.LogicalType == nil || element.LogicalType.Json == nil {
+			t.Errorf("column %q is not annotated with the JSON logical type", element.Name)
+		}
+		if element.ConvertedType == nil || *element.ConvertedType != deprecated.Json {
+			t.Errorf("column %q is not annotated with the JSON converted type", element.Name)
+		}
+		if element.Type == nil || *element.Type != format.ByteArray {
+			t.Errorf("column %q is not a BYTE_ARRAY column", element.Name)
+		}
+	}
+	for _, column := range f.Root().Columns() {
+		if lt := column.Type().LogicalType(); lt == nil || lt.Json == nil {
+			t.Errorf("column %q read from the file is not of JSON type: %s", column.Name(), column.Type())
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+}
+
+func TestBSONLogicalType(t *testing.T) {
+	type Row struct {
+		Doc      []byte `parquet:"doc,bson"`
+		String   string `parquet:"string,bson"`
+		Optional []byte `parquet:"optional,bson,optional"`
+	}
+
+	// The documents are opaque to the package, they are stored as-is.
+	rows := []Row{
+		{Doc: []byte("\x05\x00\x00\x00\x00"), String: "\x05\x00\x00\x00\x00", Optional: []byte("\x0c\x00\x00\x00\x10a\x00\x01\x00\x00\x00\x00")},
+		{Doc: []byte("\x0c\x00\x00\x00\x10b\x00\x02\x00\x00\x00\x00"), String: "", Optional: nil},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, element := range f.Metadata().Schema[1:] {
+		if element.LogicalType == nil || element.LogicalType.Bson == nil {
+			t.Errorf("column %q is not annotated with the BSON logical type", element.Name)
+		}
+		if element.ConvertedType == nil || *element.ConvertedType != deprecated.Bson {
+			t.Errorf("column %q is not annotated with the BSON converted type", element.Name)
+		}
+		if element.Type == nil || *element.Type != format.ByteArray {
+			t.Errorf("column %q is not a BYTE_ARRAY column", element.Name)
+		}
+	}
+	for _, column := range f.Root().Columns() {
+		if lt := column.Type().LogicalType(); lt == nil || lt.Bson == nil {
+			t.Errorf("column %q read from the file is not of BSON type: %s", column.Name(), column.Type())
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+}
+
+func TestWriteFromChannel(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	const numRows = 1000
+	want := make([]Row, numRows)
+	for i := range want {
+		want[i] = Row{ID: int64(i), Name: fmt.Sprintf("row-%d", i)}
+	}
+
+	ch := make(chan Row, 16)
+	go func() {
+		defer close(ch)
+		for _, row := range want {
+			ch <- row
+		}
+	}()
+
+	buf := new(bytes.Buffer)
+	if err := parquet.WriteFromChannel(buf, ch, parquet.MaxRowsPerRowGroup(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != numRows/100 {
+		t.Errorf("wrong number of row groups: want=%d got=%d", numRows/100, n)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, want, got)
+
+	t.Run("error", func(t *testing.T) {
+		ch := make(chan Row)
+		done := make(chan struct{})
+		go func() {
+			defer close(ch)
+			for _, row := range want {
+				select {
+				case ch <- row:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		writeErr := errors.New("write failed")
+		err := parquet.WriteFromChannel(failingWriter{writeErr}, ch, parquet.MaxRowsPerRowGroup(10))
+		close(done)
+		if !errors.Is(err, writeErr) {
+			t.Errorf("expected the write error to be returned, got %v", err)
+		}
+	})
+}
+
+func TestWriteConfigOptions(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i].ID = int64(i)
+	}
+
+	buf := new(bytes.Buffer)
+	err := parquet.Write(buf, rows,
+		parquet.MaxRowsPerRowGroup(10),
+		parquet.DataPageStatistics(true),
+		&parquet.WriterConfig{CreatedBy: "test"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.RowGroups()); n != 10 {
+		t.Errorf("wrong number of row groups: want=10 got=%d", n)
+	}
+	if createdBy := f.Metadata().CreatedBy; createdBy != "test" {
+		t.Errorf("wrong created by: %q", createdBy)
+	}
+
+	pages := parquet.NewRawPageReader(f.RowGroups()[0].ColumnChunks()[0])
+	defer pages.Close()
+	header, _, err := pages.ReadRawPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, ok := header.PageHeader.(parquet.DataPageHeader); !ok || page.MinValue() == nil {
+		t.Error("the data page has no statistics")
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestWriteFileAtomic(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+	rows := []Row{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rows.parquet")
+
+	assertDirEntries := func(t *testing.T, want ...string) {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("wrong directory entries: want=%q got=%q", want, names)
+		}
+	}
+
+	if err := parquet.WriteFileAtomic(path, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.ReadFile[Row](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+	assertDirEntries(t, "rows.parquet")
+
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	rows = append(rows, Row{ID: 3, Name: "three"})
+	if err := parquet.WriteFileAtomic(path, rows); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = parquet.ReadFile[Row](path); err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("the permissions of the replaced file were not retained: %v", mode)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		err := parquet.WriteFileAtomic(path, []Row{{ID: 4}}, parquet.PageBufferSize(-1))
+		if err == nil {
+			t.Fatal("expected an error writing the file with an invalid configuration")
+		}
+		got, err := parquet.ReadFile[Row](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, rows, got)
+		assertDirEntries(t, "rows.parquet")
+	})
+}
+
+func TestReadFileWithSchema(t *testing.T) {
+	type Row struct {
+		Name  string  `parquet:"name"`
+		ID    int64   `parquet:"id"`
+		Score float64 `parquet:"score"`
+	}
+	rows := []Row{{Name: "one", ID: 1, Score: 0.5}, {Name: "two", ID: 2, Score: 1.5}}
+
+	path := filepath.Join(t.TempDir(), "rows.parquet")
+	if err := parquet.WriteFile(path, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	got, schema, err := parquet.ReadFileWithSchema[any](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+	}
+	if value := got[1].(map[string]any)["name"]; value != "two" {
+		t.Errorf("wrong value of the name column: %v", value)
+	}
+	var columns []string
+	for _, field := range schema.Fields() {
+		columns = append(columns, field.Name())
+	}
+	if want := []string{"name", "id", "score"}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("wrong columns: want=%q got=%q", want, columns)
+	}
+
+	// The reader options are applied when reading the file.
+	type UpperRow struct {
+		Name string `parquet:"NAME"`
+	}
+	upper, err := parquet.ReadFile[UpperRow](path, parquet.CaseInsensitiveColumns(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, []UpperRow{{Name: "one"}, {Name: "two"}}, upper)
+}
+
 func TestReadFileGenericMultipleRowGroupsMultiplePages(t *testing.T) {
 	type MyRow struct {
 		ID    [16]byte `parquet:"id,delta,uuid"`
@@ -1246,3 +1720,150 @@ func TestParquetAnyValueConversions(t *testing.T) {
 		})
 	}
 }
+
+func TestUUIDRoundTrip(t *testing.T) {
+	type ID [16]byte
+
+	type Row struct {
+		UUID     uuid.UUID  `parquet:"uuid"`
+		Tagged   uuid.UUID  `parquet:"tagged,uuid"`
+		Named    ID         `parquet:"named,uuid"`
+		Optional *uuid.UUID `parquet:"optional,optional,uuid"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	for _, column := range []string{"uuid", "tagged", "named", "optional"} {
+		leaf, _ := schema.Lookup(column)
+		if lt := leaf.Node.Type().LogicalType(); lt == nil || lt.UUID == nil {
+			t.Errorf("%s: column is not of UUID logical type: %v", column, leaf.Node.Type())
+		}
+	}
+
+	rows := make([]Row, 10)
+	for i := range rows {
+		rows[i] = Row{UUID: uuid.New(), Tagged: uuid.New(), Named: ID(uuid.New())}
+		if i%2 == 0 {
+			id := uuid.New()
+			rows[i].Optional = &id
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestEnumRoundTrip(t *testing.T) {
+	type Color string
+
+	type Row struct {
+		Color    Color   `parquet:"color,enum"`
+		Optional *Color  `parquet:"optional,optional,enum"`
+		Colors   []Color `parquet:"colors,enum"`
+	}
+
+	red, green := Color("red"), Color("green")
+	rows := []Row{
+		{Color: red, Optional: &green, Colors: []Color{red, green}},
+		{Color: green, Colors: []Color{}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, column := range []string{"color", "optional", "colors"} {
+		leaf, _ := f.Schema().Lookup(column)
+		typ := leaf.Node.Type()
+		if lt := typ.LogicalType(); lt == nil || lt.Enum == nil {
+			t.Errorf("%s: column is not of ENUM logical type: %v", column, typ)
+		}
+		if typ.Kind() != parquet.ByteArray {
+			t.Errorf("%s: wrong physical type: %v", column, typ.Kind())
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
+
+func TestMapRoundTrip(t *testing.T) {
+	type Row struct {
+		Attrs    map[string]int64            `parquet:"attrs"`
+		Optional map[string]int64            `parquet:"optional,optional"`
+		Nested   map[string]map[int32]string `parquet:"nested"`
+	}
+
+	rows := []Row{
+		{
+			Attrs:    map[string]int64{"a": 1, "b": 2, "c": 3},
+			Optional: map[string]int64{"x": 1},
+			Nested:   map[string]map[int32]string{"one": {1: "1"}, "empty": {}},
+		},
+		{
+			Attrs:    map[string]int64{},
+			Optional: map[string]int64{}, // empty maps are distinct from null maps
+			Nested:   map[string]map[int32]string{},
+		},
+		{
+			Attrs:  map[string]int64{"d": 4},
+			Nested: map[string]map[int32]string{},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, element := range f.Metadata().Schema {
+		switch element.Name {
+		case "attrs", "optional", "nested":
+			if lt := element.LogicalType; lt == nil || lt.Map == nil {
+				t.Errorf("%s: group is not of MAP logical type: %v", element.Name, lt)
+			}
+		}
+	}
+
+	// Read the rows in a buffer holding maps to verify that entries of
+	// previous values are not retained.
+	got := make([]Row, len(rows))
+	for i := range got {
+		got[i].Attrs = map[string]int64{"stale": 0}
+		got[i].Optional = map[string]int64{"stale": 0}
+	}
+	r := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer r.Close()
+	if n, err := r.Read(got); n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d (%v)", len(rows), n, err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows, got)
+	}
+}
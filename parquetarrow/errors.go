@@ -0,0 +1,13 @@
+package parquetarrow
+
+import "errors"
+
+var (
+	// errNestedColumn is returned when converting schemas with nested or
+	// repeated columns, which are not supported yet.
+	errNestedColumn = errors.New("nested and repeated columns are not supported")
+
+	// errUnsupportedType is returned when converting columns of types which
+	// have no Arrow equivalent supported by the package.
+	errUnsupportedType = errors.New("unsupported column type")
+)
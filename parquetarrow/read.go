@@ -0,0 +1,373 @@
+// Package parquetarrow converts between parquet files and Apache Arrow records.
+//
+// The package is distributed as a separate module so programs which do not use
+// Arrow do not have to depend on the Arrow libraries.
+//
+// The conversions currently support schemas made of top-level columns of
+// primitive types and strings; nested and repeated columns are not supported.
+package parquetarrow
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// ReadFile reads all the rows of f into an Arrow record allocated with alloc.
+//
+// The columns argument selects the names of the top-level columns of f which
+// are read, in the order of the record fields. When no columns are given, all
+// the columns of f are read.
+//
+// Parquet logical types are mapped to the Arrow types that represent them,
+// for example columns of STRING logical type are read as Arrow strings, and
+// columns of TIMESTAMP logical type as Arrow timestamps of the same unit.
+// Optional columns produce nullable Arrow fields.
+//
+// The context is checked between pages, returning its error if it is canceled
+// before all the pages are decoded.
+//
+// The program must call Release on the returned record when it does not need
+// it anymore.
+func ReadFile(ctx context.Context, f *parquet.File, alloc memory.Allocator, columns ...string) (arrow.Record, error) {
+	leaves, schema, err := arrowSchemaOf(f.Schema(), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := array.NewRecordBuilder(alloc, schema)
+	defer builder.Release()
+
+	for _, rowGroup := range f.RowGroups() {
+		chunks := rowGroup.ColumnChunks()
+		for i, leaf := range leaves {
+			err := readColumnChunk(ctx, chunks[leaf.columnIndex], builder.Field(i), leaf.maxDefinitionLevel)
+			if err != nil {
+				return nil, fmt.Errorf("reading column %q: %w", leaf.name, err)
+			}
+		}
+	}
+
+	return builder.NewRecord(), nil
+}
+
+func readColumnChunk(ctx context.Context, chunk parquet.ColumnChunk, builder array.Builder, maxDefinitionLevel int) error {
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	builder.Reserve(int(chunk.NumValues()))
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		readPage(page, builder, maxDefinitionLevel)
+		parquet.Release(page)
+	}
+}
+
+// readPage appends the values of page to builder.
+//
+// The values are taken from the buffers of the page, the dictionary indexes of
+// dictionary-encoded pages being resolved against the values of the dictionary
+// page, and appended to the builder in bulk, with a validity bitmap derived
+// from the definition levels of the page.
+func readPage(page parquet.Page, builder array.Builder, maxDefinitionLevel int) {
+	var valid []bool
+	if maxDefinitionLevel > 0 {
+		definitionLevels := page.DefinitionLevels()
+		valid = make([]bool, len(definitionLevels))
+		for i, level := range definitionLevels {
+			valid[i] = int(level) == maxDefinitionLevel
+		}
+	}
+
+	data := page.Data()
+	numValues := int(page.NumValues() - page.NumNulls())
+
+	var indexes []int32
+	if dict := page.Dictionary(); dict != nil {
+		indexes = data.Int32()
+		data = dict.Page().Data()
+		numValues = int(dict.Len())
+	}
+
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		bits := data.Boolean()
+		values := make([]bool, numValues)
+		for i := range values {
+			values[i] = (bits[i/8]>>(i%8))&1 != 0
+		}
+		b.AppendValues(gather(values, indexes, valid), valid)
+	case *array.Int8Builder:
+		b.AppendValues(convert[int8](gather(data.Int32(), indexes, valid)), valid)
+	case *array.Int16Builder:
+		b.AppendValues(convert[int16](gather(data.Int32(), indexes, valid)), valid)
+	case *array.Int32Builder:
+		b.AppendValues(gather(data.Int32(), indexes, valid), valid)
+	case *array.Int64Builder:
+		b.AppendValues(gather(data.Int64(), indexes, valid), valid)
+	case *array.Uint8Builder:
+		b.AppendValues(convert[uint8](gather(data.Uint32(), indexes, valid)), valid)
+	case *array.Uint16Builder:
+		b.AppendValues(convert[uint16](gather(data.Uint32(), indexes, valid)), valid)
+	case *array.Uint32Builder:
+		b.AppendValues(gather(data.Uint32(), indexes, valid), valid)
+	case *array.Uint64Builder:
+		b.AppendValues(gather(data.Uint64(), indexes, valid), valid)
+	case *array.Float32Builder:
+		b.AppendValues(gather(data.Float(), indexes, valid), valid)
+	case *array.Float64Builder:
+		b.AppendValues(gather(data.Double(), indexes, valid), valid)
+	case *array.Date32Builder:
+		b.AppendValues(convert[arrow.Date32](gather(data.Int32(), indexes, valid)), valid)
+	case *array.Time32Builder:
+		b.AppendValues(convert[arrow.Time32](gather(data.Int32(), indexes, valid)), valid)
+	case *array.Time64Builder:
+		b.AppendValues(convert[arrow.Time64](gather(data.Int64(), indexes, valid)), valid)
+	case *array.TimestampBuilder:
+		b.AppendValues(convert[arrow.Timestamp](gather(data.Int64(), indexes, valid)), valid)
+	case *array.StringBuilder:
+		// The values are copied by the builder, the string conversion is
+		// avoided by appending them to the underlying binary builder.
+		b.BinaryBuilder.AppendValues(gather(byteArraysOf(data), indexes, valid), valid)
+	case *array.BinaryBuilder:
+		b.AppendValues(gather(byteArraysOf(data), indexes, valid), valid)
+	case *array.FixedSizeBinaryBuilder:
+		b.AppendValues(gather(fixedLenByteArraysOf(data), indexes, valid), valid)
+	default:
+		panic("cannot read parquet values into arrow arrays of type " + builder.Type().String())
+	}
+}
+
+// gather returns the values of a parquet page at the positions of the Arrow
+// array they are appended to.
+//
+// The values are looked up by index when indexes is not nil, and spread over
+// the positions set in valid when it is not nil, since parquet pages do not
+// store null values. The values are returned as-is when both are nil.
+func gather[T any](values []T, indexes []int32, valid []bool) []T {
+	if indexes == nil && valid == nil {
+		return values
+	}
+
+	n := len(indexes)
+	if valid != nil {
+		n = len(valid)
+	}
+
+	gathered := make([]T, n)
+	j := 0
+	for i := range gathered {
+		if valid != nil && !valid[i] {
+			continue
+		}
+		if indexes != nil {
+			gathered[i] = values[indexes[j]]
+		} else {
+			gathered[i] = values[j]
+		}
+		j++
+	}
+	return gathered
+}
+
+type number interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+func convert[To, From number](values []From) []To {
+	converted := make([]To, len(values))
+	for i, v := range values {
+		converted[i] = To(v)
+	}
+	return converted
+}
+
+func byteArraysOf(data encoding.Values) [][]byte {
+	values, offsets := data.ByteArray()
+	byteArrays := make([][]byte, len(offsets)-1)
+	for i := range byteArrays {
+		byteArrays[i] = values[offsets[i]:offsets[i+1]:offsets[i+1]]
+	}
+	return byteArrays
+}
+
+func fixedLenByteArraysOf(data encoding.Values) [][]byte {
+	values, size := data.FixedLenByteArray()
+	byteArrays := make([][]byte, len(values)/size)
+	for i := range byteArrays {
+		byteArrays[i] = values[i*size : (i+1)*size : (i+1)*size]
+	}
+	return byteArrays
+}
+
+type arrowLeaf struct {
+	name               string
+	columnIndex        int
+	maxDefinitionLevel int
+}
+
+func arrowSchemaOf(schema *parquet.Schema, columns []string) ([]arrowLeaf, *arrow.Schema, error) {
+	if len(columns) == 0 {
+		fields := schema.Fields()
+		columns = make([]string, len(fields))
+		for i, field := range fields {
+			columns[i] = field.Name()
+		}
+	}
+
+	leaves := make([]arrowLeaf, len(columns))
+	fields := make([]arrow.Field, len(columns))
+
+	for i, name := range columns {
+		leaf, ok := schema.Lookup(name)
+		if !ok {
+			if field := fieldByName(schema, name); field != nil {
+				return nil, nil, fmt.Errorf("column %q: %w", name, errNestedColumn)
+			}
+			return nil, nil, fmt.Errorf("column %q does not exist in the parquet schema", name)
+		}
+		if leaf.Node.Repeated() {
+			return nil, nil, fmt.Errorf("column %q: %w", name, errNestedColumn)
+		}
+
+		dataType, err := arrowTypeOf(leaf.Node.Type())
+		if err != nil {
+			return nil, nil, fmt.Errorf("column %q: %w", name, err)
+		}
+
+		leaves[i] = arrowLeaf{
+			name:               name,
+			columnIndex:        leaf.ColumnIndex,
+			maxDefinitionLevel: leaf.MaxDefinitionLevel,
+		}
+		fields[i] = arrow.Field{
+			Name:     name,
+			Type:     dataType,
+			Nullable: leaf.Node.Optional(),
+		}
+	}
+
+	return leaves, arrow.NewSchema(fields, nil), nil
+}
+
+func fieldByName(node parquet.Node, name string) parquet.Field {
+	for _, field := range node.Fields() {
+		if field.Name() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// arrowTypeOf returns the Arrow type representing values of the parquet type t.
+func arrowTypeOf(t parquet.Type) (arrow.DataType, error) {
+	lt := t.LogicalType()
+
+	switch t.Kind() {
+	case parquet.Boolean:
+		return arrow.FixedWidthTypes.Boolean, nil
+
+	case parquet.Int32:
+		switch {
+		case lt == nil:
+			return arrow.PrimitiveTypes.Int32, nil
+		case lt.Integer != nil:
+			return arrowIntegerType(lt.Integer)
+		case lt.Date != nil:
+			return arrow.FixedWidthTypes.Date32, nil
+		case lt.Time != nil && lt.Time.Unit.Millis != nil:
+			return arrow.FixedWidthTypes.Time32ms, nil
+		}
+
+	case parquet.Int64:
+		switch {
+		case lt == nil:
+			return arrow.PrimitiveTypes.Int64, nil
+		case lt.Integer != nil:
+			return arrowIntegerType(lt.Integer)
+		case lt.Timestamp != nil:
+			timestamp := &arrow.TimestampType{Unit: arrowTimeUnit(&lt.Timestamp.Unit)}
+			if lt.Timestamp.IsAdjustedToUTC {
+				timestamp.TimeZone = "UTC"
+			}
+			return timestamp, nil
+		case lt.Time != nil && lt.Time.Unit.Micros != nil:
+			return arrow.FixedWidthTypes.Time64us, nil
+		case lt.Time != nil && lt.Time.Unit.Nanos != nil:
+			return arrow.FixedWidthTypes.Time64ns, nil
+		}
+
+	case parquet.Float:
+		return arrow.PrimitiveTypes.Float32, nil
+
+	case parquet.Double:
+		return arrow.PrimitiveTypes.Float64, nil
+
+	case parquet.ByteArray:
+		switch {
+		case lt == nil, lt.Bson != nil, lt.Geometry != nil, lt.Geography != nil:
+			return arrow.BinaryTypes.Binary, nil
+		case lt.UTF8 != nil, lt.Enum != nil, lt.Json != nil:
+			return arrow.BinaryTypes.String, nil
+		}
+
+	case parquet.FixedLenByteArray:
+		if lt == nil || lt.UUID != nil {
+			return &arrow.FixedSizeBinaryType{ByteWidth: t.Length()}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnsupportedType, t)
+}
+
+func arrowIntegerType(t *format.IntType) (arrow.DataType, error) {
+	switch {
+	case t.IsSigned && t.BitWidth == 8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case t.IsSigned && t.BitWidth == 16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case t.IsSigned && t.BitWidth == 32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case t.IsSigned && t.BitWidth == 64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case !t.IsSigned && t.BitWidth == 8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case !t.IsSigned && t.BitWidth == 16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case !t.IsSigned && t.BitWidth == 32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case !t.IsSigned && t.BitWidth == 64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	}
+	return nil, fmt.Errorf("%w: integer of %d bits", errUnsupportedType, t.BitWidth)
+}
+
+func arrowTimeUnit(unit *format.TimeUnit) arrow.TimeUnit {
+	switch {
+	case unit.Millis != nil:
+		return arrow.Millisecond
+	case unit.Micros != nil:
+		return arrow.Microsecond
+	default:
+		return arrow.Nanosecond
+	}
+}
@@ -0,0 +1,232 @@
+package parquetarrow_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/parquetarrow"
+)
+
+type arrowRow struct {
+	ID       int64     `parquet:"id"`
+	Count    uint32    `parquet:"count"`
+	Flag     bool      `parquet:"flag"`
+	Score    float32   `parquet:"score"`
+	Ratio    float64   `parquet:"ratio"`
+	Name     string    `parquet:"name,dict"`
+	Email    *string   `parquet:"email,optional"`
+	Payload  []byte    `parquet:"payload"`
+	Time     time.Time `parquet:"time,timestamp(microsecond)"`
+	Birthday int32     `parquet:"birthday,date"`
+}
+
+func makeArrowRows(n int) []arrowRow {
+	rows := make([]arrowRow, n)
+	for i := range rows {
+		rows[i] = arrowRow{
+			ID:       int64(i),
+			Count:    uint32(i * 3),
+			Flag:     i%2 == 0,
+			Score:    float32(i) / 2,
+			Ratio:    float64(i) / 3,
+			Name:     []string{"one", "two", "three"}[i%3],
+			Payload:  []byte{byte(i), byte(i >> 8)},
+			Time:     time.Unix(int64(i), 1000).UTC(),
+			Birthday: int32(10000 + i),
+		}
+		if i%4 != 0 {
+			email := "user@example.com"
+			rows[i].Email = &email
+		}
+	}
+	return rows
+}
+
+func openFile(t *testing.T, rows []arrowRow, options ...parquet.WriterOption) *parquet.File {
+	t.Helper()
+	output := new(bytes.Buffer)
+	if err := parquet.Write(output, rows, options...); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestReadFile(t *testing.T) {
+	rows := makeArrowRows(1000)
+	f := openFile(t, rows, parquet.MaxRowsPerRowGroup(300), parquet.PageBufferSize(1024))
+
+	alloc := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer alloc.AssertSize(t, 0)
+
+	record, err := parquetarrow.ReadFile(context.Background(), f, alloc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer record.Release()
+
+	if n := record.NumRows(); n != int64(len(rows)) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), n)
+	}
+
+	wantTypes := []arrow.DataType{
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Uint32,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Float32,
+		arrow.PrimitiveTypes.Float64,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.Binary,
+		&arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"},
+		arrow.FixedWidthTypes.Date32,
+	}
+	schema := record.Schema()
+	for i, want := range wantTypes {
+		field := schema.Field(i)
+		if !arrow.TypeEqual(field.Type, want) {
+			t.Errorf("column %q: wrong arrow type: want=%s got=%s", field.Name, want, field.Type)
+		}
+		if nullable := field.Name == "email"; field.Nullable != nullable {
+			t.Errorf("column %q: wrong nullability: want=%t got=%t", field.Name, nullable, field.Nullable)
+		}
+	}
+
+	ids := record.Column(0).(*array.Int64)
+	counts := record.Column(1).(*array.Uint32)
+	flags := record.Column(2).(*array.Boolean)
+	scores := record.Column(3).(*array.Float32)
+	ratios := record.Column(4).(*array.Float64)
+	names := record.Column(5).(*array.String)
+	emails := record.Column(6).(*array.String)
+	payloads := record.Column(7).(*array.Binary)
+	times := record.Column(8).(*array.Timestamp)
+	birthdays := record.Column(9).(*array.Date32)
+
+	for i, row := range rows {
+		switch {
+		case ids.Value(i) != row.ID,
+			counts.Value(i) != row.Count,
+			flags.Value(i) != row.Flag,
+			scores.Value(i) != row.Score,
+			ratios.Value(i) != row.Ratio,
+			names.Value(i) != row.Name,
+			!bytes.Equal(payloads.Value(i), row.Payload),
+			times.Value(i) != arrow.Timestamp(row.Time.UnixMicro()),
+			birthdays.Value(i) != arrow.Date32(row.Birthday):
+			t.Fatalf("row %d: values mismatch", i)
+		}
+		if row.Email == nil {
+			if !emails.IsNull(i) {
+				t.Fatalf("row %d: expected a null email", i)
+			}
+		} else if emails.IsNull(i) || emails.Value(i) != *row.Email {
+			t.Fatalf("row %d: wrong email: want=%q got=%q", i, *row.Email, emails.Value(i))
+		}
+	}
+}
+
+func TestReadFileColumns(t *testing.T) {
+	rows := makeArrowRows(100)
+	f := openFile(t, rows)
+
+	record, err := parquetarrow.ReadFile(context.Background(), f, memory.DefaultAllocator, "name", "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer record.Release()
+
+	if n := record.NumCols(); n != 2 {
+		t.Fatalf("wrong number of columns: want=2 got=%d", n)
+	}
+	names := record.Column(0).(*array.String)
+	ids := record.Column(1).(*array.Int64)
+	for i, row := range rows {
+		if names.Value(i) != row.Name || ids.Value(i) != row.ID {
+			t.Fatalf("row %d: values mismatch", i)
+		}
+	}
+
+	if _, err := parquetarrow.ReadFile(context.Background(), f, memory.DefaultAllocator, "missing"); err == nil {
+		t.Error("expected an error reading a column which does not exist")
+	}
+}
+
+func TestReadFileNested(t *testing.T) {
+	type Row struct {
+		Tags []string `parquet:"tags"`
+	}
+	output := new(bytes.Buffer)
+	if err := parquet.Write(output, []Row{{Tags: []string{"a"}}}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parquetarrow.ReadFile(context.Background(), f, memory.DefaultAllocator); err == nil {
+		t.Error("expected an error reading a repeated column")
+	}
+}
+
+func TestReadFileCanceled(t *testing.T) {
+	f := openFile(t, makeArrowRows(10))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := parquetarrow.ReadFile(ctx, f, memory.DefaultAllocator); !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error reading with a canceled context: %v", err)
+	}
+}
+
+func TestReadFileOptionalDictionary(t *testing.T) {
+	type Row struct {
+		Code  *int16  `parquet:"code,optional,dict"`
+		Label *string `parquet:"label,optional,dict"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		if i%3 != 0 {
+			code, label := int16(i%7-3), []string{"a", "b", "c", "d"}[i%4]
+			rows[i] = Row{Code: &code, Label: &label}
+		}
+	}
+	output := new(bytes.Buffer)
+	if err := parquet.Write(output, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := parquetarrow.ReadFile(context.Background(), f, memory.DefaultAllocator, "code", "label")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer record.Release()
+
+	codes := record.Column(0).(*array.Int16)
+	labels := record.Column(1).(*array.String)
+	for i, row := range rows {
+		switch {
+		case row.Code == nil:
+			if !codes.IsNull(i) || !labels.IsNull(i) {
+				t.Fatalf("row %d: expected null values", i)
+			}
+		case codes.IsNull(i) || codes.Value(i) != *row.Code:
+			t.Fatalf("row %d: wrong code: want=%d got=%d", i, *row.Code, codes.Value(i))
+		case labels.IsNull(i) || labels.Value(i) != *row.Label:
+			t.Fatalf("row %d: wrong label: want=%q got=%q", i, *row.Label, labels.Value(i))
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package parquetarrow
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/parquet-go/parquet-go"
+)
+
+// WriteRecord writes the rows of rec to a parquet file written to dst.
+//
+// The schema of the parquet file is derived from the Arrow schema of rec, each
+// field becoming a top-level column of the Parquet type which represents its
+// Arrow type; nullable fields are written to optional columns, of which the
+// definition levels are derived from the validity bitmaps of the arrays. The
+// columns of parquet groups are ordered by name, so the order of the columns
+// in the file may differ from the order of the fields of rec.
+//
+// The values are written column by column from the Arrow arrays to a single
+// row group, without converting them to rows.
+//
+// The options configure the parquet writer, for example to set the compression
+// codec of the columns. Options setting a schema are ignored.
+func WriteRecord(dst io.Writer, rec arrow.Record, options ...parquet.WriterOption) error {
+	schema, err := parquetSchemaOf(rec.Schema())
+	if err != nil {
+		return err
+	}
+
+	buffer := parquet.NewBuffer(schema)
+	columns := buffer.ColumnBuffers()
+	values := make([]parquet.Value, 0, 1024)
+
+	for i, field := range rec.Schema().Fields() {
+		leaf, _ := schema.Lookup(field.Name)
+		column := columns[leaf.ColumnIndex]
+		if err := writeArray(column, rec.Column(i), leaf, values); err != nil {
+			return fmt.Errorf("writing column %q: %w", field.Name, err)
+		}
+	}
+
+	writerOptions := make([]parquet.WriterOption, 0, len(options)+1)
+	writerOptions = append(writerOptions, options...)
+	writerOptions = append(writerOptions, schema)
+
+	w := parquet.NewWriter(dst, writerOptions...)
+	if _, err := w.WriteRowGroup(buffer); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func writeArray(column parquet.ColumnBuffer, arr arrow.Array, leaf parquet.LeafColumn, values []parquet.Value) error {
+	// Arrays of required columns can be written directly from the Arrow
+	// buffers when the parquet column buffer supports it.
+	if leaf.MaxDefinitionLevel == 0 {
+		if ok, err := writeArrayValues(column, arr); ok {
+			return err
+		}
+	}
+
+	valueOf := valueFuncOf(arr.DataType())
+
+	for i, n := 0, arr.Len(); i < n; {
+		values = values[:0]
+		for ; i < n && len(values) < cap(values); i++ {
+			if arr.IsNull(i) {
+				values = append(values, parquet.Value{}.Level(0, 0, leaf.ColumnIndex))
+			} else {
+				values = append(values, valueOf(arr, i).Level(0, leaf.MaxDefinitionLevel, leaf.ColumnIndex))
+			}
+		}
+		if _, err := column.WriteValues(values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArrayValues(column parquet.ColumnBuffer, arr arrow.Array) (bool, error) {
+	var err error
+	switch a := arr.(type) {
+	case *array.Int32:
+		w, ok := column.(parquet.Int32Writer)
+		if !ok {
+			return false, nil
+		}
+		_, err = w.WriteInt32s(a.Int32Values())
+	case *array.Int64:
+		w, ok := column.(parquet.Int64Writer)
+		if !ok {
+			return false, nil
+		}
+		_, err = w.WriteInt64s(a.Int64Values())
+	case *array.Float32:
+		w, ok := column.(parquet.FloatWriter)
+		if !ok {
+			return false, nil
+		}
+		_, err = w.WriteFloats(a.Float32Values())
+	case *array.Float64:
+		w, ok := column.(parquet.DoubleWriter)
+		if !ok {
+			return false, nil
+		}
+		_, err = w.WriteDoubles(a.Float64Values())
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+func parquetSchemaOf(schema *arrow.Schema) (*parquet.Schema, error) {
+	group := make(parquet.Group, schema.NumFields())
+
+	for _, field := range schema.Fields() {
+		if _, exists := group[field.Name]; exists {
+			return nil, fmt.Errorf("field %q is repeated in the arrow schema", field.Name)
+		}
+		node, err := parquetNodeOf(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		if field.Nullable {
+			node = parquet.Optional(node)
+		}
+		group[field.Name] = node
+	}
+
+	return parquet.NewSchema("arrow", group), nil
+}
+
+// parquetNodeOf returns the parquet node representing values of the Arrow type t.
+func parquetNodeOf(t arrow.DataType) (parquet.Node, error) {
+	switch t := t.(type) {
+	case *arrow.BooleanType:
+		return parquet.Leaf(parquet.BooleanType), nil
+	case *arrow.Int8Type:
+		return parquet.Int(8), nil
+	case *arrow.Int16Type:
+		return parquet.Int(16), nil
+	case *arrow.Int32Type:
+		return parquet.Int(32), nil
+	case *arrow.Int64Type:
+		return parquet.Int(64), nil
+	case *arrow.Uint8Type:
+		return parquet.Uint(8), nil
+	case *arrow.Uint16Type:
+		return parquet.Uint(16), nil
+	case *arrow.Uint32Type:
+		return parquet.Uint(32), nil
+	case *arrow.Uint64Type:
+		return parquet.Uint(64), nil
+	case *arrow.Float32Type:
+		return parquet.Leaf(parquet.FloatType), nil
+	case *arrow.Float64Type:
+		return parquet.Leaf(parquet.DoubleType), nil
+	case *arrow.StringType:
+		return parquet.String(), nil
+	case *arrow.BinaryType:
+		return parquet.Leaf(parquet.ByteArrayType), nil
+	case *arrow.FixedSizeBinaryType:
+		return parquet.Leaf(parquet.FixedLenByteArrayType(t.ByteWidth)), nil
+	case *arrow.Date32Type:
+		return parquet.Date(), nil
+	case *arrow.Time32Type:
+		if t.Unit == arrow.Millisecond {
+			return parquet.Time(parquet.Millisecond), nil
+		}
+	case *arrow.Time64Type:
+		if unit, ok := parquetTimeUnit(t.Unit); ok {
+			return parquet.Time(unit), nil
+		}
+	case *arrow.TimestampType:
+		if unit, ok := parquetTimeUnit(t.Unit); ok {
+			return parquet.TimestampAdjusted(unit, t.TimeZone != ""), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errUnsupportedType, t)
+}
+
+func parquetTimeUnit(unit arrow.TimeUnit) (parquet.TimeUnit, bool) {
+	switch unit {
+	case arrow.Millisecond:
+		return parquet.Millisecond, true
+	case arrow.Microsecond:
+		return parquet.Microsecond, true
+	case arrow.Nanosecond:
+		return parquet.Nanosecond, true
+	default:
+		return nil, false
+	}
+}
+
+type valueFunc func(arrow.Array, int) parquet.Value
+
+func valueFuncOf(t arrow.DataType) valueFunc {
+	switch t.ID() {
+	case arrow.BOOL:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.BooleanValue(a.(*array.Boolean).Value(i)) }
+	case arrow.INT8:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Int8).Value(i))) }
+	case arrow.INT16:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Int16).Value(i))) }
+	case arrow.INT32:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(a.(*array.Int32).Value(i)) }
+	case arrow.INT64:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int64Value(a.(*array.Int64).Value(i)) }
+	case arrow.UINT8:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Uint8).Value(i))) }
+	case arrow.UINT16:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Uint16).Value(i))) }
+	case arrow.UINT32:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Uint32).Value(i))) }
+	case arrow.UINT64:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int64Value(int64(a.(*array.Uint64).Value(i))) }
+	case arrow.FLOAT32:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.FloatValue(a.(*array.Float32).Value(i)) }
+	case arrow.FLOAT64:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.DoubleValue(a.(*array.Float64).Value(i)) }
+	case arrow.DATE32:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Date32).Value(i))) }
+	case arrow.TIME32:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int32Value(int32(a.(*array.Time32).Value(i))) }
+	case arrow.TIME64:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.Int64Value(int64(a.(*array.Time64).Value(i))) }
+	case arrow.TIMESTAMP:
+		return func(a arrow.Array, i int) parquet.Value {
+			return parquet.Int64Value(int64(a.(*array.Timestamp).Value(i)))
+		}
+	case arrow.STRING:
+		// String values are converted to byte arrays without copying them,
+		// the column buffer copies the values it retains.
+		return func(a arrow.Array, i int) parquet.Value {
+			return parquet.ByteArrayValue(stringToBytes(a.(*array.String).Value(i)))
+		}
+	case arrow.BINARY:
+		return func(a arrow.Array, i int) parquet.Value { return parquet.ByteArrayValue(a.(*array.Binary).Value(i)) }
+	case arrow.FIXED_SIZE_BINARY:
+		return func(a arrow.Array, i int) parquet.Value {
+			return parquet.FixedLenByteArrayValue(a.(*array.FixedSizeBinary).Value(i))
+		}
+	default:
+		panic("cannot write arrow arrays of type " + t.String() + " to parquet columns")
+	}
+}
+
+// stringToBytes returns a byte slice sharing the memory of s, which must not be
+// modified.
+func stringToBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
@@ -0,0 +1,136 @@
+package parquetarrow_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/parquetarrow"
+)
+
+func TestWriteRecord(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "email", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "small", Type: arrow.PrimitiveTypes.Int8},
+		{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+	}, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	const numRows = 1000
+	for i := 0; i < numRows; i++ {
+		builder.Field(0).(*array.Int64Builder).Append(int64(i))
+		if i%3 == 0 {
+			builder.Field(1).AppendNull()
+		} else {
+			builder.Field(1).(*array.Float64Builder).Append(float64(i) / 2)
+		}
+		builder.Field(2).(*array.StringBuilder).Append([]string{"one", "two", "three"}[i%3])
+		if i%2 == 0 {
+			builder.Field(3).AppendNull()
+		} else {
+			builder.Field(3).(*array.StringBuilder).Append("user@example.com")
+		}
+		builder.Field(4).(*array.Int8Builder).Append(int8(i % 100))
+		builder.Field(5).(*array.TimestampBuilder).Append(arrow.Timestamp(i * 1000))
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	output := new(bytes.Buffer)
+	if err := parquetarrow.WriteRecord(output, record, parquet.Compression(&parquet.Snappy)); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.NumRows(); n != numRows {
+		t.Fatalf("wrong number of rows: want=%d got=%d", numRows, n)
+	}
+	for _, name := range []string{"score", "email"} {
+		if leaf, _ := f.Schema().Lookup(name); !leaf.Node.Optional() {
+			t.Errorf("column %q is not optional", name)
+		}
+	}
+
+	got, err := parquetarrow.ReadFile(context.Background(), f, memory.DefaultAllocator, "id", "score", "name", "email", "small", "time")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(schema) {
+		t.Errorf("arrow schema mismatch:\nwant: %s\ngot:  %s", schema, got.Schema())
+	}
+	for i := range record.Columns() {
+		if !array.Equal(got.Column(i), record.Column(i)) {
+			t.Errorf("column %q mismatch after writing and reading the record", schema.Field(i).Name)
+		}
+	}
+
+	type Row struct {
+		ID    int64    `parquet:"id"`
+		Score *float64 `parquet:"score"`
+		Email *string  `parquet:"email"`
+	}
+	rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	score, email := 0.5, "user@example.com"
+	if want := (Row{ID: 1, Score: &score, Email: &email}); !reflect.DeepEqual(rows[1], want) {
+		t.Errorf("wrong row: want=%+v got=%+v", want, rows[1])
+	}
+	if rows[0].Score != nil || rows[0].Email != nil {
+		t.Errorf("expected null values in the first row: %+v", rows[0])
+	}
+}
+
+func TestWriteRecordUnsupportedType(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	record := builder.NewRecord()
+	defer record.Release()
+
+	if err := parquetarrow.WriteRecord(new(bytes.Buffer), record); err == nil {
+		t.Error("expected an error writing a record with a list column")
+	}
+}
+
+func TestWriteRecordOptionsNotModified(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).Append(1)
+	record := builder.NewRecord()
+	defer record.Release()
+
+	compression := parquet.Compression(&parquet.Snappy)
+	options := make([]parquet.WriterOption, 1, 2)
+	options[0] = compression
+	spare := options[:2]
+	spare[1] = compression
+
+	if err := parquetarrow.WriteRecord(new(bytes.Buffer), record, options...); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spare[1].(*parquet.Schema); ok {
+		t.Error("the schema was written to the spare capacity of the options")
+	}
+}
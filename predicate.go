@@ -0,0 +1,189 @@
+package parquet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Predicate is an interface representing conditions on the values of a column.
+//
+// Predicates are used by readers to skip pages which cannot contain values
+// matching the condition, based on the min and max values recorded in the
+// column index of parquet files (see the Where reader option).
+type Predicate interface {
+	// Returns true if a page holding values between min and max, compared
+	// according to typ, may contain values matching the predicate.
+	MatchBounds(typ Type, min, max Value) bool
+}
+
+// Gte returns a predicate matching values greater than or equal to value.
+func Gte(value Value) Predicate { return &rangePredicate{lower: value} }
+
+// Lte returns a predicate matching values less than or equal to value.
+func Lte(value Value) Predicate { return &rangePredicate{upper: value} }
+
+// Eq returns a predicate matching values equal to value.
+func Eq(value Value) Predicate { return &rangePredicate{lower: value, upper: value} }
+
+// Between returns a predicate matching values greater than or equal to lower
+// and less than or equal to upper.
+func Between(lower, upper Value) Predicate { return &rangePredicate{lower: lower, upper: upper} }
+
+// rangePredicate matches values in the range [lower, upper], null bounds leave
+// the range unbounded on their side.
+type rangePredicate struct {
+	lower Value
+	upper Value
+}
+
+func (p *rangePredicate) MatchBounds(typ Type, min, max Value) bool {
+	if !p.lower.IsNull() {
+		// The bounds cannot be compared to values of a different kind, the
+		// page must be assumed to possibly match.
+		if p.lower.Kind() != typ.Kind() {
+			return true
+		}
+		if typ.Compare(max, p.lower) < 0 {
+			return false
+		}
+	}
+	if !p.upper.IsNull() {
+		if p.upper.Kind() != typ.Kind() {
+			return true
+		}
+		if typ.Compare(min, p.upper) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ColumnPredicate associates a predicate with the path of the column that it
+// applies to.
+type ColumnPredicate struct {
+	Column    string
+	Predicate Predicate
+}
+
+// rowRange represents the range of rows [start, end).
+type rowRange struct {
+	start int64
+	end   int64
+}
+
+// matchingRowRanges returns the ranges of rows of the list of row groups which
+// may contain rows matching all the predicates. Pages of columns which cannot
+// match a predicate exclude their rows from all the columns.
+//
+// Column chunks without a page index, or pages without min and max values,
+// are never excluded.
+func matchingRowRanges(schema *Schema, rowGroups []RowGroup, predicates []ColumnPredicate) []rowRange {
+	leaves := make([]LeafColumn, len(predicates))
+	for i, p := range predicates {
+		leaf, ok := schema.Lookup(strings.Split(p.Column, ".")...)
+		if !ok {
+			panic(fmt.Errorf("cannot filter rows of parquet schema %s: column %q does not exist", schema.Name(), p.Column))
+		}
+		leaves[i] = leaf
+	}
+
+	ranges := make([]rowRange, 0, len(rowGroups))
+	offset := int64(0)
+
+	for _, rowGroup := range rowGroups {
+		numRows := rowGroup.NumRows()
+		columnChunks := rowGroup.ColumnChunks()
+		matches := []rowRange{{start: 0, end: numRows}}
+
+		for i, p := range predicates {
+			chunk := columnChunks[leaves[i].ColumnIndex]
+			matches = intersectRowRanges(matches, matchingPageRanges(chunk, leaves[i].Node.Type(), p.Predicate, numRows))
+		}
+
+		for _, r := range matches {
+			ranges = append(ranges, rowRange{start: offset + r.start, end: offset + r.end})
+		}
+		offset += numRows
+	}
+
+	return ranges
+}
+
+func matchingPageRanges(chunk ColumnChunk, typ Type, predicate Predicate, numRows int64) []rowRange {
+	columnIndex, offsetIndex := chunk.ColumnIndex(), chunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return []rowRange{{start: 0, end: numRows}}
+	}
+
+	ranges := []rowRange{}
+	numPages := offsetIndex.NumPages()
+
+	for i := 0; i < numPages; i++ {
+		start, end := offsetIndex.FirstRowIndex(i), numRows
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		if !pageMayMatch(columnIndex, i, typ, predicate) {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].end == start {
+			ranges[n-1].end = end
+		} else {
+			ranges = append(ranges, rowRange{start: start, end: end})
+		}
+	}
+
+	return ranges
+}
+
+func pageMayMatch(columnIndex ColumnIndex, i int, typ Type, predicate Predicate) bool {
+	if columnIndex.NullPage(i) {
+		// Predicates never match null values.
+		return false
+	}
+	if index, ok := columnIndex.(fileColumnIndex); ok {
+		// Files may omit the min and max values of pages, in which case the
+		// column index carries empty values.
+		c := index.chunk.columnIndex
+		if len(c.MinValues[i]) == 0 && len(c.MaxValues[i]) == 0 {
+			return true
+		}
+	}
+	min, max := columnIndex.MinValue(i), columnIndex.MaxValue(i)
+	if min.IsNull() || max.IsNull() {
+		return true
+	}
+	return predicate.MatchBounds(typ, min, max)
+}
+
+func intersectRowRanges(a, b []rowRange) []rowRange {
+	ranges := []rowRange{}
+	for len(a) > 0 && len(b) > 0 {
+		start, end := a[0].start, a[0].end
+		if start < b[0].start {
+			start = b[0].start
+		}
+		if end > b[0].end {
+			end = b[0].end
+		}
+		if start < end {
+			ranges = append(ranges, rowRange{start: start, end: end})
+		}
+		if a[0].end < b[0].end {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return ranges
+}
+
+// nextRowRange returns the first range of rows ending after rowIndex.
+func nextRowRange(ranges []rowRange, rowIndex int64) (rowRange, bool) {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].end > rowIndex })
+	if i == len(ranges) {
+		return rowRange{}, false
+	}
+	return ranges[i], true
+}
@@ -0,0 +1,209 @@
+package parquet_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type predicateRow struct {
+	Timestamp int64  `parquet:"ts"`
+	Name      string `parquet:"name"`
+}
+
+func writePredicateFile(t *testing.T, numRows int) *bytes.Reader {
+	t.Helper()
+	rows := make([]predicateRow, numRows)
+	for i := range rows {
+		rows[i] = predicateRow{Timestamp: int64(i), Name: "row"}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[predicateRow](buf,
+		parquet.PageBufferSize(256),
+		parquet.MaxRowsPerRowGroup(int64(numRows/2)),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReaderWhere(t *testing.T) {
+	const numRows = 2000
+	file := writePredicateFile(t, numRows)
+
+	tests := []struct {
+		scenario string
+		options  []parquet.ReaderOption
+		min, max int64
+	}{
+		{
+			scenario: "gte",
+			options:  []parquet.ReaderOption{parquet.Where("ts", parquet.Gte(parquet.Int64Value(1500)))},
+			min:      1500,
+			max:      numRows - 1,
+		},
+		{
+			scenario: "lte",
+			options:  []parquet.ReaderOption{parquet.Where("ts", parquet.Lte(parquet.Int64Value(100)))},
+			min:      0,
+			max:      100,
+		},
+		{
+			scenario: "eq",
+			options:  []parquet.ReaderOption{parquet.Where("ts", parquet.Eq(parquet.Int64Value(1234)))},
+			min:      1234,
+			max:      1234,
+		},
+		{
+			scenario: "between",
+			options:  []parquet.ReaderOption{parquet.Where("ts", parquet.Between(parquet.Int64Value(900), parquet.Int64Value(1100)))},
+			min:      900,
+			max:      1100,
+		},
+		{
+			scenario: "multiple predicates",
+			options: []parquet.ReaderOption{
+				parquet.Where("ts", parquet.Gte(parquet.Int64Value(500))),
+				parquet.Where("ts", parquet.Lte(parquet.Int64Value(600))),
+			},
+			min: 500,
+			max: 600,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			rows, err := parquet.Read[predicateRow](file, file.Size(), test.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) == 0 || len(rows) >= numRows/2 {
+				t.Fatalf("pages were not skipped: read %d rows out of %d", len(rows), numRows)
+			}
+
+			found := 0
+			for i, row := range rows {
+				if i > 0 && row.Timestamp <= rows[i-1].Timestamp {
+					t.Fatalf("rows out of order at index %d: %d <= %d", i, row.Timestamp, rows[i-1].Timestamp)
+				}
+				if row.Timestamp >= test.min && row.Timestamp <= test.max {
+					found++
+				}
+			}
+			if want := int(test.max-test.min) + 1; found != want {
+				t.Errorf("wrong number of matching rows: want=%d got=%d", want, found)
+			}
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		rows, err := parquet.Read[predicateRow](file, file.Size(), parquet.Where("ts", parquet.Gte(parquet.Int64Value(numRows))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 0 {
+			t.Errorf("expected no rows but got %d", len(rows))
+		}
+	})
+
+	t.Run("no page index", func(t *testing.T) {
+		f, err := parquet.OpenFile(file, file.Size(), parquet.SkipPageIndex(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader := parquet.NewGenericReader[predicateRow](f, parquet.Where("ts", parquet.Gte(parquet.Int64Value(1500))))
+		defer reader.Close()
+		rows := make([]predicateRow, numRows)
+		n, _ := reader.Read(rows)
+		if n != numRows {
+			t.Errorf("pages of columns without page index must not be skipped: want=%d got=%d", numRows, n)
+		}
+	})
+
+	t.Run("row reader", func(t *testing.T) {
+		reader := parquet.NewReader(file, parquet.Where("ts", parquet.Gte(parquet.Int64Value(1990))))
+		defer reader.Close()
+		n := 0
+		for {
+			row := predicateRow{}
+			if err := reader.Read(&row); err != nil {
+				break
+			}
+			if row.Timestamp < 1000 {
+				t.Fatalf("read row from a skipped page: %d", row.Timestamp)
+			}
+			n++
+		}
+		if n == 0 || n >= numRows/2 {
+			t.Errorf("pages were not skipped: read %d rows out of %d", n, numRows)
+		}
+	})
+}
+
+func TestFileRowGroupsMatching(t *testing.T) {
+	rows := make([]predicateRow, 1000)
+	for i := range rows {
+		rows[i] = predicateRow{Timestamp: int64(i), Name: "row"}
+	}
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[predicateRow](buf, parquet.MaxRowsPerRowGroup(250))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		scenario  string
+		column    string
+		predicate parquet.Predicate
+		want      []int64 // first row of each matching row group
+	}{
+		{"gte", "ts", parquet.Gte(parquet.Int64Value(600)), []int64{500, 750}},
+		{"lte", "ts", parquet.Lte(parquet.Int64Value(250)), []int64{0, 250}},
+		{"eq", "ts", parquet.Eq(parquet.Int64Value(499)), []int64{250}},
+		{"between", "ts", parquet.Between(parquet.Int64Value(200), parquet.Int64Value(800)), []int64{0, 250, 500, 750}},
+		{"none", "ts", parquet.Gte(parquet.Int64Value(1000)), []int64{}},
+		{"string", "name", parquet.Eq(parquet.ByteArrayValue([]byte("row"))), []int64{0, 250, 500, 750}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			rowGroups, err := f.RowGroupsMatching(test.column, test.predicate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int64{}
+			for _, rowGroup := range rowGroups {
+				rows := make([]parquet.Row, 1)
+				r := rowGroup.Rows()
+				if _, err := r.ReadRows(rows); err != nil {
+					t.Fatal(err)
+				}
+				r.Close()
+				got = append(got, rows[0][0].Int64())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("wrong row groups: want=%v got=%v", test.want, got)
+			}
+		})
+	}
+
+	t.Run("missing column", func(t *testing.T) {
+		if _, err := f.RowGroupsMatching("missing", parquet.Eq(parquet.Int64Value(0))); err == nil {
+			t.Error("expected an error filtering row groups on a column which does not exist")
+		}
+	})
+}
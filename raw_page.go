@@ -0,0 +1,167 @@
+package parquet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// RawPageHeader is the header of pages read by RawPageReader.
+//
+// The embedded PageHeader is one of DataPageHeaderV1, DataPageHeaderV2, or
+// DictionaryPageHeader, programs can use a type switch to access the methods
+// specific to each type of page.
+type RawPageHeader struct {
+	PageHeader
+	// Offset of the page in the file, which is the position of its header.
+	Offset int64
+	// Compression codec of the page data, which is the codec of the column
+	// chunk that the page belongs to. The data of pages of type
+	// DataPageHeaderV2 is only compressed if their IsCompressed method
+	// returns true.
+	Codec format.CompressionCodec
+	// Size of the page data after decompression. For pages of type
+	// DataPageHeaderV2, the repetition and definition levels at the beginning
+	// of the page data are never compressed.
+	UncompressedSize int32
+	// The CRC32 checksum of the page data, or zero if the page had none.
+	CRC int32
+}
+
+// RawPageReader reads the pages of a column chunk of a parquet file without
+// decompressing nor decoding them.
+//
+// RawPageReader values are useful to programs which need to access the content
+// of pages as stored in the file, for example to cache them; the page data can
+// later be decoded by decompressing it with the codec and using the encoding
+// reported by the page headers.
+type RawPageReader struct {
+	chunk    *fileColumnChunk
+	section  io.SectionReader
+	rbuf     *bufio.Reader
+	rbufpool *sync.Pool
+
+	protocol thrift.CompactProtocol
+	decoder  thrift.Decoder
+
+	baseOffset int64
+	err        error
+}
+
+// NewRawPageReader constructs a reader of the raw pages of chunk.
+//
+// Only column chunks of parquet files support reading raw pages; reading pages
+// of other column chunks, or of encrypted columns, returns an error.
+func NewRawPageReader(chunk ColumnChunk) *RawPageReader {
+	r := new(RawPageReader)
+	c, ok := chunk.(*fileColumnChunk)
+	switch {
+	case !ok:
+		r.err = fmt.Errorf("cannot read raw pages of column chunks of type %T", chunk)
+	case isEncryptedColumnChunk(c.chunk):
+		r.err = fmt.Errorf("%s: %w", columnPath(c.column.Path()), ErrEncryptedColumn)
+	default:
+		r.chunk = c
+		r.baseOffset = c.chunk.MetaData.DataPageOffset
+		if c.chunk.MetaData.DictionaryPageOffset != 0 {
+			r.baseOffset = c.chunk.MetaData.DictionaryPageOffset
+		}
+		r.section = *io.NewSectionReader(c.file, r.baseOffset, c.chunk.MetaData.TotalCompressedSize)
+		r.rbuf, r.rbufpool = getBufioReader(&r.section, c.file.config.ReadBufferSize)
+		r.decoder.Reset(r.protocol.NewReader(r.rbuf))
+	}
+	return r
+}
+
+// ReadRawPage reads the next page of the column chunk, returning its header and
+// the page data, which is still compressed. The returned byte slice is owned
+// by the caller.
+//
+// The method returns io.EOF after the last page of the column chunk.
+func (r *RawPageReader) ReadRawPage() (RawPageHeader, []byte, error) {
+	if r.err != nil {
+		return RawPageHeader{}, nil, r.err
+	}
+	if r.chunk == nil {
+		return RawPageHeader{}, nil, io.ErrClosedPipe
+	}
+
+	position, _ := r.section.Seek(0, io.SeekCurrent)
+	position -= int64(r.rbuf.Buffered())
+	if position >= r.section.Size() {
+		return RawPageHeader{}, nil, io.EOF
+	}
+
+	header := new(format.PageHeader)
+	if err := r.decoder.Decode(header); err != nil {
+		return RawPageHeader{}, nil, r.fail(position, err)
+	}
+
+	rawHeader := RawPageHeader{
+		Offset:           r.baseOffset + position,
+		Codec:            r.chunk.chunk.MetaData.Codec,
+		UncompressedSize: header.UncompressedPageSize,
+		CRC:              header.CRC,
+	}
+	switch header.Type {
+	case format.DataPage:
+		if header.DataPageHeader == nil {
+			return RawPageHeader{}, nil, r.fail(position, ErrMissingPageHeader)
+		}
+		rawHeader.PageHeader = DataPageHeaderV1{header.DataPageHeader}
+	case format.DataPageV2:
+		if header.DataPageHeaderV2 == nil {
+			return RawPageHeader{}, nil, r.fail(position, ErrMissingPageHeader)
+		}
+		rawHeader.PageHeader = DataPageHeaderV2{header.DataPageHeaderV2}
+	case format.DictionaryPage:
+		if header.DictionaryPageHeader == nil {
+			return RawPageHeader{}, nil, r.fail(position, ErrMissingPageHeader)
+		}
+		rawHeader.PageHeader = DictionaryPageHeader{header.DictionaryPageHeader}
+	default:
+		rawHeader.PageHeader = unknownPageHeader{header}
+	}
+
+	// The page size is validated before allocating the page data, corrupted
+	// headers could otherwise cause arbitrarily large allocations.
+	offset, _ := r.section.Seek(0, io.SeekCurrent)
+	remaining := r.section.Size() - (offset - int64(r.rbuf.Buffered()))
+	if header.CompressedPageSize < 0 || int64(header.CompressedPageSize) > remaining {
+		return RawPageHeader{}, nil, r.fail(position, fmt.Errorf("page of %d bytes exceeds the %d bytes remaining in the column chunk: %w", header.CompressedPageSize, remaining, ErrCorrupted))
+	}
+
+	data := make([]byte, header.CompressedPageSize)
+	if _, err := io.ReadFull(r.rbuf, data); err != nil {
+		return RawPageHeader{}, nil, r.fail(position, err)
+	}
+	return rawHeader, data, nil
+}
+
+func (r *RawPageReader) fail(position int64, err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	r.err = fmt.Errorf("reading raw page at offset %d of column %q: %w", r.baseOffset+position, columnPath(r.chunk.column.Path()), err)
+	return r.err
+}
+
+// Close releases the resources held by r.
+func (r *RawPageReader) Close() error {
+	if r.rbuf != nil {
+		putBufioReader(r.rbuf, r.rbufpool)
+	}
+	r.chunk = nil
+	r.section = io.SectionReader{}
+	r.rbuf = nil
+	r.rbufpool = nil
+	return nil
+}
+
+// Err returns the first error that occurred reading pages from r, not
+// including io.EOF.
+func (r *RawPageReader) Err() error { return r.err }
@@ -0,0 +1,25 @@
+//go:build go1.23
+
+package parquet
+
+import "iter"
+
+// RawPages returns an iterator over the remaining raw pages of r, yielding the
+// header and compressed data of each page.
+//
+// The iteration stops at the end of the column chunk or when an error occurs,
+// programs must call Err after the iteration to check whether all pages were
+// read. The byte slices are owned by the caller.
+func (r *RawPageReader) RawPages() iter.Seq2[RawPageHeader, []byte] {
+	return func(yield func(RawPageHeader, []byte) bool) {
+		for {
+			header, data, err := r.ReadRawPage()
+			if err != nil {
+				return
+			}
+			if !yield(header, data) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestRawPageReaderRawPages(t *testing.T) {
+	type row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows, parquet.PageBufferSize(256)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunk := f.RowGroups()[0].ColumnChunks()[0]
+	r := parquet.NewRawPageReader(chunk)
+	defer r.Close()
+
+	numPages, numValues := 0, int64(0)
+	for header, data := range r.RawPages() {
+		if len(data) == 0 {
+			t.Errorf("page %d has no data", numPages)
+		}
+		numPages++
+		numValues += header.NumValues()
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want := chunk.OffsetIndex().NumPages(); numPages != want {
+		t.Errorf("wrong number of pages: want=%d got=%d", want, numPages)
+	}
+	if numValues != int64(len(rows)) {
+		t.Errorf("wrong number of values: want=%d got=%d", len(rows), numValues)
+	}
+}
@@ -0,0 +1,153 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	"github.com/segmentio/encoding/thrift"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestRawPageReader(t *testing.T) {
+	type row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i] = row{ID: int64(i), Name: []string{"A", "B", "C"}[i%3]}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[row](buf, parquet.Compression(&parquet.Snappy), parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		metadata := &f.Metadata().RowGroups[0].Columns[i].MetaData
+		pageLocations := chunk.OffsetIndex()
+
+		r := parquet.NewRawPageReader(chunk)
+		numDictionaryPages, numDataPages, numValues := 0, 0, int64(0)
+
+		for {
+			header, data, err := r.ReadRawPage()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if header.Codec != format.Snappy {
+				t.Errorf("%s: wrong codec: %v", metadata.PathInSchema, header.Codec)
+			}
+			if header.CRC != int32(crc32.ChecksumIEEE(data)) {
+				t.Errorf("%s: page at offset %d: checksum mismatch", metadata.PathInSchema, header.Offset)
+			}
+			uncompressed := data
+			if v2, ok := header.PageHeader.(parquet.DataPageHeaderV2); !ok || v2.IsCompressed() {
+				uncompressed, err = parquet.LookupCompressionCodec(header.Codec).Decode(nil, data)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(uncompressed) != int(header.UncompressedSize) {
+				t.Errorf("%s: wrong uncompressed size: want=%d got=%d", metadata.PathInSchema, header.UncompressedSize, len(uncompressed))
+			}
+
+			switch h := header.PageHeader.(type) {
+			case parquet.DictionaryPageHeader:
+				numDictionaryPages++
+				if header.Offset != metadata.DictionaryPageOffset {
+					t.Errorf("%s: wrong dictionary page offset: want=%d got=%d", metadata.PathInSchema, metadata.DictionaryPageOffset, header.Offset)
+				}
+			case parquet.DataPageHeader:
+				if want := pageLocations.Offset(numDataPages); header.Offset != want {
+					t.Errorf("%s: wrong offset of data page %d: want=%d got=%d", metadata.PathInSchema, numDataPages, want, header.Offset)
+				}
+				numDataPages++
+				numValues += h.NumValues()
+			default:
+				t.Errorf("%s: unexpected page header: %T", metadata.PathInSchema, h)
+			}
+		}
+
+		if err := r.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if wantDictionaryPages := i; numDictionaryPages != wantDictionaryPages {
+			t.Errorf("%s: wrong number of dictionary pages: want=%d got=%d", metadata.PathInSchema, wantDictionaryPages, numDictionaryPages)
+		}
+		if numDataPages != pageLocations.NumPages() || numDataPages < 2 {
+			t.Errorf("%s: wrong number of data pages: want=%d got=%d", metadata.PathInSchema, pageLocations.NumPages(), numDataPages)
+		}
+		if numValues != int64(len(rows)) {
+			t.Errorf("%s: wrong number of values: want=%d got=%d", metadata.PathInSchema, len(rows), numValues)
+		}
+	}
+}
+
+func TestRawPageReaderUnsupportedColumnChunk(t *testing.T) {
+	buffer := parquet.NewBuffer(parquet.SchemaOf(struct{ ID int64 }{}))
+	r := parquet.NewRawPageReader(buffer.ColumnChunks()[0])
+	if _, _, err := r.ReadRawPage(); err == nil || err == io.EOF {
+		t.Errorf("expected an error reading raw pages of a buffer, got %v", err)
+	}
+}
+
+func TestRawPageReaderInvalidPageSize(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []struct{ ID int64 }{{1}, {2}, {3}}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offset := f.Metadata().RowGroups[0].Columns[0].MetaData.DataPageOffset
+
+	for _, size := range []int32{-1, 1 << 30} {
+		protocol := new(thrift.CompactProtocol)
+		r := bytes.NewReader(b[offset:])
+		header := new(format.PageHeader)
+		if err := thrift.NewDecoder(protocol.NewReader(r)).Decode(header); err != nil {
+			t.Fatal(err)
+		}
+		headerSize := int64(len(b)) - offset - int64(r.Len())
+
+		header.CompressedPageSize = size
+		corrupted, err := thrift.Marshal(protocol, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := append([]byte{}, b[:offset]...)
+		c = append(c, corrupted...)
+		c = append(c, b[offset+headerSize:]...)
+
+		f, err := parquet.OpenFile(bytes.NewReader(c), int64(len(c)), parquet.SkipPageIndex(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = parquet.NewRawPageReader(f.RowGroups()[0].ColumnChunks()[0]).ReadRawPage()
+		if !errors.Is(err, parquet.ErrCorrupted) {
+			t.Errorf("page of %d bytes: expected an error wrapping ErrCorrupted, got %v", size, err)
+		}
+	}
+}
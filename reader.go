@@ -1,10 +1,12 @@
 package parquet
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 )
 
 // GenericReader is similar to a Reader but uses a type parameter to define the
@@ -12,8 +14,9 @@ import (
 //
 // See GenericWriter for details about the benefits over the classic Reader API.
 type GenericReader[T any] struct {
-	base Reader
-	read readFunc[T]
+	base    Reader
+	read    readFunc[T]
+	factory func() T
 }
 
 // NewGenericReader is like NewReader but returns GenericReader[T] suited to write
@@ -47,6 +50,9 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 			c.Schema = schemaOf(dereference(t))
 		}
 	}
+	if len(c.Columns) > 0 {
+		c.Schema = projectSchema(c.Schema, c.Columns)
+	}
 
 	r := &GenericReader[T]{
 		base: Reader{
@@ -54,15 +60,24 @@ func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *Generi
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			strictTypes:            c.StrictTypes,
+			caseInsensitiveColumns: c.CaseInsensitiveColumns,
+			allowMissingColumns:    !c.DisallowMissingColumns,
+			orderedMaps:            c.OrderedMaps,
 		},
 	}
 
 	if !nodesAreEqual(c.Schema, f.schema) {
-		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
+		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c)
+	}
+
+	if len(c.Predicates) > 0 {
+		r.base.setRowRanges(matchingRowRanges(f.schema, f.RowGroups(), c.Predicates))
 	}
 
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
 	r.read = readFuncOf[T](t, r.base.file.schema)
+	r.factory = rowFactoryOf[T](c)
 	return r
 }
 
@@ -80,6 +95,9 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 			c.Schema = schemaOf(dereference(t))
 		}
 	}
+	if len(c.Columns) > 0 {
+		c.Schema = projectSchema(c.Schema, c.Columns)
+	}
 
 	r := &GenericReader[T]{
 		base: Reader{
@@ -87,18 +105,38 @@ func NewGenericRowGroupReader[T any](rowGroup RowGroup, options ...ReaderOption)
 				schema:   c.Schema,
 				rowGroup: rowGroup,
 			},
+			strictTypes:            c.StrictTypes,
+			caseInsensitiveColumns: c.CaseInsensitiveColumns,
+			allowMissingColumns:    !c.DisallowMissingColumns,
+			orderedMaps:            c.OrderedMaps,
 		},
 	}
 
 	if !nodesAreEqual(c.Schema, rowGroup.Schema()) {
-		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c.Schema)
+		r.base.file.rowGroup = convertRowGroupTo(r.base.file.rowGroup, c)
+	}
+
+	if len(c.Predicates) > 0 {
+		r.base.setRowRanges(matchingRowRanges(rowGroup.Schema(), []RowGroup{rowGroup}, c.Predicates))
 	}
 
 	r.base.read.init(r.base.file.schema, r.base.file.rowGroup)
 	r.read = readFuncOf[T](t, r.base.file.schema)
+	r.factory = rowFactoryOf[T](c)
 	return r
 }
 
+func rowFactoryOf[T any](c *ReaderConfig) func() T {
+	if c.RowFactory == nil {
+		return nil
+	}
+	factory, ok := c.RowFactory.(func() T)
+	if !ok {
+		panic(fmt.Errorf("cannot create reader for values of type %s with a row factory of type %T", reflect.TypeOf((*T)(nil)).Elem(), c.RowFactory))
+	}
+	return factory
+}
+
 func (r *GenericReader[T]) Reset() {
 	r.base.Reset()
 }
@@ -106,7 +144,9 @@ func (r *GenericReader[T]) Reset() {
 // Read reads the next rows from the reader into the given rows slice up to len(rows).
 //
 // The returned values are safe to reuse across Read calls and do not share
-// memory with the reader's underlying page buffers.
+// memory with the reader's underlying page buffers. When the reader was
+// configured with the RowFactory option, each row is decoded into a value
+// returned by the factory, which replaces the value held in rows.
 //
 // The method returns the number of rows read and io.EOF when no more rows
 // can be read from the reader.
@@ -114,10 +154,53 @@ func (r *GenericReader[T]) Read(rows []T) (int, error) {
 	return r.read(r, rows)
 }
 
+// ReadContext is like Read but checks for the cancellation of ctx before
+// reading each page of rows. When ctx is canceled or its deadline expires,
+// the method returns the number of rows read so far and ctx.Err().
+//
+// Programs reading from slow storage, for example object stores exposed
+// through an io.ReaderAt, may use this method to abort long reads.
+func (r *GenericReader[T]) ReadContext(ctx context.Context, rows []T) (int, error) {
+	return r.readRowsContext(ctx, rows)
+}
+
+// ReadInto reads the next row into the value pointed by row, returning false
+// when no more rows can be read.
+//
+// Unlike Read, the memory of slices and maps held by the value is reused when
+// possible instead of allocating new ones, which allows programs to decode
+// rows without allocating memory when reading them into the same value. The
+// content of those slices and maps is overwritten by each call, programs must
+// make copies of values that they retain.
+func (r *GenericReader[T]) ReadInto(row *T) (bool, error) {
+	if cap(r.base.rowbuf) == 0 {
+		r.base.rowbuf = make([]Row, 1)
+	}
+	rows := r.base.rowbuf[:1]
+
+	n, err := r.base.ReadRows(rows)
+	if n == 0 {
+		if err == io.EOF {
+			err = nil
+		}
+		return false, err
+	}
+	if err := r.base.reconstruct(r.base.Schema(), row, rows[0], levels{reuse: true}); err != nil {
+		return false, err
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return true, err
+}
+
 func (r *GenericReader[T]) ReadRows(rows []Row) (int, error) {
 	return r.base.ReadRows(rows)
 }
 
+// Schema returns the schema of rows read by r, which is the schema of the file
+// when reading values of type any, or the schema of T otherwise, retaining only
+// the columns selected by the Columns option if it was set.
 func (r *GenericReader[T]) Schema() *Schema {
 	return r.base.Schema()
 }
@@ -126,6 +209,11 @@ func (r *GenericReader[T]) NumRows() int64 {
 	return r.base.NumRows()
 }
 
+// SeekToRow positions r on the row at rowIndex.
+//
+// When reading parquet files which have a page index, the offset index of each
+// column chunk is used to position the reader directly on the page containing
+// the row; the pages which precede it are neither read nor decompressed.
 func (r *GenericReader[T]) SeekToRow(rowIndex int64) error {
 	return r.base.SeekToRow(rowIndex)
 }
@@ -142,6 +230,10 @@ func (r *GenericReader[T]) Close() error {
 // The method returns the number of rows read and io.EOF when no more rows
 // can be read from the reader.
 func (r *GenericReader[T]) readRows(rows []T) (int, error) {
+	return r.readRowsContext(context.Background(), rows)
+}
+
+func (r *GenericReader[T]) readRowsContext(ctx context.Context, rows []T) (int, error) {
 	nRequest := len(rows)
 	if cap(r.base.rowbuf) < nRequest {
 		r.base.rowbuf = make([]Row, nRequest)
@@ -157,12 +249,20 @@ func (r *GenericReader[T]) readRows(rows []T) (int, error) {
 		// In that case, ReadRows will read the number of rows equal to the length of the
 		// given slice argument. We limit that length to never be more than requested
 		// because sequential reads can cross page boundaries.
+		if err = ctx.Err(); err != nil {
+			break
+		}
 		n, err = r.base.ReadRows(r.base.rowbuf[:nRequest-nTotal])
 		if n > 0 {
 			schema := r.base.Schema()
 
 			for i, row := range r.base.rowbuf[:n] {
-				if err2 := schema.Reconstruct(&rows[nTotal+i], row); err2 != nil {
+				levels := levels{}
+				if r.factory != nil {
+					rows[nTotal+i] = r.factory()
+					levels.reuse = true
+				}
+				if err2 := r.base.reconstruct(schema, &rows[nTotal+i], row, levels); err2 != nil {
 					return nTotal + i, err2
 				}
 			}
@@ -237,6 +337,12 @@ type Reader struct {
 	read     reader
 	rowIndex int64
 	rowbuf   []Row
+	columns  []string
+
+	strictTypes            bool
+	caseInsensitiveColumns bool
+	allowMissingColumns    bool
+	orderedMaps            bool
 }
 
 // NewReader constructs a parquet reader reading rows from the given
@@ -277,11 +383,24 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 			schema:   f.schema,
 			rowGroup: fileRowGroupOf(f),
 		},
+		columns:                c.Columns,
+		strictTypes:            c.StrictTypes,
+		caseInsensitiveColumns: c.CaseInsensitiveColumns,
+		allowMissingColumns:    !c.DisallowMissingColumns,
+		orderedMaps:            c.OrderedMaps,
+	}
+
+	if len(c.Columns) > 0 {
+		c.Schema = projectSchema(coalesceSchema(c.Schema, f.schema), c.Columns)
 	}
 
 	if c.Schema != nil {
 		r.file.schema = c.Schema
-		r.file.rowGroup = convertRowGroupTo(r.file.rowGroup, c.Schema)
+		r.file.rowGroup = convertRowGroupTo(r.file.rowGroup, c)
+	}
+
+	if len(c.Predicates) > 0 {
+		r.setRowRanges(matchingRowRanges(f.schema, f.RowGroups(), c.Predicates))
 	}
 
 	r.read.init(r.file.schema, r.file.rowGroup)
@@ -321,8 +440,17 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 		panic(err)
 	}
 
+	if len(c.Columns) > 0 {
+		c.Schema = projectSchema(coalesceSchema(c.Schema, rowGroup.Schema()), c.Columns)
+	}
+
+	var ranges []rowRange
+	if len(c.Predicates) > 0 {
+		ranges = matchingRowRanges(rowGroup.Schema(), []RowGroup{rowGroup}, c.Predicates)
+	}
+
 	if c.Schema != nil {
-		rowGroup = convertRowGroupTo(rowGroup, c.Schema)
+		rowGroup = convertRowGroupTo(rowGroup, c)
 	}
 
 	r := &Reader{
@@ -330,15 +458,21 @@ func NewRowGroupReader(rowGroup RowGroup, options ...ReaderOption) *Reader {
 			schema:   rowGroup.Schema(),
 			rowGroup: rowGroup,
 		},
+		columns:                c.Columns,
+		strictTypes:            c.StrictTypes,
+		caseInsensitiveColumns: c.CaseInsensitiveColumns,
+		allowMissingColumns:    !c.DisallowMissingColumns,
+		orderedMaps:            c.OrderedMaps,
 	}
+	r.setRowRanges(ranges)
 
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
 
-func convertRowGroupTo(rowGroup RowGroup, schema *Schema) RowGroup {
-	if rowGroupSchema := rowGroup.Schema(); !nodesAreEqual(schema, rowGroupSchema) {
-		conv, err := Convert(schema, rowGroupSchema)
+func convertRowGroupTo(rowGroup RowGroup, c *ReaderConfig) RowGroup {
+	if rowGroupSchema := rowGroup.Schema(); !nodesAreEqual(c.Schema, rowGroupSchema) {
+		conv, err := readerConversion(c.Schema, rowGroupSchema, c.StrictTypes, c.CaseInsensitiveColumns, !c.DisallowMissingColumns)
 		if err != nil {
 			// TODO: this looks like something we should not be panicking on,
 			// but the current NewReader API does not offer a mechanism to
@@ -350,6 +484,176 @@ func convertRowGroupTo(rowGroup RowGroup, schema *Schema) RowGroup {
 	return rowGroup
 }
 
+// readerConversion returns the conversion of rows from the source schema to
+// the target schema of a reader, see the StrictTypes, CaseInsensitiveColumns,
+// and AllowMissingColumns options.
+func readerConversion(to *Schema, from Node, strictTypes, caseInsensitiveColumns, allowMissingColumns bool) (Conversion, error) {
+	if caseInsensitiveColumns {
+		fields, err := matchFieldNamesFold(to.Fields(), from.Fields(), nil)
+		if err != nil {
+			return nil, err
+		}
+		from = &projectedNode{Node: from, fields: fields}
+	}
+	conv, err := convert(to, from, readerConversionMode(strictTypes))
+	if err != nil {
+		return nil, err
+	}
+	if !allowMissingColumns {
+		var missing columnPath
+		forEachLeafColumnOf(to, func(leaf leafColumn) {
+			if missing == nil && !hasLeafColumn(from, leaf.path) {
+				missing = append(columnPath{}, leaf.path...)
+			}
+		})
+		if missing != nil {
+			return nil, fmt.Errorf("%w: column %s is missing from the source schema", ErrSchemaMismatch, missing)
+		}
+	}
+	return conv, nil
+}
+
+// hasLeafColumn returns true if node has a leaf column at the given path.
+func hasLeafColumn(node Node, path columnPath) bool {
+	for _, name := range path {
+		if node.Leaf() {
+			return false
+		}
+		if node = fieldByName(node, name); node == nil {
+			return false
+		}
+	}
+	return node.Leaf()
+}
+
+// matchFieldNamesFold returns the source fields, renamed after the target
+// fields which have the same names under case folding. Source fields matching
+// a target field exactly retain their names.
+//
+// The order of the source fields is preserved, so the renamed fields have the
+// same column indexes as the original ones.
+func matchFieldNamesFold(target, source []Field, path columnPath) ([]Field, error) {
+	exact := make(map[string]bool, len(source))
+	for _, field := range source {
+		exact[field.Name()] = true
+	}
+
+	matched := make([]Field, len(source))
+	claimed := make(map[string]string, len(source))
+
+	for i, field := range source {
+		name := field.Name()
+		targetField := fieldNamed(target, name)
+
+		if targetField == nil {
+			var candidates []Field
+			for _, f := range target {
+				if !exact[f.Name()] && strings.EqualFold(f.Name(), name) {
+					candidates = append(candidates, f)
+				}
+			}
+			switch len(candidates) {
+			case 0:
+			case 1:
+				targetField = candidates[0]
+				if other, ok := claimed[targetField.Name()]; ok {
+					return nil, fmt.Errorf("%w: columns %s and %s both match %s ignoring case",
+						ErrSchemaMismatch, path.append(other), path.append(name), path.append(targetField.Name()))
+				}
+				claimed[targetField.Name()] = name
+				name = targetField.Name()
+			default:
+				return nil, fmt.Errorf("%w: column %s matches both %s and %s ignoring case",
+					ErrSchemaMismatch, path.append(name), path.append(candidates[0].Name()), path.append(candidates[1].Name()))
+			}
+		}
+
+		fields := field.Fields()
+		if targetField != nil && !field.Leaf() && !targetField.Leaf() {
+			var err error
+			if fields, err = matchFieldNamesFold(targetField.Fields(), fields, path.append(name)); err != nil {
+				return nil, err
+			}
+		}
+		matched[i] = &renamedField{Field: field, name: name, fields: fields}
+	}
+
+	return matched, nil
+}
+
+func fieldNamed(fields []Field, name string) Field {
+	for _, f := range fields {
+		if f.Name() == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type renamedField struct {
+	Field
+	name   string
+	fields []Field
+}
+
+func (f *renamedField) Name() string { return f.name }
+
+func (f *renamedField) Fields() []Field { return f.fields }
+
+// projectSchema returns a schema retaining only the columns of schema which
+// were selected by the list of column paths.
+//
+// The fields of the projected schema are the same as in the original schema,
+// which allows rows to be reconstructed into the same Go types.
+func projectSchema(schema *Schema, columns []string) *Schema {
+	selected := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		if !hasColumnPath(schema, strings.Split(column, ".")) {
+			panic(fmt.Errorf("cannot project parquet schema %s: column %q does not exist", schema.Name(), column))
+		}
+		selected[column] = struct{}{}
+	}
+	return newSchema(schema.Name(), &projectedNode{
+		Node:   schema,
+		fields: projectFields(schema.Fields(), nil, selected),
+	})
+}
+
+func projectFields(fields []Field, path columnPath, selected map[string]struct{}) []Field {
+	projected := make([]Field, 0, len(fields))
+	for _, field := range fields {
+		fieldPath := path.append(field.Name())
+		if _, ok := selected[fieldPath.String()]; ok {
+			projected = append(projected, field)
+		} else if !field.Leaf() {
+			switch subfields := projectFields(field.Fields(), fieldPath, selected); {
+			case len(subfields) == 0:
+			case isMap(field):
+				// Maps cannot be reconstructed from their keys or values
+				// alone, selecting one of their columns selects the map.
+				projected = append(projected, field)
+			default:
+				projected = append(projected, &projectedField{Field: field, fields: subfields})
+			}
+		}
+	}
+	return projected
+}
+
+type projectedNode struct {
+	Node
+	fields []Field
+}
+
+func (n *projectedNode) Fields() []Field { return n.fields }
+
+type projectedField struct {
+	Field
+	fields []Field
+}
+
+func (f *projectedField) Fields() []Field { return f.fields }
+
 func sizeOf(r io.ReaderAt) (int64, error) {
 	switch f := r.(type) {
 	case interface{ Size() int64 }:
@@ -409,17 +713,41 @@ func (r *Reader) Read(row interface{}) error {
 		return err
 	}
 
-	r.rowIndex++
-	return r.read.schema.Reconstruct(row, r.rowbuf[0])
+	r.rowIndex = r.read.rowIndex
+	return r.reconstruct(r.read.schema, row, r.rowbuf[0], levels{})
+}
+
+// reconstruct reconstructs row into value, converting the maps of values of
+// type any to OrderedMap values when the OrderedMaps option is enabled.
+func (r *Reader) reconstruct(schema *Schema, value interface{}, row Row, levels levels) error {
+	if err := schema.reconstructValue(value, row, levels); err != nil {
+		return err
+	}
+	if r.orderedMaps {
+		if v, ok := value.(*interface{}); ok {
+			*v = orderedMapOf(schema, *v)
+		}
+	}
+	return nil
+}
+
+// setRowRanges restricts the rows read by r to the given ranges, a nil slice
+// leaves all the rows readable.
+func (r *Reader) setRowRanges(ranges []rowRange) {
+	r.file.ranges = ranges
+	r.read.ranges = ranges
 }
 
 func (r *Reader) updateReadSchema(rowType reflect.Type) error {
 	schema := schemaOf(rowType)
+	if len(r.columns) > 0 {
+		schema = projectSchema(schema, r.columns)
+	}
 
 	if nodesAreEqual(schema, r.file.schema) {
 		r.read.init(schema, r.file.rowGroup)
 	} else {
-		conv, err := Convert(schema, r.file.schema)
+		conv, err := readerConversion(schema, r.file.schema, r.strictTypes, r.caseInsensitiveColumns, r.allowMissingColumns)
 		if err != nil {
 			return err
 		}
@@ -441,7 +769,7 @@ func (r *Reader) ReadRows(rows []Row) (int, error) {
 		return 0, err
 	}
 	n, err := r.file.ReadRows(rows)
-	r.rowIndex += int64(n)
+	r.rowIndex = r.file.rowIndex
 	return n, err
 }
 
@@ -481,6 +809,9 @@ type reader struct {
 	rowGroup RowGroup
 	rows     Rows
 	rowIndex int64
+	// When non-nil, only the rows within these ranges are read, the others
+	// are skipped by seeking to the start of the next range.
+	ranges []rowRange
 }
 
 func (r *reader) init(schema *Schema, rowGroup RowGroup) {
@@ -515,6 +846,20 @@ func (r *reader) ReadRows(rows []Row) (int, error) {
 	if r.rowGroup == nil {
 		return 0, io.EOF
 	}
+	if r.ranges != nil {
+		next, ok := nextRowRange(r.ranges, r.rowIndex)
+		if !ok {
+			return 0, io.EOF
+		}
+		if next.start > r.rowIndex {
+			if err := r.SeekToRow(next.start); err != nil {
+				return 0, err
+			}
+		}
+		if limit := next.end - r.rowIndex; int64(len(rows)) > limit {
+			rows = rows[:limit]
+		}
+	}
 	if r.rows == nil {
 		r.rows = r.rowGroup.Rows()
 		if r.rowIndex > 0 {
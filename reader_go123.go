@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package parquet
+
+import (
+	"io"
+	"iter"
+)
+
+// Rows returns an iterator over the rows of r, starting at the current
+// position of the reader (e.g. after a call to SeekToRow).
+//
+// Rows are read one at a time, so breaking out of the iteration leaves the
+// reader positioned right after the last row that was yielded. The iteration
+// stops after yielding a non-nil error; io.EOF is not reported as an error.
+func (r *GenericReader[T]) Rows() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		rows := make([]T, 1)
+
+		for {
+			rows[0] = zero
+			n, err := r.Read(rows)
+			if n == 1 {
+				if !yield(rows[0], nil) {
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					yield(zero, err)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestGenericReaderRows(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Tags []string `parquet:"tags"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Tags: []string{"a", "b", "c"}[:i%3]}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows, parquet.PageBufferSize(128)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		reader := parquet.NewGenericReader[Row](bytes.NewReader(buffer.Bytes()))
+		defer reader.Close()
+
+		var read []Row
+		for row, err := range reader.Rows() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			read = append(read, row)
+		}
+		assertRowsEqual(t, rows, read)
+	})
+
+	t.Run("seek", func(t *testing.T) {
+		reader := parquet.NewGenericReader[Row](bytes.NewReader(buffer.Bytes()))
+		defer reader.Close()
+
+		if err := reader.SeekToRow(42); err != nil {
+			t.Fatal(err)
+		}
+
+		var read []Row
+		for row, err := range reader.Rows() {
+			if err != nil {
+				t.Fatal(err)
+			}
+			read = append(read, row)
+			if len(read) == 10 {
+				break
+			}
+		}
+		assertRowsEqual(t, rows[42:52], read)
+
+		// Breaking out of the loop must leave the reader right after the last
+		// row that was yielded.
+		next := make([]Row, 1)
+		if _, err := reader.Read(next); err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, rows[52:53], next)
+	})
+}
@@ -2,6 +2,7 @@ package parquet_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -610,6 +611,186 @@ func TestReaderReadSubset(t *testing.T) {
 	}
 }
 
+func TestReaderColumns(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"zip"`
+	}
+	type Person struct {
+		ID      int64   `parquet:"id"`
+		Name    string  `parquet:"name"`
+		Age     int32   `parquet:"age"`
+		Address Address `parquet:"address"`
+	}
+
+	people := []Person{
+		{ID: 1, Name: "Luke", Age: 19, Address: Address{City: "Anchorhead", Zip: "00001"}},
+		{ID: 2, Name: "Leia", Age: 19, Address: Address{City: "Aldera", Zip: "00002"}},
+		{ID: 3, Name: "Han", Age: 32, Address: Address{City: "Corellia", Zip: "00003"}},
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, people); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+
+	// Corrupt the pages of the columns which are not selected, reading them
+	// would cause the decoding to fail.
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = append([]byte{}, b...)
+	for _, chunk := range f.Metadata().RowGroups[0].Columns {
+		switch chunk.MetaData.PathInSchema[len(chunk.MetaData.PathInSchema)-1] {
+		case "name", "age", "zip":
+			offset := chunk.MetaData.DataPageOffset
+			size := chunk.MetaData.TotalCompressedSize
+			for i := offset; i < offset+size; i++ {
+				b[i] = 0xFF
+			}
+		}
+	}
+	columns := parquet.Columns("id", "address.city")
+	if _, err := parquet.Read[any](bytes.NewReader(b), int64(len(b))); err == nil {
+		t.Fatal("reading the corrupted columns was expected to fail")
+	}
+
+	t.Run("any", func(t *testing.T) {
+		rows, err := parquet.Read[any](bytes.NewReader(b), int64(len(b)), columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []any{
+			map[string]any{"id": int64(1), "address": map[string]any{"city": "Anchorhead"}},
+			map[string]any{"id": int64(2), "address": map[string]any{"city": "Aldera"}},
+			map[string]any{"id": int64(3), "address": map[string]any{"city": "Corellia"}},
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		rows, err := parquet.Read[Person](bytes.NewReader(b), int64(len(b)), columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, row := range rows {
+			want := Person{ID: people[i].ID, Address: Address{City: people[i].Address.City}}
+			if row != want {
+				t.Errorf("row %d mismatch: want=%+v got=%+v", i, want, row)
+			}
+		}
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		reader := parquet.NewReader(bytes.NewReader(b), columns)
+		defer reader.Close()
+		for i := range people {
+			row := Person{}
+			if err := reader.Read(&row); err != nil {
+				t.Fatal(err)
+			}
+			want := Person{ID: people[i].ID, Address: Address{City: people[i].Address.City}}
+			if row != want {
+				t.Errorf("row %d mismatch: want=%+v got=%+v", i, want, row)
+			}
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic when selecting a column which does not exist")
+			}
+		}()
+		parquet.NewGenericReader[any](bytes.NewReader(b), parquet.Columns("nope"))
+	})
+}
+
+func TestReaderColumnsNested(t *testing.T) {
+	type Geo struct {
+		Lat float64 `parquet:"lat"`
+		Lon float64 `parquet:"lon"`
+	}
+	type Address struct {
+		Street string `parquet:"street"`
+		City   string `parquet:"city"`
+		Geo    Geo    `parquet:"geo"`
+	}
+	type Contact struct {
+		Name  string `parquet:"name"`
+		Phone string `parquet:"phone"`
+	}
+	type Row struct {
+		ID       int64             `parquet:"id"`
+		Address  Address           `parquet:"address"`
+		Home     *Address          `parquet:"home,optional"`
+		Contacts []Contact         `parquet:"contacts"`
+		Labels   map[string]string `parquet:"labels"`
+	}
+
+	rows := []Row{
+		{
+			ID:       1,
+			Address:  Address{Street: "Main", City: "Metropolis", Geo: Geo{Lat: 1, Lon: 2}},
+			Home:     &Address{Street: "Elm", City: "Smallville", Geo: Geo{Lat: 3, Lon: 4}},
+			Contacts: []Contact{{Name: "Lois", Phone: "1"}, {Name: "Jimmy", Phone: "2"}},
+			Labels:   map[string]string{"team": "planet"},
+		},
+		{ID: 2, Address: Address{City: "Gotham"}},
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		column string
+		want   func(Row) Row
+	}{
+		{"address.geo.lat", func(r Row) Row {
+			return Row{Address: Address{Geo: Geo{Lat: r.Address.Geo.Lat}}}
+		}},
+		{"home.city", func(r Row) Row {
+			if r.Home == nil {
+				return Row{}
+			}
+			return Row{Home: &Address{City: r.Home.City}}
+		}},
+		{"contacts.name", func(r Row) Row {
+			contacts := []Contact{}
+			for _, c := range r.Contacts {
+				contacts = append(contacts, Contact{Name: c.Name})
+			}
+			return Row{Contacts: contacts}
+		}},
+		{"labels.key_value.key", func(r Row) Row {
+			labels := map[string]string{}
+			for k, v := range r.Labels {
+				labels[k] = v
+			}
+			return Row{Labels: labels}
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.Columns(test.column))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, row := range rows {
+				if want := test.want(row); !reflect.DeepEqual(got[i], want) {
+					t.Errorf("row %d mismatch:\nwant: %+v\ngot:  %+v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
+
 func TestReaderSeekToRow(t *testing.T) {
 	type rowType struct {
 		Name utf8string `parquet:",dict"`
@@ -786,3 +967,516 @@ func TestSeekToRowDictReadMultiplePages(t *testing.T) {
 		t.Fatalf("read != write")
 	}
 }
+
+func TestGenericReaderReadInto(t *testing.T) {
+	type Row struct {
+		ID     int64           `parquet:"id"`
+		Values []int64         `parquet:"values"`
+		Data   []byte          `parquet:"data"`
+		Attrs  map[int32]int64 `parquet:"attrs"`
+		Name   *string         `parquet:"name,optional"`
+	}
+
+	name := "name"
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Values: make([]int64, 10-i%10),
+			Data:   bytes.Repeat([]byte{byte(i)}, 20-i%20),
+			Attrs:  map[int32]int64{int32(i % 3): int64(i)},
+		}
+		for j := range rows[i].Values {
+			rows[i].Values[j] = int64(i * j)
+		}
+		if i%2 == 0 {
+			rows[i].Name = &name
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	r := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+	defer r.Close()
+
+	row := Row{}
+	for i := 0; ; i++ {
+		ok, err := r.ReadInto(&row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			if i != len(rows) {
+				t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), i)
+			}
+			break
+		}
+		if !reflect.DeepEqual(row, rows[i]) {
+			t.Fatalf("row %d mismatch:\nwant: %+v\ngot:  %+v", i, rows[i], row)
+		}
+	}
+
+	// The last row read has shorter slices than the first row, reading the
+	// first row again must reuse their backing arrays.
+	values, data := row.Values[:1], row.Data[:1]
+	r.Reset()
+	if ok, err := r.ReadInto(&row); !ok || err != nil {
+		t.Fatalf("reading the first row after reset: ok=%t err=%v", ok, err)
+	}
+	if &row.Values[0] != &values[0] || &row.Data[0] != &data[0] {
+		t.Error("the memory of slices was not reused")
+	}
+	if !reflect.DeepEqual(row, rows[0]) {
+		t.Errorf("row mismatch after reset:\nwant: %+v\ngot:  %+v", rows[0], row)
+	}
+}
+
+type cancelReaderAt struct {
+	io.ReaderAt
+	cancel context.CancelFunc
+}
+
+func (r *cancelReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	return r.ReaderAt.ReadAt(b, off)
+}
+
+func TestGenericReaderReadContext(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("read all rows", func(t *testing.T) {
+		r := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+		defer r.Close()
+
+		got := make([]Row, len(rows)+1)
+		n, err := r.ReadContext(context.Background(), got)
+		if err != io.EOF {
+			t.Fatalf("expected io.EOF, got %v", err)
+		}
+		if !reflect.DeepEqual(got[:n], rows) {
+			t.Fatalf("rows mismatch: read %d rows", n)
+		}
+	})
+
+	t.Run("canceled before reading", func(t *testing.T) {
+		r := parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()))
+		defer r.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		n, err := r.ReadContext(ctx, make([]Row, len(rows)))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if n != 0 {
+			t.Fatalf("wrong number of rows read: want=0 got=%d", n)
+		}
+	})
+
+	t.Run("canceled between pages", func(t *testing.T) {
+		reader := &cancelReaderAt{ReaderAt: bytes.NewReader(buf.Bytes())}
+		f, err := parquet.OpenFile(reader, int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := parquet.NewGenericReader[Row](f)
+		defer r.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		reader.cancel = cancel
+
+		got := make([]Row, len(rows))
+		n, err := r.ReadContext(ctx, got)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if n == 0 || n == len(rows) {
+			t.Fatalf("expected the read to stop after the first page, read %d rows", n)
+		}
+		if !reflect.DeepEqual(got[:n], rows[:n]) {
+			t.Fatal("rows read before the cancellation mismatch")
+		}
+	})
+}
+
+func TestCaseInsensitiveColumns(t *testing.T) {
+	type Address struct {
+		City string `parquet:"City"`
+	}
+	type Input struct {
+		UserID  int64   `parquet:"UserID"`
+		Name    string  `parquet:"NAME"`
+		Address Address `parquet:"Address"`
+	}
+	type Output struct {
+		UserID  int64 `parquet:"userid"`
+		Name    string
+		Address struct {
+			City string `parquet:"city"`
+		} `parquet:"address"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []Input{
+		{UserID: 1, Name: "Luke", Address: Address{City: "Tatooine"}},
+		{UserID: 2, Name: "Leia", Address: Address{City: "Alderaan"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]Output, 2)
+	want[0].UserID, want[0].Name, want[0].Address.City = 1, "Luke", "Tatooine"
+	want[1].UserID, want[1].Name, want[1].Address.City = 2, "Leia", "Alderaan"
+
+	t.Run("disabled", func(t *testing.T) {
+		rows, err := parquet.Read[Output](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(rows, make([]Output, 2)) {
+			t.Errorf("columns were matched ignoring case: %+v", rows)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		r := parquet.NewGenericReader[Output](bytes.NewReader(buf.Bytes()), parquet.CaseInsensitiveColumns(true))
+		defer r.Close()
+		rows := make([]Output, 2)
+		if n, err := r.Read(rows); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+		}
+	})
+
+	t.Run("exact match", func(t *testing.T) {
+		type Exact struct {
+			Name  string `parquet:"Name"`
+			Upper string `parquet:"NAME"`
+		}
+		type Target struct {
+			Name string `parquet:"NAME"`
+		}
+		b := new(bytes.Buffer)
+		if err := parquet.Write(b, []Exact{{Name: "a", Upper: "b"}}); err != nil {
+			t.Fatal(err)
+		}
+		r := parquet.NewGenericReader[Target](bytes.NewReader(b.Bytes()), parquet.CaseInsensitiveColumns(true))
+		defer r.Close()
+		rows := make([]Target, 1)
+		if n, err := r.Read(rows); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		if rows[0].Name != "b" {
+			t.Errorf("the exact column name was not preferred: %q", rows[0].Name)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		type Ambiguous struct {
+			Name  string `parquet:"Name"`
+			Upper string `parquet:"NAME"`
+		}
+		type Target struct {
+			Name string `parquet:"name"`
+		}
+		b := new(bytes.Buffer)
+		if err := parquet.Write(b, []Ambiguous{{Name: "a", Upper: "b"}}); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Errorf("expected a schema mismatch error, got %v", err)
+			}
+		}()
+		parquet.NewGenericReader[Target](bytes.NewReader(b.Bytes()), parquet.CaseInsensitiveColumns(true))
+	})
+}
+
+func TestAllowMissingColumns(t *testing.T) {
+	type Contact struct {
+		Email string
+		Phone *string
+	}
+	type V1 struct {
+		ID   int64
+		Name string
+	}
+	type V2 struct {
+		ID      int64
+		Name    string
+		Age     int32
+		Tags    []string
+		Contact Contact
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []V1{{ID: 1, Name: "Luke"}, {ID: 2, Name: "Leia"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := []V2{{ID: 1, Name: "Luke", Tags: []string{}}, {ID: 2, Name: "Leia", Tags: []string{}}}
+
+	t.Run("default", func(t *testing.T) {
+		rows, err := parquet.Read[V2](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		r := parquet.NewGenericReader[V2](bytes.NewReader(buf.Bytes()), parquet.AllowMissingColumns(true))
+		defer r.Close()
+		rows := make([]V2, 2)
+		if n, err := r.Read(rows); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, rows)
+		}
+	})
+
+	t.Run("disallowed", func(t *testing.T) {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Errorf("expected a schema mismatch error, got %v", err)
+			}
+		}()
+		parquet.NewGenericReader[V2](bytes.NewReader(buf.Bytes()), parquet.AllowMissingColumns(false))
+	})
+
+	t.Run("disallowed with all columns", func(t *testing.T) {
+		r := parquet.NewGenericReader[V1](bytes.NewReader(buf.Bytes()),
+			parquet.AllowMissingColumns(false),
+			parquet.SchemaOf(V1{}),
+		)
+		defer r.Close()
+		rows := make([]V1, 2)
+		if n, err := r.Read(rows); n != len(rows) {
+			t.Fatalf("reading rows: n=%d err=%v", n, err)
+		}
+	})
+
+	t.Run("untyped reader", func(t *testing.T) {
+		r := parquet.NewReader(bytes.NewReader(buf.Bytes()), parquet.AllowMissingColumns(false))
+		defer r.Close()
+		row := V2{}
+		if err := r.Read(&row); !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Errorf("expected a schema mismatch error, got %v", err)
+		}
+	})
+}
+
+func TestOrderedMaps(t *testing.T) {
+	type Address struct {
+		Street string `parquet:"street"`
+		City   string `parquet:"city"`
+	}
+	type Row struct {
+		Name      string    `parquet:"name"`
+		ID        int64     `parquet:"id"`
+		Address   Address   `parquet:"address"`
+		Previous  []Address `parquet:"previous"`
+		Nicknames []string  `parquet:"nicknames,list"`
+	}
+
+	buf := new(bytes.Buffer)
+	rows := []Row{{
+		Name:      "Luke",
+		ID:        1,
+		Address:   Address{Street: "Homestead", City: "Tatooine"},
+		Previous:  []Address{{Street: "Lars", City: "Anchorhead"}},
+		Nicknames: []string{"Wormie"},
+	}}
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	want := parquet.OrderedMap{
+		{Key: "name", Value: "Luke"},
+		{Key: "id", Value: int64(1)},
+		{Key: "address", Value: parquet.OrderedMap{
+			{Key: "street", Value: "Homestead"},
+			{Key: "city", Value: "Tatooine"},
+		}},
+		{Key: "previous", Value: []any{parquet.OrderedMap{
+			{Key: "street", Value: "Lars"},
+			{Key: "city", Value: "Anchorhead"},
+		}}},
+		{Key: "nicknames", Value: parquet.OrderedMap{
+			{Key: "list", Value: []any{parquet.OrderedMap{
+				{Key: "element", Value: "Wormie"},
+			}}},
+		}},
+	}
+
+	got, err := parquet.Read[any](bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.OrderedMaps(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", want, got)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buf.Bytes()), parquet.OrderedMaps(true))
+	defer reader.Close()
+	var row any
+	if err := reader.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("row mismatch:\nwant: %+v\ngot:  %+v", want, row)
+	}
+	if keys := row.(parquet.OrderedMap).Keys(); !reflect.DeepEqual(keys, []string{"name", "id", "address", "previous", "nicknames"}) {
+		t.Errorf("wrong keys: %q", keys)
+	}
+
+	genericReader := parquet.NewGenericReader[any](bytes.NewReader(buf.Bytes()), parquet.OrderedMaps(true))
+	defer genericReader.Close()
+	row = nil
+	if ok, err := genericReader.ReadInto(&row); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("no rows read by ReadInto")
+	}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("row mismatch reading into a value:\nwant: %+v\ngot:  %+v", want, row)
+	}
+}
+
+func TestGenericReaderRowFactory(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id"`
+		Payload []byte `parquet:"payload"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Payload: bytes.Repeat([]byte{byte(i)}, 10)}
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	// The pool hands out rows of which the payload buffers are recycled.
+	pool := make([]*Row, 0, len(rows))
+	buffers := make(map[*byte]bool, len(rows))
+	for i := 0; i < len(rows); i++ {
+		row := &Row{Payload: make([]byte, 0, 64)}
+		buffers[&row.Payload[:1][0]] = true
+		pool = append(pool, row)
+	}
+	factory := func() *Row {
+		row := pool[len(pool)-1]
+		pool = pool[:len(pool)-1]
+		return row
+	}
+
+	reader := parquet.NewGenericReader[*Row](bytes.NewReader(buf.Bytes()), parquet.RowFactory(factory))
+	defer reader.Close()
+
+	got := make([]*Row, 0, len(rows))
+	for {
+		batch := make([]*Row, 7)
+		n, err := reader.Read(batch)
+		got = append(got, batch[:n]...)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if len(got) != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+	}
+	if len(pool) != 0 {
+		t.Errorf("%d rows were not obtained from the factory", len(pool))
+	}
+	for i, row := range got {
+		if !reflect.DeepEqual(*row, rows[i]) {
+			t.Errorf("row %d mismatch: want=%+v got=%+v", i, rows[i], *row)
+		}
+		if !buffers[&row.Payload[0]] {
+			t.Errorf("row %d was not decoded into the buffer of the row returned by the factory", i)
+		}
+	}
+
+	t.Run("mismatching type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic using a row factory of the wrong type")
+			}
+		}()
+		parquet.NewGenericReader[Row](bytes.NewReader(buf.Bytes()), parquet.RowFactory(factory))
+	})
+}
+
+func TestGenericReaderSchema(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+		Age  int32  `parquet:"age"`
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []Row{{ID: 1, Name: "Luke", Age: 19}}); err != nil {
+		t.Fatal(err)
+	}
+	f := openFile(t, buf.Bytes())
+
+	r := parquet.NewGenericReader[any](f)
+	defer r.Close()
+	if schema := r.Schema(); schema.String() != f.Schema().String() {
+		t.Errorf("wrong schema of reader of any values:\nwant: %s\ngot:  %s", f.Schema(), schema)
+	}
+
+	projected := parquet.NewGenericReader[any](f, parquet.Columns("name", "id"))
+	defer projected.Close()
+	const want = `message Row {
+	required int64 id (INT(64,true));
+	required binary name (STRING);
+}`
+	if got := projected.Schema().String(); got != want {
+		t.Errorf("wrong schema of projected reader:\nwant: %s\ngot:  %s", want, got)
+	}
+	rows := make([]any, 1)
+	if n, err := projected.Read(rows); n != 1 || (err != nil && err != io.EOF) {
+		t.Fatalf("reading rows: n=%d err=%v", n, err)
+	}
+	for _, column := range projected.Schema().Columns() {
+		if _, ok := rows[0].(map[string]any)[column[0]]; !ok {
+			t.Errorf("missing column %q in row read with the projected schema: %v", column, rows[0])
+		}
+	}
+}
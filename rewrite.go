@@ -0,0 +1,248 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/segmentio/encoding/thrift"
+)
+
+// Rewrite writes the rows of src to dst, changing the compression codecs and
+// encodings of its columns.
+//
+// The transform function is called with the path of each leaf column of src,
+// made of the names of the parent groups joined by dots, and returns the codec
+// and encoding that the column is rewritten with. Returning a nil codec or
+// encoding retains the codec or encoding of the column in src.
+//
+// The row groups, row order, and key/value metadata of src are preserved. The
+// column chunks which already use the codec and encoding requested for their
+// column are copied verbatim, including their statistics, page index, and
+// bloom filter, the other column chunks are decoded and written again.
+// Column chunks can only be copied if the page index of src was loaded.
+//
+// The options configure the writer used to produce dst; options which apply to
+// the content of the file, like bloom filters, only affect the column chunks
+// which are written again.
+//
+// Encrypted parquet files cannot be rewritten.
+func Rewrite(dst io.Writer, src *File, transform func(column string) (compress.Codec, encoding.Encoding), options ...WriterOption) error {
+	for i := range src.metadata.RowGroups {
+		for j := range src.metadata.RowGroups[i].Columns {
+			if chunk := &src.metadata.RowGroups[i].Columns[j]; isEncryptedColumnChunk(chunk) {
+				return fmt.Errorf("cannot rewrite column %q: %w", columnPath(chunk.MetaData.PathInSchema), ErrEncryptedColumn)
+			}
+		}
+	}
+
+	schema, err := rewriteSchema(src.Schema(), transform)
+	if err != nil {
+		return err
+	}
+
+	writerOptions := make([]WriterOption, 0, len(src.metadata.KeyValueMetadata)+len(options)+1)
+	for _, kv := range src.metadata.KeyValueMetadata {
+		writerOptions = append(writerOptions, KeyValueMetadata(kv.Key, kv.Value))
+	}
+	writerOptions = append(writerOptions, options...)
+	writerOptions = append(writerOptions, schema)
+
+	w := NewWriter(dst, writerOptions...)
+
+	for _, rowGroup := range src.RowGroups() {
+		numRows := rowGroup.NumRows()
+		if numRows == 0 {
+			continue
+		}
+		chunks := rowGroup.ColumnChunks()
+		w.writer.configureBloomFilters(chunks)
+
+		for i, c := range w.writer.columns {
+			if chunk := chunks[i].(*fileColumnChunk); c.canCopyColumnChunk(chunk) {
+				c.copyColumnChunk(chunk, numRows)
+			}
+		}
+
+		err := w.writer.forEachColumn(func(i int, c *writerColumn) error {
+			if c.copied != nil {
+				return nil
+			}
+			if err := c.writeColumnChunk(chunks[i]); err != nil {
+				return fmt.Errorf("writing values of row group column %d: %w", i, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		w.writer.numRows += numRows
+		if _, err := w.writer.writeRowGroup(schema, rowGroup.SortingColumns()); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func rewriteSchema(schema *Schema, transform func(string) (compress.Codec, encoding.Encoding)) (*Schema, error) {
+	fields, err := rewriteFields(schema.Fields(), nil, transform)
+	if err != nil {
+		return nil, err
+	}
+	return newSchema(schema.Name(), &rewrittenNode{Node: schema, fields: fields}), nil
+}
+
+func rewriteFields(fields []Field, path columnPath, transform func(string) (compress.Codec, encoding.Encoding)) ([]Field, error) {
+	rewritten := make([]Field, len(fields))
+	for i, field := range fields {
+		fieldPath := path.append(field.Name())
+		f := &rewrittenField{Field: field}
+
+		if field.Leaf() {
+			f.compression, f.encoding = transform(fieldPath.String())
+			if f.compression == nil {
+				f.compression = field.Compression()
+			}
+			if f.encoding == nil {
+				f.encoding = field.Encoding()
+			} else if kind := field.Type().Kind(); !canEncode(f.encoding, kind) {
+				return nil, fmt.Errorf("cannot rewrite column %q of type %s with %s encoding", fieldPath, kind, f.encoding.Encoding())
+			}
+		} else {
+			subfields, err := rewriteFields(field.Fields(), fieldPath, transform)
+			if err != nil {
+				return nil, err
+			}
+			f.fields = subfields
+		}
+
+		rewritten[i] = f
+	}
+	return rewritten, nil
+}
+
+type rewrittenNode struct {
+	Node
+	fields []Field
+}
+
+func (n *rewrittenNode) Fields() []Field { return n.fields }
+
+type rewrittenField struct {
+	Field
+	fields      []Field
+	compression compress.Codec
+	encoding    encoding.Encoding
+}
+
+func (f *rewrittenField) Fields() []Field { return f.fields }
+
+func (f *rewrittenField) Compression() compress.Codec { return f.compression }
+
+func (f *rewrittenField) Encoding() encoding.Encoding { return f.encoding }
+
+// copiedColumnChunk represents a column chunk of a parquet file which is copied
+// to the next row group written by a column writer.
+type copiedColumnChunk struct {
+	chunk             *fileColumnChunk
+	columnIndex       format.ColumnIndex
+	bloomFilterOffset int64
+	// The metadata and page locations of the writer column, which are replaced
+	// by those of the copied column chunk while it is written, and restored
+	// when the column is reset.
+	metaData      format.ColumnMetaData
+	pageLocations []format.PageLocation
+}
+
+// canCopyColumnChunk returns true if chunk can be copied verbatim to the
+// output of c, which requires the same compression codec and encoding.
+func (c *writerColumn) canCopyColumnChunk(chunk *fileColumnChunk) bool {
+	if chunk.columnIndex == nil || chunk.offsetIndex == nil {
+		return false
+	}
+	metaData := &chunk.chunk.MetaData
+	return metaData.Codec == c.compression.CompressionCodec() &&
+		dataPageEncodingOf(metaData.Encoding) == dataPageEncodingOf([]format.Encoding{c.encoding.Encoding()})
+}
+
+// dataPageEncodingOf returns the encoding of the values of data pages of a
+// column chunk declaring the given list of encodings.
+func dataPageEncodingOf(encodings []format.Encoding) format.Encoding {
+	for _, encoding := range encodings {
+		switch encoding {
+		case format.Plain, format.RLE:
+		case format.PlainDictionary:
+			return format.RLEDictionary
+		default:
+			return encoding
+		}
+	}
+	return format.Plain
+}
+
+func (c *writerColumn) copyColumnChunk(chunk *fileColumnChunk, numRows int64) {
+	c.copied = &copiedColumnChunk{
+		chunk:       chunk,
+		columnIndex: *chunk.columnIndex,
+	}
+	c.numRows = numRows
+}
+
+func (c *writerColumn) writeCopiedBloomFilter(w *offsetTrackingWriter) error {
+	filter := c.copied.chunk.bloomFilter
+	if filter == nil {
+		return nil
+	}
+	c.copied.bloomFilterOffset = w.offset
+
+	// Bloom filters are only loaded from parquet files if they use the split
+	// block algorithm with the xxhash function.
+	header := format.BloomFilterHeader{NumBytes: int32(filter.Size())}
+	header.Algorithm.Block = &format.SplitBlockAlgorithm{}
+	header.Hash.XxHash = &format.XxHash{}
+	header.Compression.Uncompressed = &format.BloomFilterUncompressed{}
+
+	e := thrift.NewEncoder(c.header.protocol.NewWriter(w))
+	if err := e.Encode(&header); err != nil {
+		return err
+	}
+	_, err := io.Copy(w, io.NewSectionReader(&filter.SectionReader, 0, filter.Size()))
+	return err
+}
+
+func (c *writerColumn) writeCopiedColumnChunk(w *offsetTrackingWriter) error {
+	chunk := c.copied.chunk
+	metaData := chunk.chunk.MetaData
+
+	start := metaData.DataPageOffset
+	if metaData.DictionaryPageOffset != 0 {
+		start = metaData.DictionaryPageOffset
+	}
+	shift := w.offset - start
+
+	if _, err := io.Copy(w, io.NewSectionReader(chunk.file, start, metaData.TotalCompressedSize)); err != nil {
+		return err
+	}
+
+	metaData.DataPageOffset += shift
+	if metaData.DictionaryPageOffset != 0 {
+		metaData.DictionaryPageOffset += shift
+	}
+	metaData.IndexPageOffset = 0
+	metaData.BloomFilterOffset = c.copied.bloomFilterOffset
+	metaData.EncodingStats = append([]format.PageEncodingStats(nil), metaData.EncodingStats...)
+
+	pageLocations := make([]format.PageLocation, len(chunk.offsetIndex.PageLocations))
+	for i, location := range chunk.offsetIndex.PageLocations {
+		location.Offset += shift
+		pageLocations[i] = location
+	}
+
+	c.copied.metaData, c.columnChunk.MetaData = c.columnChunk.MetaData, metaData
+	c.copied.pageLocations, c.offsetIndex.PageLocations = c.offsetIndex.PageLocations, pageLocations
+	return nil
+}
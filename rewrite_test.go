@@ -0,0 +1,144 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+type rewriteRow struct {
+	ID    int64   `parquet:"id,snappy"`
+	Name  string  `parquet:"name,snappy"`
+	Score float64 `parquet:"score,snappy"`
+}
+
+func TestRewrite(t *testing.T) {
+	rows := make([]rewriteRow, 1000)
+	for i := range rows {
+		rows[i] = rewriteRow{ID: int64(i), Name: fmt.Sprintf("name-%d", i%10), Score: float64(i) / 10}
+	}
+
+	src := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[rewriteRow](src,
+		parquet.MaxRowsPerRowGroup(300),
+		parquet.KeyValueMetadata("hello", "world"),
+		parquet.BloomFilters(parquet.SplitBlockFilter(10, "id")),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	srcFile := openFile(t, src.Bytes())
+
+	dst := new(bytes.Buffer)
+	err := parquet.Rewrite(dst, srcFile, func(column string) (compress.Codec, encoding.Encoding) {
+		if column == "name" {
+			return &parquet.Zstd, &parquet.RLEDictionary
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstFile := openFile(t, dst.Bytes())
+
+	if value, ok := dstFile.Lookup("hello"); !ok || value != "world" {
+		t.Errorf("key/value metadata was not preserved: %q", value)
+	}
+	if n, want := len(dstFile.RowGroups()), len(srcFile.RowGroups()); n != want {
+		t.Fatalf("wrong number of row groups: want=%d got=%d", want, n)
+	}
+
+	for i, rowGroup := range dstFile.RowGroups() {
+		srcChunks := srcFile.RowGroups()[i].ColumnChunks()
+		for j, chunk := range rowGroup.ColumnChunks() {
+			srcMetaData := srcFile.Metadata().RowGroups[i].Columns[j].MetaData
+			metaData := dstFile.Metadata().RowGroups[i].Columns[j].MetaData
+
+			if j == 1 { // name
+				if metaData.Codec != format.Zstd {
+					t.Errorf("row group %d: name column was not recompressed: %s", i, metaData.Codec)
+				}
+				if metaData.DictionaryPageOffset == 0 {
+					t.Errorf("row group %d: name column has no dictionary page", i)
+				}
+				continue
+			}
+
+			if !reflect.DeepEqual(metaData.Statistics, srcMetaData.Statistics) {
+				t.Errorf("row group %d column %d: statistics mismatch:\nwant: %+v\ngot:  %+v", i, j, srcMetaData.Statistics, metaData.Statistics)
+			}
+			k := i*len(srcChunks) + j
+			if !reflect.DeepEqual(dstFile.ColumnIndexes()[k], srcFile.ColumnIndexes()[k]) {
+				t.Errorf("row group %d column %d: column index mismatch", i, j)
+			}
+			if offset := dstFile.OffsetIndexes()[k].PageLocations[0].Offset; offset != metaData.DataPageOffset {
+				t.Errorf("row group %d column %d: wrong offset of first page: want=%d got=%d", i, j, metaData.DataPageOffset, offset)
+			}
+			if want, got := readRawPages(t, srcChunks[j]), readRawPages(t, chunk); !reflect.DeepEqual(want, got) {
+				t.Errorf("row group %d column %d: pages were not copied verbatim", i, j)
+			}
+		}
+
+		if ok, err := rowGroup.ColumnChunks()[0].BloomFilter().Check(parquet.ValueOf(int64(i * 300))); err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Errorf("row group %d: bloom filter of the id column was not copied", i)
+		}
+	}
+
+	got, err := parquet.Read[rewriteRow](bytes.NewReader(dst.Bytes()), int64(dst.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Error("rows mismatch after rewriting the file")
+	}
+}
+
+func TestRewriteInvalidEncoding(t *testing.T) {
+	src := new(bytes.Buffer)
+	if err := parquet.Write(src, []rewriteRow{{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	err := parquet.Rewrite(io.Discard, openFile(t, src.Bytes()), func(column string) (compress.Codec, encoding.Encoding) {
+		return nil, &parquet.DeltaByteArray
+	})
+	if err == nil {
+		t.Error("expected an error rewriting an int64 column with the DELTA_BYTE_ARRAY encoding")
+	}
+}
+
+func openFile(t *testing.T, b []byte) *parquet.File {
+	t.Helper()
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func readRawPages(t *testing.T, chunk parquet.ColumnChunk) (pages [][]byte) {
+	t.Helper()
+	r := parquet.NewRawPageReader(chunk)
+	defer r.Close()
+	for {
+		_, data, err := r.ReadRawPage()
+		if err == io.EOF {
+			return pages
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, data)
+	}
+}
@@ -399,6 +399,9 @@ type levels struct {
 	repetitionDepth byte
 	repetitionLevel byte
 	definitionLevel byte
+	// When reconstructing rows, reuse indicates that the memory of slices and
+	// maps held by the Go value may be reused instead of allocating new ones.
+	reuse bool
 }
 
 // deconstructFunc accepts a row, the current levels, the value to deserialize
@@ -426,6 +429,11 @@ func deconstructFuncOf(columnIndex int16, node Node) (int16, deconstructFunc) {
 func deconstructFuncOfOptional(columnIndex int16, node Node) (int16, deconstructFunc) {
 	columnIndex, deconstruct := deconstructFuncOf(columnIndex, Required(node))
 	return columnIndex, func(columns [][]Value, levels levels, value reflect.Value) {
+		// Pointers held in values of type any, for example in map[string]any
+		// rows, are null when they are nil.
+		if value.Kind() == reflect.Interface && value.Elem().Kind() == reflect.Ptr {
+			value = value.Elem()
+		}
 		if value.IsValid() {
 			if value.IsZero() {
 				value = reflect.Value{}
@@ -534,12 +542,17 @@ func deconstructFuncOfLeaf(columnIndex int16, node Node) (int16, deconstructFunc
 	typ := node.Type()
 	kind := typ.Kind()
 	lt := typ.LogicalType()
+	custom, _ := typ.(*customType)
 	valueColumnIndex := ^columnIndex
 	return columnIndex + 1, func(columns [][]Value, levels levels, value reflect.Value) {
 		v := Value{}
 
 		if value.IsValid() {
-			v = makeValue(kind, lt, value)
+			if custom != nil {
+				v = custom.makeValue(value)
+			} else {
+				v = makeValue(kind, lt, value)
+			}
 		}
 
 		v.repetitionLevel = levels.repetitionLevel
@@ -605,7 +618,11 @@ func reconstructFuncOfOptional(columnIndex int16, node Node) (int16, reconstruct
 	}
 }
 
-func setMakeSlice(v reflect.Value, n int) reflect.Value {
+func setMakeSlice(v reflect.Value, n int, levels levels) reflect.Value {
+	if levels.reuse && v.Kind() == reflect.Slice && !v.IsNil() && v.Cap() >= n {
+		v.SetLen(n)
+		return v
+	}
 	t := v.Type()
 	if t.Kind() == reflect.Interface {
 		t = reflect.TypeOf(([]interface{})(nil))
@@ -615,6 +632,21 @@ func setMakeSlice(v reflect.Value, n int) reflect.Value {
 	return s
 }
 
+// setMakeMap sets v to an empty map with room for n entries. Maps may be reused
+// across reads, the entries of a previous row must not be retained.
+func setMakeMap(v reflect.Value, n int, levels levels) {
+	switch {
+	case v.IsNil():
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+	case levels.reuse:
+		for it := v.MapRange(); it.Next(); {
+			v.SetMapIndex(it.Key(), reflect.Value{})
+		}
+	case v.Len() > 0:
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+	}
+}
+
 //go:noinline
 func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstructFunc) {
 	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, Required(node))
@@ -631,7 +663,7 @@ func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstruct
 		}
 
 		if columns[0][0].definitionLevel < levels.definitionLevel {
-			setMakeSlice(value, 0)
+			setMakeSlice(value, 0, levels)
 			return nil
 		}
 
@@ -652,7 +684,7 @@ func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstruct
 			}
 		}
 
-		value = setMakeSlice(value, n)
+		value = setMakeSlice(value, n, levels)
 
 		for i := 0; i < n; i++ {
 			for j, column := range values {
@@ -717,7 +749,7 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 		}
 
 		if columns[0][0].definitionLevel < levels.definitionLevel {
-			value.Set(reflect.MakeMap(value.Type()))
+			setMakeMap(value, 0, levels)
 			return nil
 		}
 
@@ -741,9 +773,7 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 			}
 		}
 
-		if value.IsNil() {
-			value.Set(reflect.MakeMapWithSize(t, n))
-		}
+		setMakeMap(value, n, levels)
 
 		elem := reflect.New(keyValueElem).Elem()
 		for i := 0; i < n; i++ {
@@ -799,7 +829,7 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 			elem := reflect.New(elemType).Elem()
 			zero := reflect.Zero(elemType)
 
-			if value.Len() > 0 {
+			if value.IsNil() || value.Len() > 0 {
 				value.Set(reflect.MakeMap(value.Type()))
 			}
 
@@ -836,11 +866,18 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 //go:noinline
 func reconstructFuncOfLeaf(columnIndex int16, node Node) (int16, reconstructFunc) {
 	typ := node.Type()
-	return columnIndex + 1, func(value reflect.Value, _ levels, columns [][]Value) error {
+	_, custom := typ.(*customType)
+	return columnIndex + 1, func(value reflect.Value, levels levels, columns [][]Value) error {
 		column := columns[0]
 		if len(column) == 0 {
 			return fmt.Errorf("no values found in parquet row for column %d", columnIndex)
 		}
+		if levels.reuse && !custom && value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 && !value.IsNil() {
+			if kind := column[0].Kind(); kind == ByteArray || kind == FixedLenByteArray {
+				value.SetBytes(append(value.Bytes()[:0], column[0].byteArray()...))
+				return nil
+			}
+		}
 		return typ.AssignValue(value, column[0])
 	}
 }
@@ -3,6 +3,7 @@ package parquet
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/parquet-go/parquet-go/internal/debug"
 )
@@ -37,6 +38,11 @@ type RowGroup interface {
 	// Returns the list of sorting columns describing how rows are sorted in the
 	// group.
 	//
+	// For row groups of parquet files, the sorting columns are those declared
+	// in the file footer, including the direction and the ordering of nulls of
+	// each column. Programs may check them before relying on the order of rows,
+	// for example to guard the use of Search on column indexes.
+	//
 	// The method will return an empty slice if the rows are not sorted.
 	SortingColumns() []SortingColumn
 
@@ -102,6 +108,32 @@ func Descending(path ...string) SortingColumn { return descending(path) }
 // the row group to place null values first in the column.
 func NullsFirst(sortingColumn SortingColumn) SortingColumn { return nullsFirst{sortingColumn} }
 
+// NullsLast wraps the SortingColumn passed as argument so that it instructs
+// the row group to place null values last in the column, which is the default
+// for columns constructed by Ascending and Descending.
+func NullsLast(sortingColumn SortingColumn) SortingColumn {
+	if nf, ok := sortingColumn.(nullsFirst); ok {
+		sortingColumn = nf.SortingColumn
+	}
+	return nullsLast{sortingColumn}
+}
+
+// SortingColumnOf constructs a SortingColumn value which dictates to sort the
+// column at the given path in descending order if desc is true, and to place
+// null values first if nullsFirst is true.
+func SortingColumnOf(path []string, desc, nullsFirst bool) SortingColumn {
+	var sortingColumn SortingColumn
+	if desc {
+		sortingColumn = Descending(path...)
+	} else {
+		sortingColumn = Ascending(path...)
+	}
+	if nullsFirst {
+		sortingColumn = NullsFirst(sortingColumn)
+	}
+	return sortingColumn
+}
+
 type ascending []string
 
 func (asc ascending) String() string   { return fmt.Sprintf("ascending(%s)", columnPath(asc)) }
@@ -121,6 +153,13 @@ type nullsFirst struct{ SortingColumn }
 func (nf nullsFirst) String() string   { return fmt.Sprintf("nulls_first+%s", nf.SortingColumn) }
 func (nf nullsFirst) NullsFirst() bool { return true }
 
+type nullsLast struct{ SortingColumn }
+
+func (nl nullsLast) String() string {
+	return strings.TrimPrefix(fmt.Sprint(nl.SortingColumn), "nulls_first+")
+}
+func (nl nullsLast) NullsFirst() bool { return false }
+
 func searchSortingColumn(sortingColumns []SortingColumn, path columnPath) int {
 	// There are usually a few sorting columns in a row group, so the linear
 	// scan is the fastest option and works whether the sorting column list
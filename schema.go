@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,6 +27,8 @@ type Schema struct {
 	reconstruct reconstructFunc
 	mapping     columnMapping
 	columns     [][]string
+	// Bloom filters declared with the "bloom" struct tag.
+	bloomFilters []BloomFilterColumn
 }
 
 // SchemaOf constructs a parquet schema from a Go value.
@@ -42,23 +45,36 @@ type Schema struct {
 //
 // The following options are also supported in the "parquet" struct tag:
 //
-//	optional  | make the parquet column optional
-//	snappy    | sets the parquet column compression codec to snappy
-//	gzip      | sets the parquet column compression codec to gzip
-//	brotli    | sets the parquet column compression codec to brotli
-//	lz4       | sets the parquet column compression codec to lz4
-//	zstd      | sets the parquet column compression codec to zstd
-//	plain     | enables the plain encoding (no-op default)
-//	dict      | enables dictionary encoding on the parquet column
-//	delta     | enables delta encoding on the parquet column
-//	list      | for slice types, use the parquet LIST logical type
-//	enum      | for string types, use the parquet ENUM logical type
-//	uuid      | for string and [16]byte types, use the parquet UUID logical type
-//	decimal   | for int32, int64 and [n]byte types, use the parquet DECIMAL logical type
-//	date      | for int32 types use the DATE logical type
-//	timestamp | for int64 types use the TIMESTAMP logical type with, by default, millisecond precision
-//	split     | for float32/float64, use the BYTE_STREAM_SPLIT encoding
-//	id(n)     | where n is int denoting a column field id. Example id(2) for a column with field id of 2
+//	optional         | make the parquet column optional
+//	snappy           | sets the parquet column compression codec to snappy
+//	gzip             | sets the parquet column compression codec to gzip
+//	brotli           | sets the parquet column compression codec to brotli
+//	lz4              | sets the parquet column compression codec to lz4
+//	lz4raw           | alias of lz4, sets the parquet column compression codec to lz4_raw
+//	zstd             | sets the parquet column compression codec to zstd
+//	plain            | enables the plain encoding (no-op default)
+//	dict             | enables dictionary encoding on the parquet column
+//	delta            | enables delta encoding on the parquet column
+//	delta_byte_array | for string, byte slice and byte array types, use the DELTA_BYTE_ARRAY encoding, which stores the length of the prefix shared with the previous value and the remaining suffix; same as delta on these types
+//	delta_length     | for string and byte slice types, use the DELTA_LENGTH_BYTE_ARRAY encoding, which is the default for byte array columns without an explicit encoding
+//	rle              | for bool types, use the RLE/bit-packing hybrid encoding, which is smaller than plain encoding for long runs of identical values
+//	list             | for slice types, use the parquet LIST logical type
+//	json             | use the parquet JSON logical type; strings and byte slices are written as-is, other types are serialized with encoding/json
+//	bson             | for string and byte slice types, use the parquet BSON logical type; values are written as-is and must already be serialized BSON documents
+//	geometry         | for string and byte slice types, use the parquet GEOMETRY logical type; values are written as-is and must already be encoded in WKB
+//	geography        | for string and byte slice types, use the parquet GEOGRAPHY logical type; values are written as-is and must already be encoded in WKB
+//	enum             | for string types, including named types and slices of strings, use the parquet ENUM logical type
+//	uuid             | for [16]byte types, including named types like uuid.UUID, use the parquet UUID logical type
+//	float16          | for uint16, [2]byte, float32 and float64 types, use the parquet FLOAT16 logical type; float values are rounded to the nearest half precision number
+//	interval         | for parquet.Interval and [12]byte types, use the parquet INTERVAL converted type
+//	decimal          | for int32, int64, [n]byte, big.Int and big.Rat types, use the parquet DECIMAL logical type
+//	date             | for int32 and time.Time types use the DATE logical type; the time of the day of time.Time values is truncated
+//	timestamp        | for int64 types use the TIMESTAMP logical type with, by default, millisecond precision
+//	time             | for time.Duration, int32 and int64 types use the TIME logical type with, by default, millisecond precision
+//	split            | for float32/float64, use the BYTE_STREAM_SPLIT encoding
+//	bloom            | generates a split block bloom filter for the column, sized for a false positive rate of 1% unless another rate is passed as argument
+//	custom:name      | use the custom logical type registered under the given name with RegisterLogicalType
+//	id(n)            | where n is int denoting a column field id. Example id(2) for a column with field id of 2
 //
 // # The date logical type is an int32 value of the number of days since the unix epoch
 //
@@ -69,6 +85,80 @@ type Schema struct {
 //	  TimestrampMicros int64 `parquet:"timestamp_micros,timestamp(microsecond)"
 //	}
 //
+// The precision of the time logical type is set the same way, using millis,
+// micros, or nanos as argument. Int32 fields hold times in milliseconds, int64
+// fields in microseconds or nanoseconds, while time.Duration fields are
+// converted to and from the precision of the column. The values are assumed to
+// be adjusted to UTC, appending ":local" to the precision clears the
+// isAdjustedToUTC flag of the logical type. For example:
+//
+//	type Event struct {
+//	  Start time.Duration `parquet:"start,time(micros)"`
+//	  End   time.Duration `parquet:"end,time(micros:local)"`
+//	}
+//
+// Bloom filters declared with the bloom tag are sized with 11 bits per value,
+// the size that the parquet specification gives for a false positive rate of
+// 1%. The false positive rate can be changed by passing it as argument to the
+// bloom tag, for example:
+//
+//	type User struct {
+//		ID string `parquet:"id,bloom(0.001)"`
+//	}
+//
+// Bloom filters configured on the writer with the BloomFilters option take
+// precedence over the ones declared with the bloom tag.
+//
+// The Coordinate Reference System of the geometry and geography tags defaults
+// to OGC:CRS84, and can be changed by passing it as argument, for example:
+//
+//	type Parcel struct {
+//		Shape []byte `parquet:"shape,geometry(EPSG:3857)"`
+//	}
+//
+// The level of the gzip, brotli, zstd and lz4 compression codecs can be set by
+// appending it to the codec name after a colon, for example:
+//
+//	type Archive struct {
+//		Payload []byte `parquet:"payload,zstd:19"`
+//	}
+//
+// See CompressionLevel for the range of levels supported by each codec.
+//
+// Encoding and compression tags compose, the values of a column are encoded
+// first, then the pages are compressed. For example, the BYTE_STREAM_SPLIT
+// encoding groups the bytes of floating point values by significance, which
+// usually improves the compression ratio of zstd:
+//
+//	type Measurement struct {
+//		Value float64 `parquet:"value,split,zstd:7"`
+//	}
+//
+// Fields of pointer to scalar types, like *int32 or *string, are optional
+// columns where nil pointers are null values, and the tags apply to the types
+// that they point to:
+//
+//	type Event struct {
+//		Deleted *int64 `parquet:"deleted,timestamp(millisecond)"`
+//	}
+//
+// Parquet columns only record the difference between nil and empty slices for
+// optional lists, declared with both the list and optional tags: nil slices
+// are written as null lists, and empty slices as lists with no elements. Other
+// slices are read back as empty slices when they have no elements.
+//
+//	type Record struct {
+//		Values []int `parquet:"values,list,optional"`
+//	}
+//
+// Since repeated fields cannot be directly nested in parquet schemas, the inner
+// levels of slices of slices, such as [][]T, are represented by LIST groups.
+// With the list tag, each level of the slice is a LIST:
+//
+//	type Matrix struct {
+//		Rows [][]float64 `parquet:"rows,list"`
+//	}
+//
 // The decimal tag must be followed by two integer parameters, the first integer
 // representing the scale and the second the precision; for example:
 //
@@ -76,13 +166,32 @@ type Schema struct {
 //		Cost int64 `parquet:"cost,decimal(0:3)"`
 //	}
 //
+// The parameters may also be given by name, in which case the scale defaults
+// to zero when omitted:
+//
+//	type Item struct {
+//		Cost int64 `parquet:"cost,decimal(precision=18,scale=4)"`
+//	}
+//
+// Decimal numbers of arbitrary precision are supported by tagging fields of
+// type *big.Rat, holding the exact value, *big.Int, holding the unscaled value,
+// or of types implementing the DecimalValue interface. These are stored as
+// INT64 when the precision is at most 18 digits, and as FIXED_LEN_BYTE_ARRAY
+// otherwise.
+//
 // Invalid combination of struct tags and Go types, or repeating options will
-// cause the function to panic.
+// cause the function to panic. Struct fields which map to columns of the same
+// name, for example because of a typo in a tag, cause the function to panic
+// with an error wrapping ErrDuplicateColumn.
 //
 // As a special case, if the field tag is "-", the field is omitted from the schema
 // and the data will not be written into the parquet file(s).
 // Note that a field with name "-" can still be generated using the tag "-,".
 //
+// Go maps are represented by groups of the parquet MAP logical type, made of a
+// repeated key_value group holding the key and value columns. Maps of optional
+// fields distinguish null maps from maps with zero entries.
+//
 // The configuration of Parquet maps are done via two tags:
 //   - The `parquet-key` tag allows to configure the key of a map.
 //   - The parquet-value tag allows users to configure a map's values, for example to declare their native Parquet types.
@@ -118,11 +227,127 @@ func schemaOf(model reflect.Type) *Schema {
 	return schema
 }
 
+// SchemaOfAny constructs a schema from a sample value, which is either a Go
+// struct, in which case the function behaves like SchemaOf, or a map of
+// string keys like the values of type map[string]any produced by decoding
+// JSON objects.
+//
+// The columns are inferred from the values of the map entries:
+//   - nested maps of string keys are represented by groups
+//   - slices of type []any are represented by repeated columns, of which the
+//     type is inferred from the elements; all the elements must map to the same
+//     type
+//   - other values are represented like struct fields of the same Go type, for
+//     example int64 values by INT64 columns, float64 by DOUBLE columns, strings
+//     by BYTE_ARRAY columns of the STRING logical type, and typed nil pointers
+//     by optional columns
+//
+// The function returns an error if the type of a column cannot be inferred from
+// the sample, for example when a map entry is nil, or a slice or map is empty.
+// Programs can declare the type of columns which may be null by setting the
+// entries of the sample to typed nil pointers, e.g. (*string)(nil).
+//
+// The schema is unnamed, and its columns are sorted by name like the fields of
+// Group nodes.
+func SchemaOfAny(sample any) (schema *Schema, err error) {
+	defer func() {
+		// SchemaOf and NewSchema panic when the Go types of the sample cannot
+		// be represented in a parquet schema, report it as an error.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("inferring parquet schema of %T: %v", sample, r)
+		}
+	}()
+
+	v := reflect.ValueOf(sample)
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		return SchemaOf(sample), nil
+	}
+	switch {
+	case v.Kind() == reflect.Struct:
+		return SchemaOf(sample), nil
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && v.Type().Elem().Kind() == reflect.Interface:
+		if v.Len() == 0 {
+			return nil, fmt.Errorf("cannot infer parquet schema from an empty map")
+		}
+		root, err := nodeOfSample(nil, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkDuplicateColumns(root); err != nil {
+			return nil, err
+		}
+		return newSchema("", root), nil
+	default:
+		return nil, fmt.Errorf("cannot infer parquet schema from value of type %T", sample)
+	}
+}
+
+func nodeOfSample(path columnPath, v reflect.Value) (Node, error) {
+	for v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, fmt.Errorf("%s: cannot infer the parquet type of a null value", path)
+		}
+		v = v.Elem()
+	}
+
+	switch t := v.Type(); {
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.Interface:
+		if v.Len() == 0 {
+			return nil, fmt.Errorf("%s: cannot infer the parquet columns of an empty map", path)
+		}
+		group := make(Group, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			name := iter.Key().String()
+			node, err := nodeOfSample(path.append(name), iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			group[name] = node
+		}
+		return group, nil
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Interface:
+		if v.Len() == 0 {
+			return nil, fmt.Errorf("%s: cannot infer the parquet type of the elements of an empty slice", path)
+		}
+		var elem Node
+		for i := 0; i < v.Len(); i++ {
+			node, err := nodeOfSample(path, v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case node.Repeated():
+				return nil, fmt.Errorf("%s: cannot represent slices of slices in parquet columns", path)
+			case elem == nil:
+				elem = node
+			case !nodesAreEqual(elem, node):
+				return nil, fmt.Errorf("%s: slice elements have different parquet types: %s and %s", path, elem.Type(), node.Type())
+			}
+		}
+		return Repeated(elem), nil
+
+	default:
+		return nodeOf(t, nil), nil
+	}
+}
+
 // NewSchema constructs a new Schema object with the given name and root node.
 //
 // The function panics if Node contains more leaf columns than supported by the
-// package (see parquet.MaxColumnIndex).
+// package (see parquet.MaxColumnIndex), or with an error wrapping
+// ErrDuplicateColumn if a group has multiple fields of the same name.
 func NewSchema(name string, root Node) *Schema {
+	if err := checkDuplicateColumns(root); err != nil {
+		panic(err)
+	}
+	return newSchema(name, root)
+}
+
+// newSchema is like NewSchema but does not check for duplicate column names,
+// it is used to construct schemas derived from other schemas, which were
+// already checked.
+func newSchema(name string, root Node) *Schema {
 	mapping, columns := columnMappingOf(root)
 	return &Schema{
 		name:        name,
@@ -131,6 +356,40 @@ func NewSchema(name string, root Node) *Schema {
 		reconstruct: makeReconstructFunc(root),
 		mapping:     mapping,
 		columns:     columns,
+		// Only schemas generated from Go struct types carry bloom filter
+		// declarations, this is a no-op for other nodes.
+		bloomFilters: bloomFilterColumnsOf(root, nil),
+	}
+}
+
+// checkDuplicateColumns returns an error listing the paths of the columns of
+// root which have the same name as another column of their group, since the
+// values of those columns could not be told apart.
+func checkDuplicateColumns(root Node) error {
+	var duplicates []string
+	forEachDuplicateColumnOf(root, nil, func(path columnPath) {
+		duplicates = append(duplicates, path.String())
+	})
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w: %s", ErrDuplicateColumn, strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+func forEachDuplicateColumnOf(node Node, path columnPath, do func(columnPath)) {
+	fields := node.Fields()
+	if len(fields) == 0 {
+		return
+	}
+	names := make(map[string]int, len(fields))
+	for _, field := range fields {
+		name := field.Name()
+		if names[name]++; names[name] == 2 {
+			do(path.append(name))
+		}
+	}
+	for _, field := range fields {
+		forEachDuplicateColumnOf(field, path.append(field.Name()), do)
 	}
 }
 
@@ -216,7 +475,9 @@ func (s *Schema) GoType() reflect.Type { return s.root.GoType() }
 // Deconstruct deconstructs a Go value and appends it to a row.
 //
 // The method panics is the structure of the go value does not match the
-// parquet schema.
+// parquet schema, or if a value cannot be represented in its column, for
+// example a decimal which overflows the precision of the column. Writers
+// return an error for the latter instead of panicking.
 func (s *Schema) Deconstruct(row Row, value interface{}) Row {
 	columns := make([][]Value, len(s.columns))
 	values := make([]Value, len(s.columns))
@@ -248,6 +509,10 @@ func (s *Schema) deconstructValueToColumns(columns [][]Value, value reflect.Valu
 // The method panics if the structure of the go value and parquet row do not
 // match.
 func (s *Schema) Reconstruct(value interface{}, row Row) error {
+	return s.reconstructValue(value, row, levels{})
+}
+
+func (s *Schema) reconstructValue(value interface{}, row Row, levels levels) error {
 	v := reflect.ValueOf(value)
 	if !v.IsValid() {
 		panic("cannot reconstruct row into go value of type <nil>")
@@ -275,7 +540,7 @@ func (s *Schema) Reconstruct(value interface{}, row Row) error {
 		return true
 	})
 	// we avoid the defer penalty by releasing b manually
-	err := s.reconstruct(v, levels{}, columns)
+	err := s.reconstruct(v, levels, columns)
 	b.release()
 	return err
 }
@@ -332,18 +597,301 @@ func (s *Schema) Lookup(path ...string) (LeafColumn, bool) {
 
 // Columns returns the list of column paths available in the schema.
 //
-// The method always returns the same slice value across calls to ColumnPaths,
+// The paths are those of the leaf columns, in the order of their column
+// indexes: the path at index i is the path of the column chunk at index i in
+// the list returned by the ColumnChunks method of row groups using the schema.
+// Each path may be passed to the Lookup method to retrieve its leaf column.
+//
+// The method always returns the same slice value across calls to Columns,
 // applications should treat it as immutable.
 func (s *Schema) Columns() [][]string {
 	return s.columns
 }
 
+// EstimatedRowSize returns an estimation of the size in bytes of the values of
+// a row of the schema, before compression and encoding.
+//
+// The estimation is made with EstimateRowSize, assuming that values of
+// variable-length BYTE_ARRAY columns are 20 bytes long.
+func (s *Schema) EstimatedRowSize() int64 {
+	return s.EstimateRowSize(estimatedSizeOfByteArrayValues)
+}
+
+// EstimateRowSize returns an estimation of the size in bytes of the values of
+// a row of the schema, before compression and encoding, assuming that values
+// of variable-length BYTE_ARRAY columns are byteArraySize bytes long.
+//
+// The estimation is the sum of the sizes of one value of each leaf column,
+// which is the width of the physical type for fixed-width columns. Optional
+// and repeated columns are counted as if each row had exactly one value, and
+// the space used by repetition and definition levels is not accounted for,
+// so the estimation is only a heuristic; programs may use it to derive the
+// number of rows to buffer in row groups of a target size.
+func (s *Schema) EstimateRowSize(byteArraySize int) int64 {
+	size := int64(0)
+	forEachLeafColumnOf(s.root, func(leaf leafColumn) {
+		if t := leaf.node.Type(); t.Kind() == ByteArray {
+			size += int64(byteArraySize)
+		} else {
+			size += int64(t.EstimateSize(1))
+		}
+	})
+	return size
+}
+
 // Comparator constructs a comparator function which orders rows according to
 // the list of sorting columns passed as arguments.
 func (s *Schema) Comparator(sortingColumns ...SortingColumn) func(Row, Row) int {
 	return compareRowsFuncOf(s, sortingColumns)
 }
 
+// Compatible returns a non-nil error if rows of the other schema cannot be
+// written to files of schema s without conversion, for example when appending
+// row groups with MergeFiles or CopyRows.
+//
+// The schemas are compatible if they have the same columns in the same order,
+// with equal repetition, physical and logical types. The names of the root
+// nodes are not compared.
+//
+// The returned error names the first incompatible column, and wraps
+// ErrSchemaMismatch.
+func (s *Schema) Compatible(other *Schema) error {
+	return compatibleNodes(s.root, other.root, nil)
+}
+
+func compatibleNodes(node1, node2 Node, path columnPath) error {
+	fields1 := node1.Fields()
+	fields2 := node2.Fields()
+
+	for i := 0; i < len(fields1) || i < len(fields2); i++ {
+		if i >= len(fields2) {
+			return fmt.Errorf("%w: column %s is missing", ErrSchemaMismatch, path.append(fields1[i].Name()))
+		}
+		if i >= len(fields1) {
+			return fmt.Errorf("%w: column %s is unexpected", ErrSchemaMismatch, path.append(fields2[i].Name()))
+		}
+
+		field1, field2 := fields1[i], fields2[i]
+		fieldPath := path.append(field1.Name())
+
+		if field1.Name() != field2.Name() {
+			return fmt.Errorf("%w: column %s is %s", ErrSchemaMismatch, fieldPath, path.append(field2.Name()))
+		}
+		if r1, r2 := fieldRepetitionTypeOf(field1), fieldRepetitionTypeOf(field2); r1 != r2 {
+			return fmt.Errorf("%w: column %s is %s but %s", ErrSchemaMismatch, fieldPath, r1, r2)
+		}
+		if field1.Leaf() != field2.Leaf() {
+			return fmt.Errorf("%w: column %s is a %s but a %s", ErrSchemaMismatch, fieldPath, nodeKindOf(field1), nodeKindOf(field2))
+		}
+
+		type1, type2 := field1.Type(), field2.Type()
+		if field1.Leaf() {
+			if type1.Kind() != type2.Kind() || type1.Length() != type2.Length() {
+				return fmt.Errorf("%w: column %s has physical type %s but %s", ErrSchemaMismatch, fieldPath, physicalTypeString(type1), physicalTypeString(type2))
+			}
+		}
+		if lt1, lt2 := type1.LogicalType(), type2.LogicalType(); !reflect.DeepEqual(lt1, lt2) {
+			return fmt.Errorf("%w: column %s has logical type %v but %v", ErrSchemaMismatch, fieldPath, lt1, lt2)
+		}
+
+		if !field1.Leaf() {
+			if err := compatibleNodes(field1, field2, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// UnionSchemas returns a schema holding the union of the columns of the given
+// schemas, which can be used to write rows of heterogeneous sources to a single
+// file. The name of the returned schema is the name of the first schema.
+//
+// Columns which are present in any of the schemas are present in the union,
+// and required columns are made optional so rows of the other schemas can be
+// written with null values; repeated columns remain repeated. Since the union
+// is built from Group nodes, the columns are ordered by name.
+//
+// Columns with the same name must have the same physical and logical types in
+// all schemas, and groups with a logical type like LIST or MAP must have the
+// same structure. The function returns an error wrapping ErrSchemaMismatch
+// naming the first conflicting column otherwise.
+func UnionSchemas(schemas ...*Schema) (*Schema, error) {
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("no schemas to union")
+	}
+	nodes := make([]Node, len(schemas))
+	for i, schema := range schemas {
+		nodes[i] = schema.root
+	}
+	root, err := unionGroups(nodes, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newSchema(schemas[0].Name(), root), nil
+}
+
+func unionGroups(groups []Node, path columnPath) (Group, error) {
+	union := make(Group)
+	names := []string{}
+	fields := map[string][]Node{}
+
+	for _, group := range groups {
+		for _, field := range group.Fields() {
+			name := field.Name()
+			if _, exists := fields[name]; !exists {
+				names = append(names, name)
+			}
+			fields[name] = append(fields[name], field)
+		}
+	}
+
+	for _, name := range names {
+		node, err := unionFields(fields[name], path.append(name))
+		if err != nil {
+			return nil, err
+		}
+		union[name] = node
+	}
+	return union, nil
+}
+
+func unionFields(fields []Node, path columnPath) (Node, error) {
+	first := fields[0]
+	repeated := false
+
+	for _, field := range fields {
+		repeated = repeated || field.Repeated()
+
+		if field.Leaf() != first.Leaf() {
+			return nil, fmt.Errorf("%w: column %s is a %s but a %s", ErrSchemaMismatch, path, nodeKindOf(first), nodeKindOf(field))
+		}
+		type1, type2 := first.Type(), field.Type()
+		if first.Leaf() {
+			if type1.Kind() != type2.Kind() || type1.Length() != type2.Length() {
+				return nil, fmt.Errorf("%w: column %s has physical type %s but %s", ErrSchemaMismatch, path, physicalTypeString(type1), physicalTypeString(type2))
+			}
+		}
+		if lt1, lt2 := type1.LogicalType(), type2.LogicalType(); !reflect.DeepEqual(lt1, lt2) {
+			return nil, fmt.Errorf("%w: column %s has logical type %v but %v", ErrSchemaMismatch, path, lt1, lt2)
+		}
+		// The structure of groups like LIST or MAP is defined by their logical
+		// type, their fields cannot be merged.
+		if !first.Leaf() && type1.LogicalType() != nil {
+			if err := compatibleNodes(first, field, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	node := first
+	if !first.Leaf() && first.Type().LogicalType() == nil {
+		group, err := unionGroups(fields, path)
+		if err != nil {
+			return nil, err
+		}
+		node = group
+	}
+
+	switch {
+	case repeated:
+		if !node.Repeated() {
+			node = Repeated(node)
+		}
+	case !node.Optional():
+		node = Optional(node)
+	}
+	return node, nil
+}
+
+func nodeKindOf(node Node) string {
+	if node.Leaf() {
+		return "leaf"
+	}
+	return "group"
+}
+
+func physicalTypeString(t Type) string {
+	if t.Kind() == FixedLenByteArray {
+		return fmt.Sprintf("%s(%d)", t.Kind(), t.Length())
+	}
+	return t.Kind().String()
+}
+
+// Diff returns a human-readable description of the differences between the
+// schemas s and other, or an empty string if they have the same columns.
+//
+// The output has one line per column present in only one of the schemas,
+// prefixed with "-" for columns of s and "+" for columns of other, in the
+// style of the diff program. Columns which exist in both schemas but have a
+// different repetition, physical type, or logical type are reported as a
+// removed line followed by an added line. Lines are sorted by column path,
+// which is made of the names of the parent groups joined by dots. The names
+// of the root nodes are not compared.
+func (s *Schema) Diff(other *Schema) string {
+	nodes1 := diffNodesOf(s.root)
+	nodes2 := diffNodesOf(other.root)
+
+	paths := make([]string, 0, len(nodes1)+len(nodes2))
+	for path := range nodes1 {
+		paths = append(paths, path)
+	}
+	for path := range nodes2 {
+		if _, ok := nodes1[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := new(strings.Builder)
+	for _, path := range paths {
+		node1, ok1 := nodes1[path]
+		node2, ok2 := nodes2[path]
+		if ok1 && ok2 && node1 == node2 {
+			continue
+		}
+		if ok1 {
+			fmt.Fprintf(diff, "- %s: %s\n", path, node1)
+		}
+		if ok2 {
+			fmt.Fprintf(diff, "+ %s: %s\n", path, node2)
+		}
+	}
+	return diff.String()
+}
+
+// diffNodesOf returns a map of the paths of all groups and leaves under node
+// to a description of their type.
+func diffNodesOf(node Node) map[string]string {
+	nodes := make(map[string]string)
+	var walk func(Node, columnPath)
+	walk = func(node Node, path columnPath) {
+		for _, field := range node.Fields() {
+			fieldPath := path.append(field.Name())
+			nodes[fieldPath.String()] = diffNodeString(field)
+			if !field.Leaf() {
+				walk(field, fieldPath)
+			}
+		}
+	}
+	walk(node, nil)
+	return nodes
+}
+
+func diffNodeString(node Node) string {
+	s := strings.ToLower(fieldRepetitionTypeOf(node).String())
+	if node.Leaf() {
+		s += " " + physicalTypeString(node.Type())
+	} else {
+		s += " group"
+	}
+	if annotation := annotationOf(node); annotation != "" {
+		s += " (" + annotation + ")"
+	}
+	return s
+}
+
 func (s *Schema) forEachNode(do func(name string, node Node)) {
 	forEachNodeOf(s.Name(), s, do)
 }
@@ -370,6 +918,10 @@ func structNodeOf(t reflect.Type) *structNode {
 			fields[i].Tag.Get("parquet-key"),
 			fields[i].Tag.Get("parquet-value"),
 		})
+		field.bloomFilterBitsPerValue = bloomFilterBitsPerValueOf(fields[i].Type, fields[i].Name, fields[i].Tag.Get("parquet"))
+		if field.bloomFilterBitsPerValue != 0 && !field.Node.Leaf() {
+			throwInvalidTag(fields[i].Type, fields[i].Name, "bloom")
+		}
 		s.fields[i] = field
 	}
 
@@ -467,6 +1019,9 @@ type structField struct {
 	Node
 	name  string
 	index []int
+	// Number of bits per value of the split block bloom filter declared with
+	// the "bloom" tag, or zero if the field had no bloom filter.
+	bloomFilterBitsPerValue uint
 }
 
 func (f *structField) Name() string { return f.name }
@@ -528,6 +1083,18 @@ func forEachStructTagOption(sf reflect.StructField, do func(t reflect.Type, opti
 	}
 }
 
+// listElementNodeOf returns the node of the elements of slices of type t.
+//
+// Repeated fields cannot be directly nested in parquet schemas, a slice of
+// slices is represented as a repeated field of LIST groups, so the repetition
+// of each level of the Go type is preserved.
+func listElementNodeOf(t reflect.Type, tag []string) Node {
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		return List(listElementNodeOf(t.Elem(), nil))
+	}
+	return nodeOf(t, tag)
+}
+
 func nodeOf(t reflect.Type, tag []string) Node {
 	switch t {
 	case reflect.TypeOf(deprecated.Int96{}):
@@ -536,6 +1103,8 @@ func nodeOf(t reflect.Type, tag []string) Node {
 		return UUID()
 	case reflect.TypeOf(time.Time{}):
 		return Timestamp(Nanosecond)
+	case reflect.TypeOf(Interval{}):
+		return Leaf(IntervalType)
 	}
 
 	var n Node
@@ -571,7 +1140,7 @@ func nodeOf(t reflect.Type, tag []string) Node {
 		if elem := t.Elem(); elem.Kind() == reflect.Uint8 { // []byte?
 			n = Leaf(ByteArrayType)
 		} else {
-			n = Repeated(nodeOf(elem, nil))
+			n = Repeated(listElementNodeOf(elem, nil))
 		}
 
 	case reflect.Array:
@@ -629,12 +1198,24 @@ func nodeOf(t reflect.Type, tag []string) Node {
 }
 
 func split(s string) (head, tail string) {
-	if i := strings.IndexByte(s, ','); i < 0 {
-		head = s
-	} else {
-		head, tail = s[:i], s[i+1:]
+	// Commas within parentheses separate the arguments of an option, for
+	// example decimal(precision=18,scale=4), and do not end the option.
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				return s[:i], s[i+1:]
+			}
+		}
 	}
-	return
+	return s, ""
 }
 
 func splitOptionArgs(s string) (option, args string) {
@@ -654,21 +1235,61 @@ func parseDecimalArgs(args string) (scale, precision int, err error) {
 	}
 	args = strings.TrimPrefix(args, "(")
 	args = strings.TrimSuffix(args, ")")
-	parts := strings.Split(args, ":")
-	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("malformed decimal args: (%s)", args)
+	var s, p int64
+	if strings.Contains(args, "=") {
+		s, p, err = parseDecimalNamedArgs(args)
+	} else {
+		parts := strings.Split(args, ":")
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("malformed decimal args: (%s)", args)
+		}
+		if s, err = strconv.ParseInt(parts[0], 10, 32); err != nil {
+			return 0, 0, err
+		}
+		p, err = strconv.ParseInt(parts[1], 10, 32)
 	}
-	s, err := strconv.ParseInt(parts[0], 10, 32)
 	if err != nil {
 		return 0, 0, err
 	}
-	p, err := strconv.ParseInt(parts[1], 10, 32)
-	if err != nil {
-		return 0, 0, err
+	if p <= 0 || s < 0 || s > p {
+		return 0, 0, fmt.Errorf("invalid decimal scale and precision: (%s)", args)
 	}
 	return int(s), int(p), nil
 }
 
+// parseDecimalNamedArgs parses decimal arguments of the form
+// precision=P,scale=S where the scale is optional and defaults to zero.
+func parseDecimalNamedArgs(args string) (scale, precision int64, err error) {
+	for _, arg := range strings.Split(args, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(arg), "=")
+		if !ok {
+			return 0, 0, fmt.Errorf("malformed decimal args: (%s)", args)
+		}
+		switch name {
+		case "precision":
+			precision, err = strconv.ParseInt(value, 10, 32)
+		case "scale":
+			scale, err = strconv.ParseInt(value, 10, 32)
+		default:
+			err = fmt.Errorf("unknown decimal argument: %s", name)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return scale, precision, nil
+}
+
+func parseGeospatialArgs(args string) (crs string, err error) {
+	if args == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return "", fmt.Errorf("malformed geospatial args: %s", args)
+	}
+	return args[1 : len(args)-1], nil
+}
+
 func parseIDArgs(args string) (int, error) {
 	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
 		return 0, fmt.Errorf("malformed id args: %s", args)
@@ -678,6 +1299,102 @@ func parseIDArgs(args string) (int, error) {
 	return strconv.Atoi(args)
 }
 
+// Bits per value of split block bloom filters needed to achieve false positive
+// rates, as documented in the parquet specification:
+// https://github.com/apache/parquet-format/blob/master/BloomFilter.md#sizing-an-sbbf
+var bloomFilterSizes = [...]struct {
+	falsePositiveRate float64
+	bitsPerValue      uint
+}{
+	{0.1, 6},
+	{0.01, 11},
+	{0.001, 17},
+	{0.0001, 27},
+	{0.00001, 41},
+}
+
+// defaultBloomFilterBitsPerValue is used for bloom filters declared without a
+// false positive rate, it yields a false positive rate of 1%.
+var defaultBloomFilterBitsPerValue = bloomFilterSizes[1].bitsPerValue
+
+func parseBloomFilterArgs(args string) (uint, error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return 0, fmt.Errorf("malformed bloom filter args: %s", args)
+	}
+	args = strings.TrimPrefix(args, "(")
+	args = strings.TrimSuffix(args, ")")
+
+	if len(args) == 0 {
+		return defaultBloomFilterBitsPerValue, nil
+	}
+
+	falsePositiveRate, err := strconv.ParseFloat(args, 64)
+	if err != nil {
+		return 0, err
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return 0, fmt.Errorf("bloom filter false positive rate must be between 0 and 1: %s", args)
+	}
+	for _, size := range bloomFilterSizes {
+		if size.falsePositiveRate <= falsePositiveRate {
+			return size.bitsPerValue, nil
+		}
+	}
+	return bloomFilterSizes[len(bloomFilterSizes)-1].bitsPerValue, nil
+}
+
+func bloomFilterBitsPerValueOf(t reflect.Type, name string, tag string) (bitsPerValue uint) {
+	forEachTagOption([]string{tag}, func(option, args string) {
+		if option != "bloom" {
+			return
+		}
+		if bitsPerValue != 0 {
+			throwInvalidNode(t, "struct field has multiple declaration of the bloom tag", name, tag)
+		}
+		var err error
+		if bitsPerValue, err = parseBloomFilterArgs(args); err != nil {
+			throwInvalidTag(t, name, option+args)
+		}
+	})
+	return bitsPerValue
+}
+
+// bloomFilterColumnsOf returns the list of bloom filters declared with the
+// "bloom" struct tag on the leaf columns of node.
+func bloomFilterColumnsOf(node Node, path columnPath) (filters []BloomFilterColumn) {
+	for _, field := range node.Fields() {
+		fieldPath := path.append(field.Name())
+		if f, ok := field.(*structField); ok && f.bloomFilterBitsPerValue != 0 {
+			filters = append(filters, SplitBlockFilter(f.bloomFilterBitsPerValue, fieldPath...))
+		}
+		if !field.Leaf() {
+			filters = append(filters, bloomFilterColumnsOf(field, fieldPath)...)
+		}
+	}
+	return filters
+}
+
+// compressionCodecOfTag returns the compression codec of the given name in
+// struct tags, or nil if the name does not match any codec.
+func compressionCodecOfTag(name string) compress.Codec {
+	switch name {
+	case "snappy":
+		return &Snappy
+	case "gzip":
+		return &Gzip
+	case "brotli":
+		return &Brotli
+	case "lz4", "lz4raw":
+		return &Lz4Raw
+	case "zstd":
+		return &Zstd
+	case "uncompressed":
+		return &Uncompressed
+	default:
+		return nil
+	}
+}
+
 func parseTimestampArgs(args string) (TimeUnit, error) {
 	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
 		return nil, fmt.Errorf("malformed timestamp args: %s", args)
@@ -703,6 +1420,38 @@ func parseTimestampArgs(args string) (TimeUnit, error) {
 	return nil, fmt.Errorf("unknown time unit: %s", args)
 }
 
+func parseTimeArgs(args string) (unit TimeUnit, isAdjustedToUTC bool, err error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return nil, false, fmt.Errorf("malformed time args: %s", args)
+	}
+
+	args = strings.TrimPrefix(args, "(")
+	args = strings.TrimSuffix(args, ")")
+	unitName, adjustment, _ := strings.Cut(args, ":")
+
+	switch unitName {
+	case "", "millis", "millisecond":
+		unit = Millisecond
+	case "micros", "microsecond":
+		unit = Microsecond
+	case "nanos", "nanosecond":
+		unit = Nanosecond
+	default:
+		return nil, false, fmt.Errorf("unknown time unit: %s", unitName)
+	}
+
+	switch adjustment {
+	case "", "utc":
+		isAdjustedToUTC = true
+	case "local":
+		isAdjustedToUTC = false
+	default:
+		return nil, false, fmt.Errorf("unknown time adjustment: %s", adjustment)
+	}
+
+	return unit, isAdjustedToUTC, nil
+}
+
 type goNode struct {
 	Node
 	gotype reflect.Type
@@ -717,6 +1466,17 @@ var (
 )
 
 func makeNodeOf(t reflect.Type, name string, tag []string) Node {
+	// Pointers to scalar values are represented by optional columns, the tags
+	// apply to the values that they point to; for example, a *int64 field with
+	// the timestamp tag is an optional timestamp column.
+	if isPointerToScalar(t) {
+		node := makeNodeOf(t.Elem(), name, tag)
+		if !node.Optional() {
+			node = Optional(node)
+		}
+		return node
+	}
+
 	var (
 		node       Node
 		optional   bool
@@ -766,39 +1526,81 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 			node = nodeOf(t, tag)
 			return
 		}
+		if codecName, level, ok := strings.Cut(option, ":"); ok {
+			if codecName == "custom" {
+				setNode(customNodeOf(dereference(t), name, level, tag))
+				return
+			}
+			codec := compressionCodecOfTag(codecName)
+			if codec == nil {
+				throwUnknownTag(t, name, option)
+			}
+			n, err := strconv.Atoi(level)
+			if err != nil {
+				throwInvalidTag(t, name, option)
+			}
+			c, err := CompressionLevel{Codec: codec, Level: n}.NewCodec()
+			if err != nil {
+				throwInvalidNode(t, err.Error(), name, tag...)
+			}
+			setCompression(c)
+			return
+		}
+
+		if codec := compressionCodecOfTag(option); codec != nil {
+			setCompression(codec)
+			return
+		}
+
 		switch option {
 		case "":
 			return
 		case "optional":
 			setOptional()
 
-		case "snappy":
-			setCompression(&Snappy)
-
-		case "gzip":
-			setCompression(&Gzip)
-
-		case "brotli":
-			setCompression(&Brotli)
-
-		case "lz4":
-			setCompression(&Lz4Raw)
-
-		case "zstd":
-			setCompression(&Zstd)
-
-		case "uncompressed":
-			setCompression(&Uncompressed)
-
 		case "plain":
 			setEncoding(&Plain)
 
 		case "dict":
 			setEncoding(&RLEDictionary)
 
+		case "rle":
+			switch dereference(t).Kind() {
+			case reflect.Bool:
+				setEncoding(&RLE)
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
 		case "json":
 			setNode(JSON())
 
+		case "bson":
+			switch {
+			case t.Kind() == reflect.String,
+				t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+				setNode(BSON())
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
+		case "geometry", "geography":
+			crs, err := parseGeospatialArgs(args)
+			if err != nil {
+				throwInvalidTag(t, name, option+args)
+			}
+			switch t := dereference(t); {
+			case t.Kind() == reflect.String,
+				t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+				if option == "geometry" {
+					setNode(Geometry(crs))
+				} else {
+					setNode(Geography(crs))
+				}
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
 		case "delta":
 			switch t.Kind() {
 			case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
@@ -821,6 +1623,25 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 				throwInvalidTag(t, name, option)
 			}
 
+		case "delta_byte_array":
+			switch {
+			case t.Kind() == reflect.String,
+				t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8, // []byte?
+				t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8: // [N]byte?
+				setEncoding(&DeltaByteArray)
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
+		case "delta_length":
+			switch {
+			case t.Kind() == reflect.String,
+				t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8: // []byte?
+				setEncoding(&DeltaLengthByteArray)
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
 		case "split":
 			switch t.Kind() {
 			case reflect.Float32, reflect.Float64:
@@ -832,7 +1653,7 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 		case "list":
 			switch t.Kind() {
 			case reflect.Slice:
-				element := nodeOf(t.Elem(), nil)
+				element := listElementNodeOf(t.Elem(), nil)
 				setNode(element)
 				setList()
 			default:
@@ -840,19 +1661,54 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 			}
 
 		case "enum":
-			switch t.Kind() {
+			switch t := dereference(t); t.Kind() {
 			case reflect.String:
 				setNode(Enum())
+			case reflect.Slice:
+				if t.Elem().Kind() != reflect.String {
+					throwInvalidTag(t, name, option)
+				}
+				setNode(&goNode{Node: Repeated(Enum()), gotype: t})
 			default:
 				throwInvalidTag(t, name, option)
 			}
 
 		case "uuid":
-			switch t.Kind() {
+			switch t := dereference(t); t.Kind() {
 			case reflect.Array:
 				if t.Elem().Kind() != reflect.Uint8 || t.Len() != 16 {
 					throwInvalidTag(t, name, option)
 				}
+				setNode(UUID())
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
+		case "interval":
+			switch t := dereference(t); t.Kind() {
+			case reflect.Struct:
+				if t != reflect.TypeOf(Interval{}) {
+					throwInvalidTag(t, name, option)
+				}
+				setNode(Leaf(IntervalType))
+			case reflect.Array:
+				if t.Elem().Kind() != reflect.Uint8 || t.Len() != intervalSize {
+					throwInvalidTag(t, name, option)
+				}
+				setNode(Leaf(IntervalType))
+			default:
+				throwInvalidTag(t, name, option)
+			}
+
+		case "float16":
+			switch t := dereference(t); t.Kind() {
+			case reflect.Uint16, reflect.Float32, reflect.Float64:
+				setNode(Float16())
+			case reflect.Array:
+				if t.Elem().Kind() != reflect.Uint8 || t.Len() != 2 {
+					throwInvalidTag(t, name, option)
+				}
+				setNode(Float16())
 			default:
 				throwInvalidTag(t, name, option)
 			}
@@ -871,13 +1727,16 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 			case reflect.Array, reflect.Slice:
 				baseType = FixedLenByteArrayType(decimalFixedLenByteArraySize(precision))
 			default:
-				throwInvalidTag(t, name, option)
+				if !isDecimalGoType(dereference(t)) {
+					throwInvalidTag(t, name, option)
+				}
+				baseType = decimalBaseType(precision)
 			}
 
 			setNode(Decimal(scale, precision, baseType))
 		case "date":
-			switch t.Kind() {
-			case reflect.Int32:
+			switch {
+			case t.Kind() == reflect.Int32, t == reflect.TypeOf(time.Time{}):
 				setNode(Date())
 			default:
 				throwInvalidTag(t, name, option)
@@ -902,6 +1761,20 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 					throwInvalidTag(t, name, option)
 				}
 			}
+		case "time":
+			switch t.Kind() {
+			case reflect.Int32, reflect.Int64:
+				timeUnit, isAdjustedToUTC, err := parseTimeArgs(args)
+				if err != nil {
+					throwInvalidTag(t, name, option+args)
+				}
+				if (t.Kind() == reflect.Int32) != (timeUnit == Millisecond) && t != reflect.TypeOf(time.Duration(0)) {
+					throwInvalidTag(t, name, option+args)
+				}
+				setNode(TimeAdjusted(timeUnit, isAdjustedToUTC))
+			default:
+				throwInvalidTag(t, name, option)
+			}
 		case "id":
 			id, err := parseIDArgs(args)
 			if err != nil {
@@ -921,7 +1794,7 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 		// Note for strings "optional" applies only to the entire BYTE_ARRAY and
 		// not each individual byte.
 		if optional && !isUint8 {
-			node = Repeated(Optional(nodeOf(t.Elem(), tag)))
+			node = Repeated(Optional(listElementNodeOf(t.Elem(), tag)))
 			// Don't also apply "optional" to the whole list.
 			optional = false
 		}
@@ -943,13 +1816,6 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 		node = List(node)
 	}
 
-	if node.Repeated() && !list {
-		elemKind := node.GoType().Elem().Kind()
-		if elemKind == reflect.Slice {
-			panic("unhandled nested slice on parquet schema without list tag")
-		}
-	}
-
 	if optional {
 		node = Optional(node)
 	}
@@ -959,6 +1825,29 @@ func makeNodeOf(t reflect.Type, name string, tag []string) Node {
 	return node
 }
 
+// isPointerToScalar returns true if t is a pointer to a Go type represented by
+// a single leaf column, other than the decimal types which are usually handled
+// by pointers (e.g. *big.Rat) and are nullable only with the optional tag.
+func isPointerToScalar(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr {
+		return false
+	}
+	switch elem := t.Elem(); elem.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return !isDecimalGoType(elem)
+	case reflect.Slice, reflect.Array:
+		return elem.Elem().Kind() == reflect.Uint8 && !isDecimalGoType(elem)
+	case reflect.Struct:
+		return elem == reflect.TypeOf(time.Time{})
+	default:
+		return false
+	}
+}
+
 func forEachTagOption(tags []string, do func(option, args string)) {
 	for _, tag := range tags {
 		_, tag = split(tag) // skip the field name
@@ -1,6 +1,10 @@
 package parquet_test
 
 import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
@@ -202,6 +206,30 @@ func TestSchemaOf(t *testing.T) {
 	}
 	repeated binary d (STRING) = 4;
 	optional binary e (STRING) = 5;
+}`,
+		},
+		{
+			value: new(struct {
+				A [16]byte  `parquet:"a,uuid"`
+				B *[16]byte `parquet:"b,optional,uuid"`
+				C [16]byte  `parquet:"c"`
+			}),
+			print: `message {
+	required fixed_len_byte_array(16) a (UUID);
+	optional fixed_len_byte_array(16) b (UUID);
+	required fixed_len_byte_array(16) c;
+}`,
+		},
+		{
+			value: new(struct {
+				A string   `parquet:"a,enum"`
+				B *string  `parquet:"b,optional,enum"`
+				C []string `parquet:"c,enum"`
+			}),
+			print: `message {
+	required binary a (ENUM);
+	optional binary b (ENUM);
+	repeated binary c (ENUM);
 }`,
 		},
 	}
@@ -216,3 +244,472 @@ func TestSchemaOf(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaCompatible(t *testing.T) {
+	base := parquet.NewSchema("base", parquet.Group{
+		"id":   parquet.Int(64),
+		"name": parquet.Optional(parquet.String()),
+		"tags": parquet.Repeated(parquet.String()),
+		"address": parquet.Group{
+			"city": parquet.String(),
+			"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+		},
+	})
+
+	tests := []struct {
+		scenario string
+		other    parquet.Node
+		column   string
+	}{
+		{
+			scenario: "equal with different root name",
+			other: parquet.Group{
+				"id":   parquet.Int(64),
+				"name": parquet.Optional(parquet.String()),
+				"tags": parquet.Repeated(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+					"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+				},
+			},
+		},
+		{
+			scenario: "physical type",
+			other: parquet.Group{
+				"id":   parquet.Int(32),
+				"name": parquet.Optional(parquet.String()),
+				"tags": parquet.Repeated(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+					"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+				},
+			},
+			column: "id",
+		},
+		{
+			scenario: "logical type",
+			other: parquet.Group{
+				"id":   parquet.Int(64),
+				"name": parquet.Optional(parquet.Leaf(parquet.ByteArrayType)),
+				"tags": parquet.Repeated(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+					"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+				},
+			},
+			column: "name",
+		},
+		{
+			scenario: "repetition",
+			other: parquet.Group{
+				"id":   parquet.Int(64),
+				"name": parquet.Optional(parquet.String()),
+				"tags": parquet.Optional(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+					"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+				},
+			},
+			column: "tags",
+		},
+		{
+			scenario: "fixed length",
+			other: parquet.Group{
+				"id":   parquet.Int(64),
+				"name": parquet.Optional(parquet.String()),
+				"tags": parquet.Repeated(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+					"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(9)),
+				},
+			},
+			column: "address.zip",
+		},
+		{
+			scenario: "missing column",
+			other: parquet.Group{
+				"id":   parquet.Int(64),
+				"name": parquet.Optional(parquet.String()),
+				"tags": parquet.Repeated(parquet.String()),
+				"address": parquet.Group{
+					"city": parquet.String(),
+				},
+			},
+			column: "address.zip",
+		},
+		{
+			scenario: "leaf and group",
+			other: parquet.Group{
+				"id":      parquet.Int(64),
+				"name":    parquet.Optional(parquet.String()),
+				"tags":    parquet.Repeated(parquet.String()),
+				"address": parquet.String(),
+			},
+			column: "address",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			err := base.Compatible(parquet.NewSchema("other", test.other))
+			if test.column == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, parquet.ErrSchemaMismatch) {
+				t.Fatalf("expected a schema mismatch error but got %v", err)
+			}
+			if !strings.Contains(err.Error(), "column "+test.column+" ") {
+				t.Errorf("error does not name column %s: %v", test.column, err)
+			}
+		})
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	base := parquet.NewSchema("base", parquet.Group{
+		"id":   parquet.Int(64),
+		"name": parquet.Optional(parquet.String()),
+		"address": parquet.Group{
+			"city": parquet.String(),
+			"zip":  parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+		},
+	})
+
+	if diff := base.Diff(parquet.NewSchema("other", base)); diff != "" {
+		t.Errorf("expected no differences between equal schemas:\n%s", diff)
+	}
+
+	other := parquet.NewSchema("other", parquet.Group{
+		"id":   parquet.Int(32),
+		"name": parquet.Optional(parquet.String()),
+		"tags": parquet.Repeated(parquet.String()),
+		"address": parquet.Group{
+			"city":    parquet.Optional(parquet.String()),
+			"country": parquet.String(),
+		},
+	})
+
+	want := `- address.city: required BYTE_ARRAY (STRING)
++ address.city: optional BYTE_ARRAY (STRING)
++ address.country: required BYTE_ARRAY (STRING)
+- address.zip: required FIXED_LEN_BYTE_ARRAY(5)
+- id: required INT64 (INT(64,true))
++ id: required INT32 (INT(32,true))
++ tags: repeated BYTE_ARRAY (STRING)
+`
+	if diff := base.Diff(other); diff != want {
+		t.Errorf("wrong schema diff:\nwant:\n%s\ngot:\n%s", want, diff)
+	}
+}
+
+func TestSchemaEstimatedRowSize(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"id":    parquet.Int(64),
+		"flag":  parquet.Leaf(parquet.BooleanType),
+		"score": parquet.Optional(parquet.Leaf(parquet.FloatType)),
+		"uuid":  parquet.UUID(),
+		"name":  parquet.String(),
+		"tags":  parquet.Repeated(parquet.Leaf(parquet.ByteArrayType)),
+		"address": parquet.Group{
+			"zip": parquet.Leaf(parquet.FixedLenByteArrayType(5)),
+		},
+	})
+
+	// id + flag + score + uuid + zip, then the two byte array columns.
+	const fixedSize = 8 + 1 + 4 + 16 + 5
+
+	if size := schema.EstimatedRowSize(); size != fixedSize+2*20 {
+		t.Errorf("wrong estimated row size: want=%d got=%d", fixedSize+2*20, size)
+	}
+	if size := schema.EstimateRowSize(100); size != fixedSize+2*100 {
+		t.Errorf("wrong estimated row size: want=%d got=%d", fixedSize+2*100, size)
+	}
+}
+
+func TestSchemaColumns(t *testing.T) {
+	type Point struct {
+		X float64 `parquet:"x"`
+		Y float64 `parquet:"y"`
+	}
+	type Row struct {
+		ID     int64            `parquet:"id"`
+		Name   string           `parquet:"name,optional"`
+		Tags   []string         `parquet:"tags,list"`
+		Points []Point          `parquet:"points"`
+		Attrs  map[string]int64 `parquet:"attrs"`
+		Origin struct {
+			Lat float64 `parquet:"lat"`
+			Lon float64 `parquet:"lon"`
+		} `parquet:"origin"`
+	}
+
+	schema := parquet.SchemaOf(new(Row))
+	want := [][]string{
+		{"id"},
+		{"name"},
+		{"tags", "list", "element"},
+		{"points", "x"},
+		{"points", "y"},
+		{"attrs", "key_value", "key"},
+		{"attrs", "key_value", "value"},
+		{"origin", "lat"},
+		{"origin", "lon"},
+	}
+
+	columns := schema.Columns()
+	if !reflect.DeepEqual(columns, want) {
+		t.Fatalf("wrong columns:\nwant: %q\ngot:  %q", want, columns)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, []Row{{ID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := f.RowGroups()[0].ColumnChunks()
+	if len(chunks) != len(columns) {
+		t.Fatalf("wrong number of column chunks: want=%d got=%d", len(columns), len(chunks))
+	}
+	for i, path := range columns {
+		leaf, ok := schema.Lookup(path...)
+		if !ok {
+			t.Fatalf("column %q not found", path)
+		}
+		if leaf.ColumnIndex != i {
+			t.Errorf("column %q: wrong column index: want=%d got=%d", path, i, leaf.ColumnIndex)
+		}
+		if chunks[i].Column() != i {
+			t.Errorf("column %q: wrong column chunk index: want=%d got=%d", path, i, chunks[i].Column())
+		}
+		if got := f.Metadata().RowGroups[0].Columns[i].MetaData.PathInSchema; !reflect.DeepEqual(got, path) {
+			t.Errorf("column %d: wrong path in the file: want=%q got=%q", i, path, got)
+		}
+	}
+}
+
+func TestUnionSchemas(t *testing.T) {
+	type User struct {
+		ID    int64    `parquet:"id"`
+		Name  string   `parquet:"name"`
+		Tags  []string `parquet:"tags"`
+		Extra struct {
+			Age int32 `parquet:"age"`
+		} `parquet:"extra"`
+	}
+	type Event struct {
+		ID    int64   `parquet:"id"`
+		Kind  *string `parquet:"kind,optional"`
+		Extra struct {
+			Source string `parquet:"source"`
+		} `parquet:"extra"`
+	}
+
+	userSchema := parquet.SchemaOf(User{})
+	eventSchema := parquet.SchemaOf(Event{})
+	union, err := parquet.UnionSchemas(userSchema, eventSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `message User {
+	optional group extra {
+		optional int32 age (INT(32,true));
+		optional binary source (STRING);
+	}
+	optional int64 id (INT(64,true));
+	optional binary kind (STRING);
+	optional binary name (STRING);
+	repeated binary tags (STRING);
+}`
+	if got := union.String(); got != want {
+		t.Errorf("wrong union schema:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+
+	kind := "click"
+	user := User{ID: 1, Name: "Luke", Tags: []string{"jedi"}}
+	user.Extra.Age = 19
+	event := Event{ID: 2, Kind: &kind}
+	event.Extra.Source = "web"
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewWriter(buf, union)
+	for _, record := range []struct {
+		schema *parquet.Schema
+		value  interface{}
+	}{
+		{userSchema, user},
+		{eventSchema, event},
+	} {
+		conv, err := parquet.Convert(union, record.schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := []parquet.Row{record.schema.Deconstruct(nil, record.value)}
+		if _, err := conv.Convert(rows); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.WriteRows(rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	type Extra struct {
+		Age    *int32  `parquet:"age,optional"`
+		Source *string `parquet:"source,optional"`
+	}
+	type Union struct {
+		Extra *Extra   `parquet:"extra,optional"`
+		ID    *int64   `parquet:"id,optional"`
+		Kind  *string  `parquet:"kind,optional"`
+		Name  *string  `parquet:"name,optional"`
+		Tags  []string `parquet:"tags"`
+	}
+	rows, err := parquet.Read[Union](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id1, id2, age, name, source := int64(1), int64(2), int32(19), "Luke", "web"
+	assertRowsEqual(t, []Union{
+		{Extra: &Extra{Age: &age}, ID: &id1, Name: &name, Tags: []string{"jedi"}},
+		{Extra: &Extra{Source: &source}, ID: &id2, Kind: &kind, Tags: []string{}},
+	}, rows)
+
+	t.Run("conflict", func(t *testing.T) {
+		type Other struct {
+			ID string `parquet:"id"`
+		}
+		_, err := parquet.UnionSchemas(userSchema, parquet.SchemaOf(Other{}))
+		if !errors.Is(err, parquet.ErrSchemaMismatch) {
+			t.Errorf("expected a schema mismatch error, got %v", err)
+		} else if !strings.Contains(err.Error(), "id") {
+			t.Errorf("the error does not name the conflicting column: %v", err)
+		}
+	})
+}
+
+func TestSchemaOfDuplicateColumns(t *testing.T) {
+	type Address struct {
+		City string `parquet:"city"`
+		Zip  string `parquet:"city"`
+	}
+	type Row struct {
+		ID      int64   `parquet:"id"`
+		Key     string  `parquet:"id"`
+		Address Address `parquet:"address"`
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, parquet.ErrDuplicateColumn) {
+			t.Fatalf("expected a duplicate column error, got %v", err)
+		}
+		if msg := err.Error(); !strings.Contains(msg, "id, address.city") {
+			t.Errorf("the error does not list the duplicate columns: %s", msg)
+		}
+	}()
+	parquet.SchemaOf(Row{})
+}
+
+func TestSchemaOfAny(t *testing.T) {
+	type obj = map[string]any
+	type arr = []any
+
+	sample := obj{
+		"id":     int64(1),
+		"name":   "Luke",
+		"score":  0.5,
+		"active": true,
+		"tags":   arr{"pilot", "jedi"},
+		"email":  (*string)(nil),
+		"address": obj{
+			"city": "Anchorhead",
+			"zip":  int32(12345),
+		},
+		"ships": arr{
+			obj{"name": "X-wing", "crew": int64(1)},
+			obj{"name": "Falcon", "crew": int64(6)},
+		},
+	}
+
+	schema, err := parquet.SchemaOfAny(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `message {
+	required boolean active;
+	required group address {
+		required binary city (STRING);
+		required int32 zip (INT(32,true));
+	}
+	optional binary email (STRING);
+	required int64 id (INT(64,true));
+	required binary name (STRING);
+	required double score;
+	repeated group ships {
+		required int64 crew (INT(64,true));
+		required binary name (STRING);
+	}
+	repeated binary tags (STRING);
+}`
+	if got := schema.String(); got != want {
+		t.Errorf("wrong schema:\nwant: %s\ngot:  %s", want, got)
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[any](buf, schema)
+	if _, err := w.Write([]any{sample}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := parquet.Read[any](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := rows[0].(obj)
+	if got["name"] != "Luke" || got["email"] != nil || !reflect.DeepEqual(got["tags"], arr{"pilot", "jedi"}) {
+		t.Errorf("wrong row read back: %+v", got)
+	}
+
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+	if schema, err := parquet.SchemaOfAny(&Row{}); err != nil || schema != parquet.SchemaOf(Row{}) {
+		t.Errorf("wrong schema of struct value: %v (%v)", schema, err)
+	}
+
+	for _, test := range []struct {
+		scenario string
+		sample   any
+		err      string
+	}{
+		{"nil", nil, "cannot infer parquet schema"},
+		{"scalar", int64(1), "cannot infer parquet schema"},
+		{"empty map", obj{}, "empty map"},
+		{"null value", obj{"a": obj{"b": nil}}, "a.b: cannot infer the parquet type of a null value"},
+		{"empty slice", obj{"a": arr{}}, "a: cannot infer the parquet type of the elements of an empty slice"},
+		{"mixed slice", obj{"a": arr{int64(1), "2"}}, "a: slice elements have different parquet types"},
+		{"nested slices", obj{"a": arr{arr{int64(1)}}}, "a: cannot represent slices of slices"},
+		{"unsupported type", obj{"a": make(chan int)}, "cannot create parquet node"},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.SchemaOfAny(test.sample)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("wrong error: want %q, got %v", test.err, err)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package parquet
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Search is like Find, but uses the default ordering of the given type. Search
 // and Find are scoped to a given ColumnChunk and find the pages within a
 // ColumnChunk which might contain the result.  See Find for more details.
@@ -17,8 +22,9 @@ func Search(index ColumnIndex, value Value, typ Type) int {
 //
 // If you want to search the entire parquet file, you must iterate over the
 // RowGroups and search each one individually, if there are multiple in the
-// file. If you call writer.Flush before closing the file, then you will have
-// multiple RowGroups to iterate over, otherwise Flush is called once on Close.
+// file, or use the File.Search method. If you call writer.Flush before closing
+// the file, then you will have multiple RowGroups to iterate over, otherwise
+// Flush is called once on Close.
 //
 // The comparison function passed as last argument is used to determine the
 // relative order of values. This should generally be the Compare method of
@@ -114,3 +120,118 @@ func linearSearch(index ColumnIndex, value Value, cmp func(Value, Value) int) in
 
 	return n
 }
+
+// RowRange represents the range of rows [Start, End) of a parquet file.
+type RowRange struct {
+	Start int64
+	End   int64
+}
+
+// Search returns the ranges of rows of f which may contain the value in the
+// given column, which is expressed as a dot-separated path.
+//
+// Row groups are first filtered using the min and max statistics of their
+// column chunks, then the pages which may hold the value are located using
+// the column and offset indexes. When the first sorting column of a row group
+// is the searched column in ascending order, the first page is found by binary
+// search (see Search); pages of other columns are scanned linearly.
+//
+// Row groups without statistics or page index cannot be filtered, the method
+// returns ranges spanning all their rows. Rows of the returned ranges must
+// still be compared to the value since the ranges only indicate that the rows
+// may match. Adjacent ranges are merged, and the ranges are sorted by row
+// index.
+//
+// The method returns an error if the column does not exist in the schema of
+// f, or if the value is null or of a different kind than the column.
+func (f *File) Search(column string, value Value) ([]RowRange, error) {
+	path := columnPath(strings.Split(column, "."))
+	leaf, ok := f.schema.Lookup(path...)
+	if !ok {
+		return nil, fmt.Errorf("cannot search parquet schema %s: column %q does not exist", f.schema.Name(), column)
+	}
+	typ := leaf.Node.Type()
+	if value.IsNull() {
+		return nil, fmt.Errorf("cannot search null values in column %q", column)
+	}
+	if value.Kind() != typ.Kind() {
+		return nil, fmt.Errorf("cannot search %s value in column %q of type %s", value.Kind(), column, typ)
+	}
+
+	ranges := []RowRange{}
+	appendRange := func(start, end int64) {
+		if n := len(ranges); n > 0 && ranges[n-1].End == start {
+			ranges[n-1].End = end
+		} else {
+			ranges = append(ranges, RowRange{Start: start, End: end})
+		}
+	}
+
+	predicate := Eq(value)
+	offset := int64(0)
+
+	for _, rowGroup := range f.rowGroups {
+		numRows := rowGroup.NumRows()
+		chunk := rowGroup.ColumnChunks()[leaf.ColumnIndex].(*fileColumnChunk)
+
+		if chunk.mayMatch(typ, predicate) {
+			sorted := isSortedBy(rowGroup.SortingColumns(), path)
+			for _, r := range searchPageRanges(chunk, typ, value, numRows, sorted) {
+				appendRange(offset+r.start, offset+r.end)
+			}
+		}
+
+		offset += numRows
+	}
+
+	return ranges, nil
+}
+
+// isSortedBy returns true if rows sorted by the list of sorting columns are in
+// the ascending order of the column at path, with null values last.
+func isSortedBy(sortingColumns []SortingColumn, path columnPath) bool {
+	if len(sortingColumns) == 0 {
+		return false
+	}
+	s := sortingColumns[0]
+	return path.equal(s.Path()) && !s.Descending() && !s.NullsFirst()
+}
+
+func searchPageRanges(chunk ColumnChunk, typ Type, value Value, numRows int64, sorted bool) []rowRange {
+	columnIndex, offsetIndex := chunk.ColumnIndex(), chunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return []rowRange{{start: 0, end: numRows}}
+	}
+	// The boundary order of the column index only describes the order of the
+	// min and max values, the values of pages may still overlap when the rows
+	// are not sorted by the column.
+	if !sorted || !columnIndex.IsAscending() {
+		return matchingPageRanges(chunk, typ, Eq(value), numRows)
+	}
+
+	ranges := []rowRange{}
+	numPages := offsetIndex.NumPages()
+	predicate := Eq(value)
+
+	for i := Search(columnIndex, value, typ); i < numPages; i++ {
+		if !pageMayMatch(columnIndex, i, typ, predicate) {
+			// The pages are sorted, no page after one starting with a greater
+			// value can hold the value.
+			if !columnIndex.NullPage(i) && !columnIndex.MinValue(i).IsNull() && typ.Compare(columnIndex.MinValue(i), value) > 0 {
+				break
+			}
+			continue
+		}
+		start, end := offsetIndex.FirstRowIndex(i), numRows
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].end == start {
+			ranges[n-1].end = end
+		} else {
+			ranges = append(ranges, rowRange{start: start, end: end})
+		}
+	}
+
+	return ranges
+}
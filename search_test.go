@@ -1,6 +1,8 @@
 package parquet_test
 
 import (
+	"bytes"
+	"reflect"
 	"testing"
 
 	"github.com/parquet-go/parquet-go"
@@ -86,3 +88,121 @@ func testSearch(t *testing.T, pages [][]int32, expectIndex [][]int) {
 		}
 	}
 }
+
+func TestFileSearch(t *testing.T) {
+	type Row struct {
+		ID    int64 `parquet:"id"`
+		Value int64 `parquet:"value"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Value: int64((i * 7919) % 1000)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.MaxRowsPerRowGroup(300),
+		parquet.PageBufferSize(256),
+		parquet.SortingWriterConfig(
+			parquet.SortingColumns(parquet.Ascending("id")),
+		),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	contains := func(ranges []parquet.RowRange, rowIndex int64) bool {
+		for _, r := range ranges {
+			if r.Start <= rowIndex && rowIndex < r.End {
+				return true
+			}
+		}
+		return false
+	}
+	count := func(ranges []parquet.RowRange) (n int64) {
+		for _, r := range ranges {
+			n += r.End - r.Start
+		}
+		return n
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []int64{0, 299, 300, 450, 999} {
+			ranges, err := f.Search("id", parquet.ValueOf(id))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !contains(ranges, id) {
+				t.Errorf("row %d is not in the ranges %v", id, ranges)
+			}
+			if n := count(ranges); n <= 0 || n >= 300 {
+				t.Errorf("searching for %d returned %d rows in the ranges %v", id, n, ranges)
+			}
+		}
+		ranges, err := f.Search("id", parquet.ValueOf(int64(1000)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranges) != 0 {
+			t.Errorf("unexpected ranges for a missing value: %v", ranges)
+		}
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, row := range rows[:10] {
+			ranges, err := f.Search("value", parquet.ValueOf(row.Value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !contains(ranges, int64(i)) {
+				t.Errorf("row %d is not in the ranges %v", i, ranges)
+			}
+		}
+	})
+
+	t.Run("without page index", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()), parquet.SkipPageIndex(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ranges, err := f.Search("id", parquet.ValueOf(int64(450)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []parquet.RowRange{{Start: 300, End: 600}}
+		if !reflect.DeepEqual(ranges, want) {
+			t.Errorf("wrong ranges: want=%v got=%v", want, ranges)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range []struct {
+			column string
+			value  parquet.Value
+		}{
+			{"missing", parquet.ValueOf(int64(1))},
+			{"id", parquet.ValueOf("1")},
+			{"id", parquet.NullValue()},
+		} {
+			if _, err := f.Search(test.column, test.value); err == nil {
+				t.Errorf("expected an error searching %v in column %q", test.value, test.column)
+			}
+		}
+	})
+}
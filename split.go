@@ -0,0 +1,137 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+)
+
+// SplitFile writes the rows of src to multiple parquet files holding at most
+// rowsPerShard rows each, for example to process the content of a large file
+// in parallel.
+//
+// The open function is called with the index of each shard, starting at zero,
+// and returns the writer that the shard is written to. When the writer also
+// implements io.Closer, it is closed after the shard was written. The number
+// of shards is the number of rows of src divided by rowsPerShard, rounded up;
+// no shards are written when src has no rows.
+//
+// Each shard is a complete parquet file with the schema and key/value metadata
+// of src. The row groups of src are split when a shard boundary falls in the
+// middle of a row group, the other row group boundaries are preserved. The
+// options configure the writers used to produce the shards.
+func SplitFile(src *File, rowsPerShard int64, open func(shard int) (io.Writer, error), options ...WriterOption) error {
+	if rowsPerShard <= 0 {
+		return fmt.Errorf("cannot split parquet file into shards of %d rows", rowsPerShard)
+	}
+
+	writerOptions := make([]WriterOption, 0, len(src.metadata.KeyValueMetadata)+len(options)+1)
+	writerOptions = append(writerOptions, src.Schema())
+	for _, kv := range src.metadata.KeyValueMetadata {
+		writerOptions = append(writerOptions, KeyValueMetadata(kv.Key, kv.Value))
+	}
+	writerOptions = append(writerOptions, options...)
+
+	s := fileSplitter{
+		open:          open,
+		rowsPerShard:  rowsPerShard,
+		writerOptions: writerOptions,
+		buffer:        make([]Row, defaultRowBufferSize),
+	}
+	defer clearRows(s.buffer)
+
+	for _, rowGroup := range src.RowGroups() {
+		if err := s.writeRowGroup(rowGroup); err != nil {
+			return err
+		}
+	}
+	return s.closeShard()
+}
+
+type fileSplitter struct {
+	open          func(int) (io.Writer, error)
+	rowsPerShard  int64
+	writerOptions []WriterOption
+	buffer        []Row
+
+	shard   int
+	output  io.Writer
+	writer  *Writer
+	numRows int64
+}
+
+func (s *fileSplitter) writeRowGroup(rowGroup RowGroup) error {
+	rows := rowGroup.Rows()
+	defer rows.Close()
+
+	for {
+		buffer := s.buffer
+		if remain := s.rowsPerShard - s.numRows; remain < int64(len(buffer)) {
+			buffer = buffer[:remain]
+		}
+
+		n, err := rows.ReadRows(buffer)
+		if n > 0 {
+			// Shards are opened when the first rows are read so no empty
+			// shard is written after the last rows of the source file.
+			if s.writer == nil {
+				if err := s.openShard(); err != nil {
+					return err
+				}
+			}
+			if _, err := s.writer.WriteRows(buffer[:n]); err != nil {
+				return fmt.Errorf("writing rows of shard %d: %w", s.shard, err)
+			}
+			s.numRows += int64(n)
+			if s.numRows == s.rowsPerShard {
+				if err := s.closeShard(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+
+	// Preserve the row group boundaries of the source file in the shard.
+	if s.writer != nil {
+		if err := s.writer.Flush(); err != nil {
+			return fmt.Errorf("writing rows of shard %d: %w", s.shard, err)
+		}
+	}
+	return nil
+}
+
+func (s *fileSplitter) openShard() error {
+	output, err := s.open(s.shard)
+	if err != nil {
+		return fmt.Errorf("opening shard %d: %w", s.shard, err)
+	}
+	s.output = output
+	s.writer = NewWriter(output, s.writerOptions...)
+	return nil
+}
+
+func (s *fileSplitter) closeShard() error {
+	if s.writer == nil {
+		return nil
+	}
+	err := s.writer.Close()
+	if closer, ok := s.output.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("closing shard %d: %w", s.shard, err)
+	}
+	s.shard++
+	s.output = nil
+	s.writer = nil
+	s.numRows = 0
+	return nil
+}
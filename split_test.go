@@ -0,0 +1,134 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type splitRow struct {
+	ID   int64  `parquet:"id"`
+	Name string `parquet:"name,dict"`
+}
+
+func TestSplitFile(t *testing.T) {
+	rows := make([]splitRow, 1000)
+	for i := range rows {
+		rows[i] = splitRow{ID: int64(i), Name: fmt.Sprintf("name-%d", i%10)}
+	}
+
+	src := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[splitRow](src,
+		parquet.MaxRowsPerRowGroup(300),
+		parquet.KeyValueMetadata("hello", "world"),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	srcFile := openFile(t, src.Bytes())
+
+	for _, test := range []struct {
+		rowsPerShard int64
+		rowGroups    [][]int64
+	}{
+		{rowsPerShard: 1000, rowGroups: [][]int64{{300, 300, 300, 100}}},
+		{rowsPerShard: 2000, rowGroups: [][]int64{{300, 300, 300, 100}}},
+		{rowsPerShard: 300, rowGroups: [][]int64{{300}, {300}, {300}, {100}}},
+		{rowsPerShard: 400, rowGroups: [][]int64{{300, 100}, {200, 200}, {100, 100}}},
+		{rowsPerShard: 500, rowGroups: [][]int64{{300, 200}, {100, 300, 100}}},
+	} {
+		t.Run(fmt.Sprint(test.rowsPerShard), func(t *testing.T) {
+			var shards []*bytes.Buffer
+			err := parquet.SplitFile(srcFile, test.rowsPerShard, func(shard int) (io.Writer, error) {
+				if shard != len(shards) {
+					t.Errorf("wrong shard index: want=%d got=%d", len(shards), shard)
+				}
+				shards = append(shards, new(bytes.Buffer))
+				return shards[shard], nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(shards) != len(test.rowGroups) {
+				t.Fatalf("wrong number of shards: want=%d got=%d", len(test.rowGroups), len(shards))
+			}
+
+			var got []splitRow
+			for i, shard := range shards {
+				f := openFile(t, shard.Bytes())
+				if f.Schema().String() != srcFile.Schema().String() {
+					t.Errorf("shard %d: the schema was not preserved:\n%s", i, f.Schema())
+				}
+				if value, ok := f.Lookup("hello"); !ok || value != "world" {
+					t.Errorf("shard %d: the key/value metadata was not preserved: %q", i, value)
+				}
+				var rowGroups []int64
+				for _, rowGroup := range f.RowGroups() {
+					rowGroups = append(rowGroups, rowGroup.NumRows())
+				}
+				if fmt.Sprint(rowGroups) != fmt.Sprint(test.rowGroups[i]) {
+					t.Errorf("shard %d: wrong row groups: want=%v got=%v", i, test.rowGroups[i], rowGroups)
+				}
+				shardRows, err := parquet.Read[splitRow](bytes.NewReader(shard.Bytes()), int64(shard.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, shardRows...)
+			}
+			assertRowsEqual(t, rows, got)
+		})
+	}
+
+	t.Run("close", func(t *testing.T) {
+		var closed int
+		err := parquet.SplitFile(srcFile, 600, func(shard int) (io.Writer, error) {
+			return &closeCounter{counter: &closed}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if closed != 2 {
+			t.Errorf("wrong number of closed shards: want=2 got=%d", closed)
+		}
+	})
+
+	t.Run("open error", func(t *testing.T) {
+		errOpen := errors.New("cannot open shard")
+		err := parquet.SplitFile(srcFile, 600, func(shard int) (io.Writer, error) {
+			if shard == 1 {
+				return nil, errOpen
+			}
+			return new(bytes.Buffer), nil
+		})
+		if !errors.Is(err, errOpen) {
+			t.Errorf("expected the error of the open function, got %v", err)
+		}
+	})
+
+	t.Run("invalid shard size", func(t *testing.T) {
+		err := parquet.SplitFile(srcFile, 0, func(int) (io.Writer, error) {
+			t.Error("no shard should be opened")
+			return new(bytes.Buffer), nil
+		})
+		if err == nil {
+			t.Error("expected an error splitting the file into shards of zero rows")
+		}
+	})
+}
+
+type closeCounter struct {
+	bytes.Buffer
+	counter *int
+}
+
+func (c *closeCounter) Close() error {
+	*c.counter++
+	return nil
+}
@@ -0,0 +1,423 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/deprecated"
+)
+
+func TestTimestampRoundTrip(t *testing.T) {
+	type timestampRow struct {
+		Time time.Time `parquet:"time"`
+	}
+
+	zone := time.FixedZone("UTC-5", -5*3600)
+	times := []time.Time{
+		time.Date(2023, time.March, 12, 1, 59, 59, 123456789, zone),
+		time.Date(1969, time.December, 31, 23, 0, 0, 999999999, time.UTC),
+		time.Date(2100, time.January, 1, 0, 0, 0, 1, zone),
+	}
+
+	units := []struct {
+		name string
+		unit parquet.TimeUnit
+		prec time.Duration
+	}{
+		{"millisecond", parquet.Millisecond, time.Millisecond},
+		{"microsecond", parquet.Microsecond, time.Microsecond},
+		{"nanosecond", parquet.Nanosecond, time.Nanosecond},
+	}
+
+	for _, unit := range units {
+		for _, adjusted := range []bool{true, false} {
+			name := unit.name + "/utc"
+			if !adjusted {
+				name = unit.name + "/local"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				schema := parquet.NewSchema("timestamps", parquet.Group{
+					"time": parquet.TimestampAdjusted(unit.unit, adjusted),
+				})
+
+				rows := make([]timestampRow, len(times))
+				for i, v := range times {
+					rows[i].Time = v
+				}
+
+				buf := new(bytes.Buffer)
+				w := parquet.NewGenericWriter[timestampRow](buf, schema)
+				if _, err := w.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				leaf, _ := f.Schema().Lookup("time")
+				lt := leaf.Node.Type().LogicalType()
+				if lt == nil || lt.Timestamp == nil {
+					t.Fatalf("column is not a timestamp: %v", leaf.Node.Type())
+				}
+				if lt.Timestamp.IsAdjustedToUTC != adjusted {
+					t.Errorf("wrong isAdjustedToUTC: want=%t got=%t", adjusted, lt.Timestamp.IsAdjustedToUTC)
+				}
+				if want := unit.unit.TimeUnit(); lt.Timestamp.Unit != want {
+					t.Errorf("wrong unit: want=%v got=%v", &want, &lt.Timestamp.Unit)
+				}
+
+				got, err := parquet.Read[timestampRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for i, v := range times {
+					v, g := v.Truncate(unit.prec), got[i].Time
+					if g.Location() != time.UTC {
+						t.Errorf("row %d: wrong location: want=UTC got=%v", i, g.Location())
+					}
+					if adjusted {
+						if !g.Equal(v) {
+							t.Errorf("row %d: wrong time: want=%v got=%v", i, v, g)
+						}
+					} else {
+						want := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), time.UTC)
+						if !g.Equal(want) {
+							t.Errorf("row %d: wrong wall clock time: want=%v got=%v", i, want, g)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestInt96Timestamp(t *testing.T) {
+	// makeInt96 returns the INT96 representation of a timestamp, made of the
+	// nanoseconds within the day followed by the Julian day number.
+	makeInt96 := func(julianDay uint32, nanos uint64) deprecated.Int96 {
+		return deprecated.Int96{uint32(nanos), uint32(nanos >> 32), julianDay}
+	}
+
+	const unixEpochJulianDay = 2440588
+	const lastNanosOfDay = 24*3600*1e9 - 1
+
+	tests := []struct {
+		value deprecated.Int96
+		time  time.Time
+	}{
+		{makeInt96(unixEpochJulianDay, 0), time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{makeInt96(unixEpochJulianDay, 1), time.Date(1970, time.January, 1, 0, 0, 0, 1, time.UTC)},
+		{makeInt96(unixEpochJulianDay-1, lastNanosOfDay), time.Date(1969, time.December, 31, 23, 59, 59, 999999999, time.UTC)},
+		{makeInt96(unixEpochJulianDay+1, lastNanosOfDay), time.Date(1970, time.January, 2, 23, 59, 59, 999999999, time.UTC)},
+		{makeInt96(2451545, 12*3600*1e9), time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)},
+		{makeInt96(2299161, 0), time.Date(1582, time.October, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	type legacyRow struct {
+		Time deprecated.Int96 `parquet:"time"`
+	}
+	legacyRows := make([]legacyRow, len(tests))
+	for i, test := range tests {
+		legacyRows[i].Time = test.value
+	}
+	buf := new(bytes.Buffer)
+	if err := parquet.Write(buf, legacyRows); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("nanosecond", func(t *testing.T) {
+		type timeRow struct {
+			Time time.Time `parquet:"time"`
+		}
+		rows, err := parquet.Read[timeRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, test := range tests {
+			if test.time.Year() < 1678 {
+				continue // not representable as nanoseconds since the unix epoch
+			}
+			if !rows[i].Time.Equal(test.time) {
+				t.Errorf("%v: wrong time: want=%v got=%v", test.value, test.time, rows[i].Time)
+			}
+		}
+	})
+
+	t.Run("millisecond", func(t *testing.T) {
+		type timeRow struct {
+			Time time.Time `parquet:"time,timestamp(millisecond)"`
+		}
+		rows, err := parquet.Read[timeRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, test := range tests {
+			if want := test.time.Truncate(time.Millisecond); !rows[i].Time.Equal(want) {
+				t.Errorf("%v: wrong time: want=%v got=%v", test.value, want, rows[i].Time)
+			}
+		}
+	})
+
+	t.Run("assign", func(t *testing.T) {
+		for _, test := range tests {
+			var got time.Time
+			err := parquet.Int96Type.AssignValue(reflect.ValueOf(&got).Elem(), parquet.ValueOf(test.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(test.time) {
+				t.Errorf("%v: wrong time: want=%v got=%v", test.value, test.time, got)
+			}
+		}
+	})
+}
+
+func TestTimeDuration(t *testing.T) {
+	type millisRow struct {
+		Time time.Duration `parquet:"time,time(millis)"`
+	}
+	type microsRow struct {
+		Time time.Duration `parquet:"time,time(micros)"`
+	}
+	type nanosRow struct {
+		Time time.Duration `parquet:"time,time(nanos:local)"`
+	}
+
+	durations := []time.Duration{
+		0,
+		13*time.Hour + 45*time.Minute + 30*time.Second + 123456789,
+		24*time.Hour - 1,
+	}
+
+	tests := []struct {
+		name     string
+		write    func(*testing.T) [][]byte
+		read     func(*testing.T, []byte) []time.Duration
+		kind     parquet.Kind
+		unit     parquet.TimeUnit
+		adjusted bool
+	}{
+		{
+			name: "millis",
+			write: func(t *testing.T) [][]byte {
+				rows := make([]millisRow, len(durations))
+				for i, d := range durations {
+					rows[i].Time = d
+				}
+				return writeGenericAndUntyped(t, rows)
+			},
+			read: func(t *testing.T, b []byte) []time.Duration {
+				rows, err := parquet.Read[millisRow](bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make([]time.Duration, len(rows))
+				for i, row := range rows {
+					got[i] = row.Time
+				}
+				return got
+			},
+			kind:     parquet.Int32,
+			unit:     parquet.Millisecond,
+			adjusted: true,
+		},
+		{
+			name: "micros",
+			write: func(t *testing.T) [][]byte {
+				rows := make([]microsRow, len(durations))
+				for i, d := range durations {
+					rows[i].Time = d
+				}
+				return writeGenericAndUntyped(t, rows)
+			},
+			read: func(t *testing.T, b []byte) []time.Duration {
+				rows, err := parquet.Read[microsRow](bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make([]time.Duration, len(rows))
+				for i, row := range rows {
+					got[i] = row.Time
+				}
+				return got
+			},
+			kind:     parquet.Int64,
+			unit:     parquet.Microsecond,
+			adjusted: true,
+		},
+		{
+			name: "nanos",
+			write: func(t *testing.T) [][]byte {
+				rows := make([]nanosRow, len(durations))
+				for i, d := range durations {
+					rows[i].Time = d
+				}
+				return writeGenericAndUntyped(t, rows)
+			},
+			read: func(t *testing.T, b []byte) []time.Duration {
+				rows, err := parquet.Read[nanosRow](bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make([]time.Duration, len(rows))
+				for i, row := range rows {
+					got[i] = row.Time
+				}
+				return got
+			},
+			kind:     parquet.Int64,
+			unit:     parquet.Nanosecond,
+			adjusted: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, b := range test.write(t) {
+				f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				leaf, _ := f.Schema().Lookup("time")
+				typ := leaf.Node.Type()
+				if typ.Kind() != test.kind {
+					t.Errorf("wrong physical type: want=%v got=%v", test.kind, typ.Kind())
+				}
+				lt := typ.LogicalType()
+				if lt == nil || lt.Time == nil {
+					t.Fatalf("column is not a time: %v", typ)
+				}
+				if lt.Time.IsAdjustedToUTC != test.adjusted {
+					t.Errorf("wrong isAdjustedToUTC: want=%t got=%t", test.adjusted, lt.Time.IsAdjustedToUTC)
+				}
+				if want := test.unit.TimeUnit(); lt.Time.Unit != want {
+					t.Errorf("wrong unit: want=%v got=%v", &want, &lt.Time.Unit)
+				}
+
+				got := test.read(t, b)
+				for i, d := range durations {
+					if want := d.Truncate(test.unit.Duration()); got[i] != want {
+						t.Errorf("row %d: wrong time: want=%v got=%v", i, want, got[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTimeDate(t *testing.T) {
+	type Row struct {
+		Day time.Time `parquet:"day,date"`
+	}
+
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	tests := []struct {
+		time time.Time
+		want time.Time
+		days int32
+	}{
+		{
+			time: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			days: 0,
+		},
+		{
+			time: time.Date(1970, 1, 1, 23, 59, 59, 999999999, time.UTC),
+			want: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			days: 0,
+		},
+		{
+			time: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+			want: time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+			days: -1,
+		},
+		{
+			time: time.Date(2024, 2, 29, 15, 30, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			days: 19782,
+		},
+		{
+			// The date is the one of the time in its location.
+			time: time.Date(2024, 3, 1, 1, 0, 0, 0, tokyo),
+			want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			days: 19783,
+		},
+	}
+
+	rows := make([]Row, len(tests))
+	for i, test := range tests {
+		rows[i].Day = test.time
+	}
+
+	for _, b := range writeGenericAndUntyped(t, rows) {
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaf, _ := f.Schema().Lookup("day")
+		typ := leaf.Node.Type()
+		if typ.Kind() != parquet.Int32 {
+			t.Errorf("wrong physical type: want=%v got=%v", parquet.Int32, typ.Kind())
+		}
+		if lt := typ.LogicalType(); lt == nil || lt.Date == nil {
+			t.Fatalf("column is not a date: %v", typ)
+		}
+
+		values := make([]parquet.Value, len(rows))
+		pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+		page, err := pages.ReadPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := page.Values().ReadValues(values); err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		pages.Close()
+
+		got, err := parquet.Read[Row](bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, test := range tests {
+			if days := values[i].Int32(); days != test.days {
+				t.Errorf("row %d: wrong number of days: want=%d got=%d", i, test.days, days)
+			}
+			if !got[i].Day.Equal(test.want) || got[i].Day.Location() != time.UTC {
+				t.Errorf("row %d: wrong date: want=%v got=%v", i, test.want, got[i].Day)
+			}
+		}
+	}
+}
+
+// writeGenericAndUntyped writes rows to two files, with a generic writer which
+// uses the column buffers, and with an untyped writer which deconstructs the
+// rows into parquet values.
+func writeGenericAndUntyped[T any](t *testing.T, rows []T) [][]byte {
+	generic := new(bytes.Buffer)
+	if err := parquet.Write(generic, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	untyped := new(bytes.Buffer)
+	w := parquet.NewWriter(untyped, parquet.SchemaOf(new(T)))
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return [][]byte{generic.Bytes(), untyped.Bytes()}
+}
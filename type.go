@@ -561,6 +561,11 @@ func (t int96Type) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 
 func (t int96Type) AssignValue(dst reflect.Value, src Value) error {
 	v := src.Int96()
+	if dst.Type() == reflect.TypeOf(time.Time{}) {
+		// INT96 values assigned to time.Time are legacy timestamps.
+		dst.Set(reflect.ValueOf(int96ToTime(v)))
+		return nil
+	}
 	dst.Set(reflect.ValueOf(v))
 	return nil
 }
@@ -650,6 +655,8 @@ func (t floatType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch typ.(type) {
 	case *stringType:
 		return convertStringToFloat(val)
+	case *float16Type:
+		return convertFloat16ToFloat(val)
 	}
 	switch typ.Kind() {
 	case Boolean:
@@ -731,6 +738,8 @@ func (t doubleType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch typ.(type) {
 	case *stringType:
 		return convertStringToDouble(val)
+	case *float16Type:
+		return convertFloat16ToDouble(val)
 	}
 	switch typ.Kind() {
 	case Boolean:
@@ -1264,6 +1273,13 @@ func (t *decimalType) ConvertedType() *deprecated.ConvertedType {
 	return &convertedTypes[deprecated.Decimal]
 }
 
+func (t *decimalType) AssignValue(dst reflect.Value, src Value) error {
+	if isDecimalGoType(dereference(dst.Type())) {
+		return assignDecimal(dst, src, int(t.decimal.Scale))
+	}
+	return t.Type.AssignValue(dst, src)
+}
+
 // String constructs a leaf node of UTF8 logical type.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#string
@@ -1750,9 +1766,26 @@ func (t *dateType) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 }
 
 func (t *dateType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == reflect.TypeOf(time.Time{}) {
+		dst.Set(reflect.ValueOf(dateToTime(src.int32())))
+		return nil
+	}
 	return int32Type{}.AssignValue(dst, src)
 }
 
+// dateOfTime returns the number of days between the unix epoch and the date of
+// t in its location; the time of the day is truncated.
+func dateOfTime(t time.Time) int32 {
+	y, m, d := t.Date()
+	return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (nanosecondsPerDay / 1e9))
+}
+
+// dateToTime is the inverse of dateOfTime, the returned time is midnight of
+// the date in the UTC location.
+func dateToTime(days int32) time.Time {
+	return time.Unix(int64(days)*(nanosecondsPerDay/1e9), 0).UTC()
+}
+
 func (t *dateType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch src := typ.(type) {
 	case *stringType:
@@ -1801,9 +1834,21 @@ func (u *nanosecond) TimeUnit() format.TimeUnit {
 
 // Time constructs a leaf node of TIME logical type.
 //
+// Columns of TIME logical type hold times of day, they are represented by
+// time.Duration values since midnight in Go.
+//
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#time
 func Time(unit TimeUnit) Node {
-	return Leaf(&timeType{IsAdjustedToUTC: true, Unit: unit.TimeUnit()})
+	return TimeAdjusted(unit, true)
+}
+
+// TimeAdjusted constructs a leaf node of TIME logical type, with the
+// isAdjustedToUTC flag set to the given value.
+//
+// The flag only describes the semantics of the values, time.Duration values
+// are written and read back unchanged regardless of its value.
+func TimeAdjusted(unit TimeUnit, isAdjustedToUTC bool) Node {
+	return Leaf(&timeType{IsAdjustedToUTC: isAdjustedToUTC, Unit: unit.TimeUnit()})
 }
 
 type timeType format.TimeType
@@ -1849,6 +1894,10 @@ func (t *timeType) LogicalType() *format.LogicalType {
 }
 
 func (t *timeType) ConvertedType() *deprecated.ConvertedType {
+	if !t.IsAdjustedToUTC {
+		// The legacy converted types only represent times adjusted to UTC.
+		return nil
+	}
 	switch {
 	case t.useInt32():
 		return &convertedTypes[deprecated.TimeMillis]
@@ -1892,9 +1941,30 @@ func (t *timeType) EstimateDecodeSize(numValues int, src []byte, enc encoding.En
 }
 
 func (t *timeType) AssignValue(dst reflect.Value, src Value) error {
+	if dst.Type() == reflect.TypeOf(time.Duration(0)) {
+		var v int64
+		if t.useInt32() {
+			v = int64(src.int32())
+		} else {
+			v = src.int64()
+		}
+		dst.SetInt(int64(timeToDuration(v, (*format.TimeType)(t))))
+		return nil
+	}
 	return t.baseType().AssignValue(dst, src)
 }
 
+// timeOfDuration returns the value of d in the unit of the TIME logical type
+// lt.
+func timeOfDuration(d time.Duration, lt *format.TimeType) int64 {
+	return int64(d / timeUnitDuration(lt.Unit))
+}
+
+// timeToDuration is the inverse of timeOfDuration.
+func timeToDuration(v int64, lt *format.TimeType) time.Duration {
+	return time.Duration(v) * timeUnitDuration(lt.Unit)
+}
+
 func (t *timeType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch src := typ.(type) {
 	case *stringType:
@@ -1919,7 +1989,17 @@ func (t *timeType) ConvertValue(val Value, typ Type) (Value, error) {
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#timestamp
 func Timestamp(unit TimeUnit) Node {
-	return Leaf(&timestampType{IsAdjustedToUTC: true, Unit: unit.TimeUnit()})
+	return TimestampAdjusted(unit, true)
+}
+
+// TimestampAdjusted constructs a leaf node of TIMESTAMP logical type, with the
+// isAdjustedToUTC flag set to the given value.
+//
+// When isAdjustedToUTC is false, the column holds local (zone-naive) times:
+// time.Time values are written using their wall clock time in their location,
+// and read back with the same wall clock time in the UTC location.
+func TimestampAdjusted(unit TimeUnit, isAdjustedToUTC bool) Node {
+	return Leaf(&timestampType{IsAdjustedToUTC: isAdjustedToUTC, Unit: unit.TimeUnit()})
 }
 
 type timestampType format.TimestampType
@@ -1953,6 +2033,10 @@ func (t *timestampType) LogicalType() *format.LogicalType {
 }
 
 func (t *timestampType) ConvertedType() *deprecated.ConvertedType {
+	if !t.IsAdjustedToUTC {
+		// The legacy converted types only represent instants.
+		return nil
+	}
 	switch {
 	case t.Unit.Millis != nil:
 		return &convertedTypes[deprecated.TimestampMillis]
@@ -1998,21 +2082,7 @@ func (t *timestampType) EstimateDecodeSize(numValues int, src []byte, enc encodi
 func (t *timestampType) AssignValue(dst reflect.Value, src Value) error {
 	switch dst.Type() {
 	case reflect.TypeOf(time.Time{}):
-		unit := Nanosecond.TimeUnit()
-		lt := t.LogicalType()
-		if lt != nil && lt.Timestamp != nil {
-			unit = lt.Timestamp.Unit
-		}
-
-		nanos := src.int64()
-		switch {
-		case unit.Millis != nil:
-			nanos = nanos * 1e6
-		case unit.Micros != nil:
-			nanos = nanos * 1e3
-		}
-
-		val := time.Unix(0, nanos).UTC()
+		val := timestampToTime(src.int64(), (*format.TimestampType)(t))
 		dst.Set(reflect.ValueOf(val))
 		return nil
 	default:
@@ -2020,13 +2090,50 @@ func (t *timestampType) AssignValue(dst reflect.Value, src Value) error {
 	}
 }
 
+// timestampOfTime returns the value of t in the unit of the TIMESTAMP logical
+// type lt. A nil lt is treated as nanosecond timestamps adjusted to UTC.
+func timestampOfTime(t time.Time, lt *format.TimestampType) int64 {
+	if lt == nil {
+		return t.UnixNano()
+	}
+	if !lt.IsAdjustedToUTC {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+	switch {
+	case lt.Unit.Millis != nil:
+		return t.UnixMilli()
+	case lt.Unit.Micros != nil:
+		return t.UnixMicro()
+	default:
+		return t.UnixNano()
+	}
+}
+
+// timestampToTime is the inverse of timestampOfTime, the returned time is
+// always in the UTC location.
+func timestampToTime(v int64, lt *format.TimestampType) time.Time {
+	switch {
+	case lt.Unit.Millis != nil:
+		return time.UnixMilli(v).UTC()
+	case lt.Unit.Micros != nil:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
+
 func (t *timestampType) ConvertValue(val Value, typ Type) (Value, error) {
 	switch src := typ.(type) {
+	case *stringType:
+		return convertStringToTimestamp(val, t.Unit, t.tz())
 	case *timestampType:
 		return convertTimestampToTimestamp(val, src.Unit, t.Unit)
 	case *dateType:
 		return convertDateToTimestamp(val, t.Unit, t.tz())
 	}
+	if typ.Kind() == Int96 {
+		return convertInt96ToTimestamp(val, t.Unit)
+	}
 	return int64Type{}.ConvertValue(val, typ)
 }
 
@@ -0,0 +1,236 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// Validate checks the structure of f against the parquet specification, and
+// returns the list of violations that it found, or nil if the file is valid.
+//
+// The function verifies that:
+//   - the file starts and ends with the parquet magic bytes,
+//   - the number of rows of the file is the sum of the rows of its row groups,
+//   - the column chunks match the leaf columns of the schema, and their pages
+//     are located within the data section of the file,
+//   - the statistics of column chunks are well formed, with min values lower
+//     than or equal to max values,
+//   - the column and offset indexes, when loaded, describe the same number of
+//     pages, which are located within their column chunks.
+//
+// Validate only reads the magic bytes of the file, all the other checks
+// use the metadata loaded when the file was opened. The content of pages
+// is not validated; page checksums are verified when the pages are read.
+// Column chunks of encrypted columns are not validated.
+//
+// All the returned errors wrap ErrInvalidFile.
+func Validate(f *File) []error {
+	v := validator{file: f}
+	v.validateMagic()
+	v.validateRowGroups()
+	return v.errors
+}
+
+type validator struct {
+	file    *File
+	dataEnd int64
+	errors  []error
+}
+
+func (v *validator) errorf(msg string, args ...interface{}) {
+	v.errors = append(v.errors, fmt.Errorf("%w: %s", ErrInvalidFile, fmt.Sprintf(msg, args...)))
+}
+
+func (v *validator) validateMagic() {
+	f := v.file
+	v.dataEnd = f.size
+
+	var b [8]byte
+	if f.size < 12 {
+		v.errorf("file of %d bytes is too small to hold the magic bytes and footer", f.size)
+		return
+	}
+	if _, err := readAt(f.reader, b[:4], 0); err != nil {
+		v.errorf("reading magic header: %v", err)
+		return
+	}
+	magic := string(b[:4])
+	if magic != "PAR1" && magic != "PARE" {
+		v.errorf("invalid magic header: %q", b[:4])
+	}
+	if _, err := readAt(f.reader, b[:8], f.size-8); err != nil {
+		v.errorf("reading magic footer: %v", err)
+		return
+	}
+	if string(b[4:8]) != magic {
+		v.errorf("invalid magic footer: %q", b[4:8])
+	}
+
+	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
+	if footerSize > f.size-12 {
+		v.errorf("footer of %d bytes exceeds the file size of %d bytes", footerSize, f.size)
+		return
+	}
+	v.dataEnd = f.size - 8 - footerSize
+}
+
+func (v *validator) validateRowGroups() {
+	f := v.file
+	numRows := int64(0)
+
+	for i, rowGroup := range f.rowGroups {
+		g := rowGroup.(*fileRowGroup)
+		numRows += g.rowGroup.NumRows
+
+		if g.rowGroup.NumRows < 0 {
+			v.errorf("row group %d: negative number of rows: %d", i, g.rowGroup.NumRows)
+		}
+		if len(g.rowGroup.Columns) != len(g.columns) {
+			v.errorf("row group %d: wrong number of column chunks: want=%d got=%d", i, len(g.columns), len(g.rowGroup.Columns))
+			continue
+		}
+		for _, c := range g.columns {
+			v.validateColumnChunk(i, c.(*fileColumnChunk))
+		}
+	}
+
+	if numRows != f.metadata.NumRows {
+		v.errorf("number of rows of the file does not match its row groups: want=%d got=%d", numRows, f.metadata.NumRows)
+	}
+}
+
+func (v *validator) validateColumnChunk(rowGroup int, c *fileColumnChunk) {
+	if isEncryptedColumnChunk(c.chunk) {
+		return
+	}
+	path := columnPath(c.column.Path())
+	where := func(msg string, args ...interface{}) {
+		v.errorf("row group %d column %q: %s", rowGroup, path, fmt.Sprintf(msg, args...))
+	}
+
+	metaData := &c.chunk.MetaData
+	numRows := c.rowGroup.NumRows
+
+	if !path.equal(metaData.PathInSchema) {
+		where("column chunk has the path of column %q", columnPath(metaData.PathInSchema))
+	}
+	typ := c.column.Type()
+	if physicalType := typ.PhysicalType(); physicalType == nil {
+		// Columns of the UNKNOWN logical type always hold null values, they
+		// have no statistics nor page bounds to validate.
+		typ = nil
+	} else if metaData.Type != *physicalType {
+		where("column chunk has type %s instead of %s", metaData.Type, *physicalType)
+		return
+	}
+
+	switch {
+	case metaData.NumValues < 0:
+		where("negative number of values: %d", metaData.NumValues)
+	case c.column.MaxRepetitionLevel() == 0 && metaData.NumValues != numRows:
+		where("column chunk has %d values for %d rows", metaData.NumValues, numRows)
+	case metaData.NumValues < numRows:
+		where("column chunk has fewer values than rows: %d < %d", metaData.NumValues, numRows)
+	}
+
+	start := metaData.DataPageOffset
+	if metaData.DictionaryPageOffset != 0 {
+		if metaData.DictionaryPageOffset >= metaData.DataPageOffset {
+			where("dictionary page offset %d is not before the data page offset %d", metaData.DictionaryPageOffset, metaData.DataPageOffset)
+		}
+		start = metaData.DictionaryPageOffset
+	}
+	end := start + metaData.TotalCompressedSize
+	if start < 4 || metaData.TotalCompressedSize < 0 || end > v.dataEnd {
+		v.errorf("row group %d column %q: column chunk [%d:%d] is out of the data section of the file [4:%d]", rowGroup, path, start, end, v.dataEnd)
+	}
+
+	if typ != nil {
+		v.validateStatistics(where, typ, metaData)
+	}
+
+	// The page index is only loaded when the file was opened without the
+	// SkipPageIndex option, and column chunks may omit either part of it.
+	if c.offsetIndex != nil && c.chunk.OffsetIndexOffset != 0 {
+		v.validateOffsetIndex(where, c.offsetIndex, start, end, numRows, metaData.NumValues)
+		if c.columnIndex != nil && c.chunk.ColumnIndexOffset != 0 && typ != nil {
+			v.validateColumnIndex(where, typ, c.columnIndex, len(c.offsetIndex.PageLocations))
+		}
+	}
+}
+
+func (v *validator) validateStatistics(where func(string, ...interface{}), typ Type, metaData *format.ColumnMetaData) {
+	stats := &metaData.Statistics
+	if n := stats.NullCount; n < 0 || n > metaData.NumValues {
+		where("invalid null count in the statistics: %d", n)
+	}
+	if stats.MinValue == nil || stats.MaxValue == nil {
+		return
+	}
+	if err := validateBounds(typ, stats.MinValue, stats.MaxValue); err != nil {
+		where("invalid statistics: %v", err)
+	}
+}
+
+func (v *validator) validateOffsetIndex(where func(string, ...interface{}), index *format.OffsetIndex, start, end, numRows, numValues int64) {
+	if len(index.PageLocations) == 0 {
+		if numValues != 0 {
+			where("the offset index has no pages")
+		}
+		return
+	}
+
+	prevRowIndex := int64(-1)
+	for i, page := range index.PageLocations {
+		pageEnd := page.Offset + int64(page.CompressedPageSize)
+		if page.Offset < start || page.CompressedPageSize <= 0 || pageEnd > end {
+			where("page %d [%d:%d] is out of the column chunk [%d:%d]", i, page.Offset, pageEnd, start, end)
+		}
+		switch {
+		case i == 0 && page.FirstRowIndex != 0:
+			where("the first page starts at row %d", page.FirstRowIndex)
+		case i > 0 && page.FirstRowIndex <= prevRowIndex:
+			where("page %d starts at row %d, which is not after the previous page starting at row %d", i, page.FirstRowIndex, prevRowIndex)
+		case page.FirstRowIndex >= numRows:
+			where("page %d starts at row %d, which is out of the %d rows of the row group", i, page.FirstRowIndex, numRows)
+		}
+		prevRowIndex = page.FirstRowIndex
+	}
+}
+
+func (v *validator) validateColumnIndex(where func(string, ...interface{}), typ Type, index *format.ColumnIndex, numPages int) {
+	if len(index.NullPages) != numPages || len(index.MinValues) != numPages || len(index.MaxValues) != numPages {
+		where("the column index has %d null pages, %d min values, and %d max values for %d pages",
+			len(index.NullPages), len(index.MinValues), len(index.MaxValues), numPages)
+		return
+	}
+	if len(index.NullCounts) != 0 && len(index.NullCounts) != numPages {
+		where("the column index has %d null counts for %d pages", len(index.NullCounts), numPages)
+	}
+	for i := 0; i < numPages; i++ {
+		if index.NullPages[i] || (len(index.MinValues[i]) == 0 && len(index.MaxValues[i]) == 0) {
+			continue
+		}
+		if err := validateBounds(typ, index.MinValues[i], index.MaxValues[i]); err != nil {
+			where("invalid bounds of page %d in the column index: %v", i, err)
+		}
+	}
+}
+
+func validateBounds(typ Type, minValue, maxValue []byte) error {
+	kind := typ.Kind()
+	min, err := parseValue(kind, minValue)
+	if err != nil {
+		return fmt.Errorf("min value: %w", err)
+	}
+	max, err := parseValue(kind, maxValue)
+	if err != nil {
+		return fmt.Errorf("max value: %w", err)
+	}
+	if typ.Compare(min, max) > 0 {
+		return fmt.Errorf("min value %v is greater than max value %v", min, max)
+	}
+	return nil
+}
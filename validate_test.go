@@ -0,0 +1,134 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestValidate(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name,dict"`
+		Tags []string `parquet:"tags"`
+		Opt  *float64 `parquet:"opt,optional"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: strings.Repeat("x", i%10)}
+		if i%3 == 0 {
+			rows[i].Tags = []string{"a", "b"}
+		}
+		if i%2 == 0 {
+			v := float64(i)
+			rows[i].Opt = &v
+		}
+	}
+
+	output := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](output, parquet.MaxRowsPerRowGroup(300), parquet.PageBufferSize(512))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	open := func(t *testing.T, data []byte) *parquet.File {
+		t.Helper()
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	expectErrors := func(t *testing.T, f *parquet.File, substrings ...string) {
+		t.Helper()
+		errs := parquet.Validate(f)
+		if len(errs) != len(substrings) {
+			t.Fatalf("wrong number of violations: want=%d got=%d %v", len(substrings), len(errs), errs)
+		}
+		for i, err := range errs {
+			if !errors.Is(err, parquet.ErrInvalidFile) {
+				t.Errorf("violation does not wrap ErrInvalidFile: %v", err)
+			}
+			if !strings.Contains(err.Error(), substrings[i]) {
+				t.Errorf("wrong violation: want an error containing %q, got %q", substrings[i], err)
+			}
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		expectErrors(t, open(t, output.Bytes()))
+	})
+
+	t.Run("magic", func(t *testing.T) {
+		data := append([]byte(nil), output.Bytes()...)
+		f := open(t, data)
+		copy(data, "PARX")
+		data[len(data)-1] = 'Y'
+		expectErrors(t, f, "invalid magic header", "invalid magic footer")
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		f := open(t, output.Bytes())
+		metadata := f.Metadata()
+		metadata.NumRows++
+		metadata.RowGroups[0].Columns[0].MetaData.NumValues = 10
+		metadata.RowGroups[1].Columns[0].MetaData.TotalCompressedSize = f.Size()
+		metadata.RowGroups[2].Columns[0].MetaData.Statistics.MinValue = []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}
+		expectErrors(t, f,
+			`row group 0 column "id": column chunk has 10 values for 300 rows`,
+			`row group 1 column "id": column chunk [`,
+			`row group 2 column "id": invalid statistics: min value`,
+			"number of rows of the file does not match its row groups",
+		)
+	})
+
+	t.Run("page index", func(t *testing.T) {
+		f := open(t, output.Bytes())
+		offsetIndex := f.OffsetIndexes()[0]
+		if len(offsetIndex.PageLocations) < 3 {
+			t.Fatalf("the column chunk must have at least 3 pages: %d", len(offsetIndex.PageLocations))
+		}
+		offsetIndex.PageLocations[1].FirstRowIndex = 0
+		offsetIndex.PageLocations[2].Offset = 0
+		columnIndex := &f.ColumnIndexes()[1]
+		columnIndex.MinValues = columnIndex.MinValues[1:]
+		expectErrors(t, f,
+			`row group 0 column "id": page 1 starts at row 0`,
+			`row group 0 column "id": page 2 [0:`,
+			`row group 0 column "name": the column index has`,
+		)
+	})
+}
+
+func TestValidateTestdata(t *testing.T) {
+	paths, err := filepath.Glob("testdata/*.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		// The metadata of this file records 6 rows while its only row group
+		// has no rows.
+		if filepath.Base(path) == "repeated_no_annotation.parquet" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			continue // files which cannot be opened are tested elsewhere
+		}
+		for _, err := range parquet.Validate(f) {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
@@ -29,6 +29,13 @@ const (
 // Value instances are small, immutable objects, and usually passed by value
 // between function calls.
 //
+// Values of BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY kinds do not hold a copy of
+// their bytes, they reference the memory that they were constructed from, for
+// example the buffer of the page that they were read from. Those values must
+// not be used after the page was released or its buffer reused; programs that
+// retain values beyond the lifetime of the pages should call Clone to make
+// copies which do not share memory with the pages.
+//
 // The zero-value of Value represents the null parquet value.
 type Value struct {
 	// data
@@ -168,7 +175,10 @@ func copyValues(dst ValueWriter, src ValueReader, buf []Value) (written int64, e
 //
 // The repetition and definition levels of the returned value are both zero.
 //
-// The function panics if the Go value cannot be represented in parquet.
+// The function panics if the Go value cannot be represented in parquet. The
+// values are constructed without a logical type; decimal values, which panic
+// when they overflow the precision of their column, must be written with a
+// Writer or GenericWriter to receive an error instead.
 func ValueOf(v interface{}) Value {
 	k := Kind(-1)
 	t := reflect.TypeOf(v)
@@ -180,6 +190,8 @@ func ValueOf(v interface{}) Value {
 		return makeValueBytes(FixedLenByteArray, value[:])
 	case deprecated.Int96:
 		return makeValueInt96(value)
+	case Interval:
+		return makeValueBytes(FixedLenByteArray, intervalBytes(value))
 	case time.Time:
 		k = Int64
 	}
@@ -252,6 +264,27 @@ func ByteArrayValue(value []byte) Value { return makeValueBytes(ByteArray, value
 // slice passed as argument.
 func FixedLenByteArrayValue(value []byte) Value { return makeValueBytes(FixedLenByteArray, value) }
 
+// valueError is the panic value of makeValue when a Go value cannot be
+// represented in parquet, writers recover it to return the error it wraps.
+type valueError struct{ err error }
+
+func (e *valueError) Error() string { return e.err.Error() }
+
+func (e *valueError) Unwrap() error { return e.err }
+
+// recoverValueError must be deferred by functions converting Go values with
+// makeValue, the panics raised for values which cannot be represented in
+// parquet are recovered and their error assigned to *err.
+func recoverValueError(err *error) {
+	if r := recover(); r != nil {
+		e, ok := r.(*valueError)
+		if !ok {
+			panic(r)
+		}
+		*err = e.err
+	}
+}
+
 func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 	if v.Kind() == reflect.Interface {
 		if v.IsNil() {
@@ -262,24 +295,43 @@ func makeValue(k Kind, lt *format.LogicalType, v reflect.Value) Value {
 		}
 	}
 
+	if lt != nil && lt.Decimal != nil && isDecimalGoType(dereference(v.Type())) {
+		val, err := makeValueDecimal(k, lt.Decimal, v)
+		if err != nil {
+			panic(&valueError{err})
+		}
+		return val
+	}
+
+	if lt != nil && lt.Float16 != nil {
+		switch v.Kind() {
+		case reflect.Uint16:
+			return makeValueFloat16(uint16(v.Uint()))
+		case reflect.Float32, reflect.Float64:
+			return makeValueFloat16(float32ToFloat16(float32(v.Float())))
+		}
+	}
+
 	switch v.Type() {
 	case reflect.TypeOf(time.Time{}):
-		unit := Nanosecond.TimeUnit()
-		if lt != nil && lt.Timestamp != nil {
-			unit = lt.Timestamp.Unit
+		if lt != nil && lt.Date != nil {
+			return makeValueInt32(dateOfTime(v.Interface().(time.Time)))
 		}
-
-		t := v.Interface().(time.Time)
-		var val int64
-		switch {
-		case unit.Millis != nil:
-			val = t.UnixMilli()
-		case unit.Micros != nil:
-			val = t.UnixMicro()
-		default:
-			val = t.UnixNano()
+		var ts *format.TimestampType
+		if lt != nil {
+			ts = lt.Timestamp
+		}
+		return makeValueInt64(timestampOfTime(v.Interface().(time.Time), ts))
+	case reflect.TypeOf(Interval{}):
+		return makeValueBytes(FixedLenByteArray, intervalBytes(v.Interface().(Interval)))
+	case reflect.TypeOf(time.Duration(0)):
+		if lt != nil && lt.Time != nil {
+			t := timeOfDuration(time.Duration(v.Int()), lt.Time)
+			if k == Int32 {
+				return makeValueInt32(int32(t))
+			}
+			return makeValueInt64(t)
 		}
-		return makeValueInt64(val)
 	}
 
 	switch k {
@@ -784,6 +836,10 @@ func (v Value) Level(repetitionLevel, definitionLevel, columnIndex int) Value {
 }
 
 // Clone returns a copy of v which does not share any pointers with it.
+//
+// The bytes of BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY values are copied, so the
+// returned value remains valid after the memory that v was read from is
+// released. Values of other kinds are returned unchanged.
 func (v Value) Clone() Value {
 	switch k := v.Kind(); k {
 	case ByteArray, FixedLenByteArray:
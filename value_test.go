@@ -2,6 +2,8 @@ package parquet_test
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"math"
 	"testing"
 	"time"
@@ -102,6 +104,68 @@ func TestValueClone(t *testing.T) {
 	}
 }
 
+func TestValueCloneRetainPage(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].Name = fmt.Sprintf("name-%04d", i)
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages := f.RowGroups()[0].ColumnChunks()[0].Pages()
+	defer pages.Close()
+
+	values := make([]parquet.Value, 0, len(rows))
+	buffer := []parquet.Value{}
+	for {
+		page, err := pages.ReadPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := int(page.NumValues()); cap(buffer) < n {
+			buffer = make([]parquet.Value, n)
+		}
+		n, err := page.Values().ReadValues(buffer[:page.NumValues()])
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		for _, v := range buffer[:n] {
+			c := v.Clone()
+			if &c.ByteArray()[0] == &v.ByteArray()[0] {
+				t.Fatal("cloned value shares memory with the page")
+			}
+			values = append(values, c)
+		}
+		parquet.Release(page)
+	}
+
+	if len(values) != len(rows) {
+		t.Fatalf("wrong number of values: want=%d got=%d", len(rows), len(values))
+	}
+	for i, v := range values {
+		if got := v.String(); got != rows[i].Name {
+			t.Errorf("value %d was corrupted after releasing its page: want=%q got=%q", i, rows[i].Name, got)
+		}
+	}
+}
+
 func TestZeroValue(t *testing.T) {
 	var v parquet.Value
 	if !v.IsNull() {
@@ -11,6 +11,8 @@ import (
 	"math/bits"
 	"reflect"
 	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/parquet-go/parquet-go/compress"
 	"github.com/parquet-go/parquet-go/encoding"
@@ -144,16 +146,29 @@ func makeWriteFunc[T any](t reflect.Type, schema *Schema) writeFunc[T] {
 	return func(w *GenericWriter[T], rows []T) (n int, err error) {
 		if w.columns == nil {
 			w.columns = make([]ColumnBuffer, len(w.base.writer.columns))
-			for i, c := range w.base.writer.columns {
-				// These fields are usually lazily initialized when writing rows,
-				// we need them to exist now tho.
+		}
+		for i, c := range w.base.writer.columns {
+			// These fields are usually lazily initialized when writing rows,
+			// we need them to exist now tho. The buffers are looked up on each
+			// call because columns replace them when they stop using their
+			// dictionary, see MaxDictionarySize.
+			if c.columnBuffer == nil {
 				c.columnBuffer = c.newColumnBuffer()
-				w.columns[i] = c.columnBuffer
 			}
+			w.columns[i] = c.columnBuffer
+		}
+		var errUnsorted error
+		if c := w.base.writer.sorted; c != nil {
+			var sorted int
+			sorted, errUnsorted = c.check(len(rows), func(i int) Row {
+				c.scratch = w.base.schema.Deconstruct(c.scratch[:0], &rows[i])
+				return c.scratch
+			})
+			rows = rows[:sorted]
 		}
 		err = writeRows(w.columns, makeArrayOf(rows), columnLevels{})
 		if err == nil {
-			n = len(rows)
+			n, err = len(rows), errUnsorted
 		}
 		return n, err
 	}
@@ -163,30 +178,37 @@ func (w *GenericWriter[T]) Close() error {
 	return w.base.Close()
 }
 
+// Flush flushes all buffers into a row group to the underlying io.Writer, see
+// the documentation of Writer.Flush for details.
 func (w *GenericWriter[T]) Flush() error {
 	return w.base.Flush()
 }
 
+// Stats returns the number of rows and bytes written by w, see the
+// documentation of Writer.Stats for details.
+func (w *GenericWriter[T]) Stats() WriterStats {
+	return w.base.Stats()
+}
+
+// Reset clears the state of the writer without flushing any of the buffers,
+// and sets the output to the io.Writer passed as argument, allowing the writer
+// to be reused to produce another parquet file.
+//
+// The schema, configuration, and column buffers of the writer are retained,
+// which avoids the cost of constructing a new writer for each file.
 func (w *GenericWriter[T]) Reset(output io.Writer) {
 	w.base.Reset(output)
 }
 
-func (w *GenericWriter[T]) Write(rows []T) (int, error) {
+func (w *GenericWriter[T]) Write(rows []T) (n int, err error) {
+	defer recoverValueError(&err)
 	return w.base.writer.writeRows(len(rows), func(i, j int) (int, error) {
 		n, err := w.write(w, rows[i:j:j])
 		if err != nil {
 			return n, err
 		}
 
-		for _, c := range w.base.writer.columns {
-			if c.columnBuffer.Size() >= int64(c.bufferSize) {
-				if err := c.flush(); err != nil {
-					return n, err
-				}
-			}
-		}
-
-		return n, nil
+		return n, w.base.writer.flushFullColumns()
 	})
 }
 
@@ -194,6 +216,8 @@ func (w *GenericWriter[T]) WriteRows(rows []Row) (int, error) {
 	return w.base.WriteRows(rows)
 }
 
+// WriteRowGroup writes a row group to the parquet file, see the documentation
+// of Writer.WriteRowGroup for details.
 func (w *GenericWriter[T]) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	return w.base.WriteRowGroup(rowGroup)
 }
@@ -344,8 +368,11 @@ func (w *Writer) Close() error {
 // Flush flushes all buffers into a row group to the underlying io.Writer.
 //
 // Flush is called automatically on Close, it is only useful to call explicitly
-// if the application needs to limit the size of row groups or wants to produce
-// multiple row groups per file.
+// if the application needs to control the boundaries of row groups. Writers
+// configured with the MaxRowsPerRowGroup or MaxRowGroupBytes options also flush
+// row groups automatically when they reach the limits, including in the middle
+// of a call writing many rows, so the size of row groups can be limited without
+// calling Flush.
 //
 // If the writer attempts to create more than MaxRowGroups row groups the method
 // returns ErrTooManyRowGroups.
@@ -356,6 +383,19 @@ func (w *Writer) Flush() error {
 	return nil
 }
 
+// Stats returns the number of rows and bytes written by w, computed from the
+// metadata of the row groups that it wrote. Rows buffered since the last row
+// group was flushed are not accounted for, the stats are complete after Close.
+//
+// The method must not be called concurrently with Write, WriteRows, Flush, or
+// Close.
+func (w *Writer) Stats() WriterStats {
+	if w.writer != nil {
+		return w.writer.stats()
+	}
+	return WriterStats{}
+}
+
 // Reset clears the state of the writer without flushing any of the buffers,
 // and setting the output to the io.Writer passed as argument, allowing the
 // writer to be reused to produce another parquet file.
@@ -373,7 +413,8 @@ func (w *Writer) Reset(output io.Writer) {
 // and decompose it into a set of columns and values. If no schema were passed
 // to NewWriter, it is deducted from the Go type of the row, which then have to
 // be a struct or pointer to struct.
-func (w *Writer) Write(row interface{}) error {
+func (w *Writer) Write(row interface{}) (err error) {
+	defer recoverValueError(&err)
 	if w.schema == nil {
 		w.configure(SchemaOf(row))
 	}
@@ -384,7 +425,7 @@ func (w *Writer) Write(row interface{}) error {
 	}
 	defer clearRows(w.rowbuf)
 	w.rowbuf[0] = w.schema.Deconstruct(w.rowbuf[0][:0], row)
-	_, err := w.WriteRows(w.rowbuf)
+	_, err = w.WriteRows(w.rowbuf)
 	return err
 }
 
@@ -406,6 +447,15 @@ func (w *Writer) WriteRows(rows []Row) (int, error) {
 //
 // The content of the row group is flushed to the writer; after the method
 // returns successfully, the row group will be empty and in ready to be reused.
+//
+// When the row group does not exceed the maximum number of rows per row group
+// of the writer, it is written column by column, which avoids the cost of
+// assembling rows. Column chunks of parquet files which use the compression
+// codec and encoding of their column in the writer are copied verbatim,
+// including their statistics and page index, and the pages of buffers are
+// encoded without decoding their values when the column is not dictionary
+// encoded. Otherwise, the rows are copied and split across multiple row groups
+// in the output file.
 func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	rowGroupSchema := rowGroup.Schema()
 	switch {
@@ -420,13 +470,25 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 		return 0, err
 	}
 	w.writer.configureBloomFilters(rowGroup.ColumnChunks())
+
+	if rowGroup.NumRows() <= w.writer.maxRows && w.writer.maxBytes == 0 && w.writer.sorted == nil {
+		// The row group fits in a single row group of the output file, its
+		// values can be written column by column without having to assemble
+		// rows.
+		if err := w.writer.writeColumnChunks(rowGroup); err != nil {
+			return 0, err
+		}
+		return w.writer.writeRowGroup(rowGroup.Schema(), rowGroup.SortingColumns())
+	}
+
 	rows := rowGroup.Rows()
 	defer rows.Close()
 	n, err := CopyRows(w.writer, rows)
 	if err != nil {
 		return n, err
 	}
-	return w.writer.writeRowGroup(rowGroup.Schema(), rowGroup.SortingColumns())
+	_, err = w.writer.writeRowGroup(rowGroup.Schema(), rowGroup.SortingColumns())
+	return n, err
 }
 
 // ReadRowsFrom reads rows from the reader passed as arguments and writes them
@@ -477,11 +539,16 @@ func (w *Writer) SetKeyValueMetadata(key, value string) {
 }
 
 type writer struct {
-	buffer  *bufio.Writer
-	writer  offsetTrackingWriter
-	values  [][]Value
-	numRows int64
-	maxRows int64
+	buffer   *bufio.Writer
+	writer   offsetTrackingWriter
+	values   [][]Value
+	numRows  int64
+	maxRows  int64
+	maxBytes int64
+	sorted   *sortedRowsCheck
+
+	// Number of columns encoded in parallel by forEachColumn.
+	concurrency int
 
 	createdBy string
 	metadata  []format.KeyValue
@@ -508,6 +575,11 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		w.writer.Reset(w.buffer)
 	}
 	w.maxRows = config.MaxRowsPerRowGroup
+	w.maxBytes = config.MaxRowGroupBytes
+	if config.AssertSorted && len(config.Sorting.SortingColumns) > 0 {
+		w.sorted = &sortedRowsCheck{compare: config.Schema.Comparator(config.Sorting.SortingColumns...)}
+	}
+	w.concurrency = config.ColumnWriteConcurrency
 	w.createdBy = config.CreatedBy
 	w.metadata = make([]format.KeyValue, 0, len(config.KeyValueMetadata))
 	for k, v := range config.KeyValueMetadata {
@@ -565,20 +637,40 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 	// Those buffers are scratch space used to generate the page header and
 	// content, they are shared by all column chunks because they are only
 	// used during calls to writeDictionaryPage or writeDataPage, which are
-	// not done concurrently.
+	// not done concurrently, unless the writer is configured to encode
+	// columns in parallel, in which case each column has its own buffers.
 	buffers := new(writerBuffers)
 
 	forEachLeafColumnOf(config.Schema, func(leaf leafColumn) {
 		encoding := encodingOf(leaf.node)
 		dictionary := Dictionary(nil)
 		columnType := leaf.node.Type()
+		plainType := columnType
+		if enc, ok := config.Encodings[leaf.path.String()]; ok && enc != nil {
+			if !canEncode(enc, columnType.Kind()) {
+				panic("cannot apply " + enc.Encoding().String() + " to column " + leaf.path.String() + " of type " + columnType.String())
+			}
+			encoding = enc
+		}
 		columnIndex := int(leaf.columnIndex)
 		compression := leaf.node.Compression()
+		if c, ok := config.Compressions[leaf.path.String()]; ok {
+			codec, err := c.NewCodec()
+			if err != nil {
+				panic("cannot apply compression to column " + leaf.path.String() + ": " + err.Error())
+			}
+			compression = codec
+		}
 
 		if compression == nil {
 			compression = defaultCompression
 		}
 
+		columnFilter := searchBloomFilterColumn(config.BloomFilters, leaf.path)
+		if columnFilter == nil {
+			columnFilter = searchBloomFilterColumn(config.Schema.bloomFilters, leaf.path)
+		}
+
 		if isDictionaryEncoding(encoding) {
 			dictBuffer := columnType.NewValues(
 				make([]byte, 0, defaultDictBufferSize),
@@ -588,13 +680,18 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			columnType = dictionary.Type()
 		}
 
+		columnBuffers := buffers
+		if w.concurrency > 1 {
+			columnBuffers = new(writerBuffers)
+		}
+
 		c := &writerColumn{
-			buffers:            buffers,
+			buffers:            columnBuffers,
 			pool:               config.ColumnPageBuffers,
 			columnPath:         leaf.path,
 			columnType:         columnType,
 			columnIndex:        columnType.NewColumnIndexer(config.ColumnIndexSizeLimit),
-			columnFilter:       searchBloomFilterColumn(config.BloomFilters, leaf.path),
+			columnFilter:       columnFilter,
 			compression:        compression,
 			dictionary:         dictionary,
 			dataPageType:       dataPageType,
@@ -603,7 +700,11 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			bufferIndex:        int32(leaf.columnIndex),
 			bufferSize:         int32(float64(config.PageBufferSize) * 0.98),
 			writePageStats:     config.DataPageStatistics,
+			writePageChecksums: !config.DisablePageChecksums,
+			unordered:          columnType.ColumnOrder() == nil,
 			encodings:          make([]format.Encoding, 0, 3),
+			maxDictionarySize:  config.MaxDictionarySize,
+			plainType:          plainType,
 			// Data pages in version 2 can omit compression when dictionary
 			// encoding is employed; only the dictionary page needs to be
 			// compressed, the data pages are encoded with the hybrid
@@ -612,7 +713,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			isCompressed: isCompressed(compression) && (dataPageType != format.DataPageV2 || dictionary == nil),
 		}
 
-		c.header.encoder.Reset(c.header.protocol.NewWriter(&buffers.header))
+		c.header.encoder.Reset(c.header.protocol.NewWriter(&columnBuffers.header))
 
 		if leaf.maxDefinitionLevel > 0 {
 			c.encodings = addEncoding(c.encodings, format.RLE)
@@ -623,6 +724,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		}
 
 		c.encoding = encoding
+		c.dictionaryEncoding = encoding
 		c.encodings = addEncoding(c.encodings, c.encoding.Encoding())
 		sortPageEncodings(c.encodings)
 
@@ -670,12 +772,73 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 	}
 
 	for i, c := range w.columns {
-		w.columnOrders[i] = *c.columnType.ColumnOrder()
+		// Columns of types with no sort order keep an empty column order,
+		// which readers interpret as undefined.
+		if columnOrder := c.columnType.ColumnOrder(); columnOrder != nil {
+			w.columnOrders[i] = *columnOrder
+		}
 	}
 
 	return w
 }
 
+// WriterStats holds the number of rows and bytes written to a parquet file,
+// for example to report how well the data compressed.
+//
+// The sizes include the page headers, as recorded in the metadata of column
+// chunks; FileSize is the number of bytes written to the output, which also
+// includes the page index and the footer once the writer is closed.
+type WriterStats struct {
+	NumRows          int64
+	NumRowGroups     int
+	UncompressedSize int64
+	CompressedSize   int64
+	FileSize         int64
+	Columns          []ColumnStats
+}
+
+// ColumnStats holds the number of values and bytes written to a column of a
+// parquet file, summed over its column chunks.
+type ColumnStats struct {
+	Path             []string
+	NumValues        int64
+	UncompressedSize int64
+	CompressedSize   int64
+}
+
+// CompressionRatio returns the ratio of the uncompressed size to the
+// compressed size of s, or zero if nothing was written.
+func (s *WriterStats) CompressionRatio() float64 {
+	if s.CompressedSize == 0 {
+		return 0
+	}
+	return float64(s.UncompressedSize) / float64(s.CompressedSize)
+}
+
+func (w *writer) stats() WriterStats {
+	stats := WriterStats{
+		NumRowGroups: len(w.rowGroups),
+		FileSize:     w.writer.offset,
+		Columns:      make([]ColumnStats, len(w.columns)),
+	}
+	for i, c := range w.columns {
+		stats.Columns[i].Path = append([]string(nil), c.columnPath...)
+	}
+	for _, rowGroup := range w.rowGroups {
+		stats.NumRows += rowGroup.NumRows
+		for i := range rowGroup.Columns {
+			metaData := &rowGroup.Columns[i].MetaData
+			column := &stats.Columns[i]
+			column.NumValues += metaData.NumValues
+			column.UncompressedSize += metaData.TotalUncompressedSize
+			column.CompressedSize += metaData.TotalCompressedSize
+			stats.UncompressedSize += metaData.TotalUncompressedSize
+			stats.CompressedSize += metaData.TotalCompressedSize
+		}
+	}
+	return stats
+}
+
 func (w *writer) reset(writer io.Writer) {
 	if w.buffer == nil {
 		w.writer.Reset(writer)
@@ -686,6 +849,9 @@ func (w *writer) reset(writer io.Writer) {
 	for _, c := range w.columns {
 		c.reset()
 	}
+	if w.sorted != nil {
+		w.sorted.reset()
+	}
 	for i := range w.rowGroups {
 		w.rowGroups[i] = format.RowGroup{}
 	}
@@ -827,13 +993,17 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		}
 	}()
 
-	for _, c := range w.columns {
-		if err := c.flush(); err != nil {
-			return 0, err
+	err := w.forEachColumn(func(_ int, c *writerColumn) error {
+		if c.copied != nil {
+			return nil
 		}
-		if err := c.flushFilterPages(); err != nil {
-			return 0, err
+		if err := c.flush(); err != nil {
+			return err
 		}
+		return c.flushFilterPages()
+	})
+	if err != nil {
+		return 0, err
 	}
 
 	if err := w.writeFileHeader(); err != nil {
@@ -842,6 +1012,12 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	fileOffset := w.writer.offset
 
 	for _, c := range w.columns {
+		if c.copied != nil {
+			if err := c.writeCopiedBloomFilter(&w.writer); err != nil {
+				return 0, err
+			}
+			continue
+		}
 		if len(c.filter) > 0 {
 			c.columnChunk.MetaData.BloomFilterOffset = w.writer.offset
 			if err := c.writeBloomFilter(&w.writer); err != nil {
@@ -851,6 +1027,14 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	}
 
 	for i, c := range w.columns {
+		if c.copied != nil {
+			if err := c.writeCopiedColumnChunk(&w.writer); err != nil {
+				return 0, fmt.Errorf("copying row group column %d: %w", i, err)
+			}
+			w.columnIndex[i] = c.copied.columnIndex
+			continue
+		}
+
 		w.columnIndex[i] = format.ColumnIndex(c.columnIndex.ColumnIndex())
 
 		if c.dictionary != nil {
@@ -886,15 +1070,17 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	sortingColumns := w.sortingColumns
 	if len(sortingColumns) == 0 && len(rowGroupSortingColumns) > 0 {
 		sortingColumns = make([]format.SortingColumn, 0, len(rowGroupSortingColumns))
-		forEachLeafColumnOf(rowGroupSchema, func(leaf leafColumn) {
-			if sortingIndex := searchSortingColumn(rowGroupSortingColumns, leaf.path); sortingIndex < len(sortingColumns) {
-				sortingColumns[sortingIndex] = format.SortingColumn{
-					ColumnIdx:  int32(leaf.columnIndex),
-					Descending: rowGroupSortingColumns[sortingIndex].Descending(),
-					NullsFirst: rowGroupSortingColumns[sortingIndex].NullsFirst(),
-				}
+		// Sorting columns which are not leaf columns of the schema are omitted
+		// since they cannot be referenced by a column index.
+		for _, sorting := range rowGroupSortingColumns {
+			if leaf, ok := rowGroupSchema.Lookup(sorting.Path()...); ok {
+				sortingColumns = append(sortingColumns, format.SortingColumn{
+					ColumnIdx:  int32(leaf.ColumnIndex),
+					Descending: sorting.Descending(),
+					NullsFirst: sorting.NullsFirst(),
+				})
 			}
-		})
+		}
 	}
 
 	columns := make([]format.ColumnChunk, len(w.columnChunk))
@@ -912,6 +1098,14 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		copy(c.MetaData.EncodingStats, w.columnChunk[i].MetaData.EncodingStats)
 	}
 
+	// The column indexes may reference the internal buffers of the column
+	// indexers, which are reused by the next row group.
+	for i := range columnIndex {
+		c := &columnIndex[i]
+		c.NullPages = append([]bool(nil), c.NullPages...)
+		c.NullCounts = append([]int64(nil), c.NullCounts...)
+	}
+
 	for i := range offsetIndex {
 		c := &offsetIndex[i]
 		c.PageLocations = make([]format.PageLocation, len(c.PageLocations))
@@ -935,6 +1129,13 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 
 func (w *writer) WriteRows(rows []Row) (int, error) {
 	return w.writeRows(len(rows), func(start, end int) (int, error) {
+		var errUnsorted error
+		if w.sorted != nil {
+			var n int
+			n, errUnsorted = w.sorted.check(end-start, func(i int) Row { return rows[start+i] })
+			end = start + n
+		}
+
 		defer func() {
 			for i, values := range w.values {
 				clearValues(values)
@@ -961,10 +1162,44 @@ func (w *writer) WriteRows(rows []Row) (int, error) {
 			}
 		}
 
-		return end - start, nil
+		return end - start, errUnsorted
 	})
 }
 
+// sortedRowsCheck verifies that rows are written in the order of the sorting
+// columns of a writer, see AssertSorted.
+type sortedRowsCheck struct {
+	compare func(Row, Row) int
+	last    Row
+	numRows int64
+	scratch Row
+}
+
+func (c *sortedRowsCheck) reset() {
+	c.last = c.last[:0]
+	c.numRows = 0
+}
+
+// check verifies the order of the numRows rows returned by rowAt, following
+// the rows previously checked. The method returns the number of rows which
+// are sorted, and a non-nil error if one of the rows is out of order.
+//
+// The rows returned by rowAt are only retained until the next call to rowAt.
+func (c *sortedRowsCheck) check(numRows int, rowAt func(int) Row) (int, error) {
+	for i := 0; i < numRows; i++ {
+		row := rowAt(i)
+		if c.numRows > 0 && c.compare(c.last, row) > 0 {
+			return i, fmt.Errorf("%w: row %d is ordered before the previous row", ErrRowsNotSorted, c.numRows)
+		}
+		c.last = append(c.last[:0], row...)
+		for j, v := range c.last {
+			c.last[j] = v.Clone()
+		}
+		c.numRows++
+	}
+	return numRows, nil
+}
+
 func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int, error) {
 	written := 0
 
@@ -984,6 +1219,16 @@ func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int,
 			length = int(remain)
 		}
 
+		if w.maxBytes > 0 {
+			length = w.limitRowGroupBytes(length)
+			if length == 0 {
+				if err := w.flush(); err != nil {
+					return written, err
+				}
+				length = 1
+			}
+		}
+
 		// Since the writer cannot flush pages across row boundaries, calls to
 		// WriteRows with very large slices can result in greatly exceeding the
 		// target page size. To set a limit to the impact of these large writes
@@ -1008,6 +1253,136 @@ func (w *writer) writeRows(numRows int, write func(i, j int) (int, error)) (int,
 	return written, nil
 }
 
+// limitRowGroupBytes returns the number of rows, up to length, which can be
+// written to the current row group before its size reaches the target of the
+// MaxRowGroupBytes option. The size of the next rows is estimated from the
+// average size of the rows already written, so only one row is written when
+// the row group is empty. Zero is returned when the row group is full.
+func (w *writer) limitRowGroupBytes(length int) int {
+	if w.numRows == 0 {
+		return 1
+	}
+	size := w.rowGroupSize()
+	if size >= w.maxBytes {
+		return 0
+	}
+	if rowSize := size / w.numRows; rowSize > 0 {
+		if n := (w.maxBytes - size + rowSize - 1) / rowSize; n < int64(length) {
+			length = int(n)
+		}
+	}
+	return length
+}
+
+// rowGroupSize returns the estimated size in bytes of the row group being
+// written, see MaxRowGroupBytes.
+func (w *writer) rowGroupSize() int64 {
+	size := int64(0)
+	for _, c := range w.columns {
+		size += c.columnChunk.MetaData.TotalCompressedSize
+		size += c.columnBuffer.Size()
+		if c.dictionary != nil {
+			size += c.dictionary.Page().Size()
+		}
+	}
+	return size
+}
+
+// writeColumnChunks writes the column chunks of rowGroup to the columns of w.
+// The schema of the row group must match the schema of w.
+//
+// Column chunks of parquet files which use the codec and encoding of their
+// column are copied verbatim, like in Rewrite, unless the column writes a bloom
+// filter that the chunk does not have. The other column chunks are written
+// with writeColumnChunk.
+func (w *writer) writeColumnChunks(rowGroup RowGroup) error {
+	numRows := rowGroup.NumRows()
+	chunks := rowGroup.ColumnChunks()
+	for i, c := range w.columns {
+		chunk, ok := chunks[i].(*fileColumnChunk)
+		if ok && !isEncryptedColumnChunk(chunk.chunk) && (c.columnFilter == nil || chunk.bloomFilter != nil) && c.canCopyColumnChunk(chunk) {
+			c.copyColumnChunk(chunk, numRows)
+		}
+	}
+	err := w.forEachColumn(func(i int, c *writerColumn) error {
+		if c.copied != nil {
+			return nil
+		}
+		if err := c.writeColumnChunk(chunks[i]); err != nil {
+			return fmt.Errorf("writing values of row group column %d: %w", i, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.numRows += numRows
+	return nil
+}
+
+// flushFullColumns writes data pages for the columns of w which have buffered
+// more values than fit in a page.
+func (w *writer) flushFullColumns() error {
+	for _, c := range w.columns {
+		if c.columnBuffer.Size() >= int64(c.bufferSize) {
+			return w.forEachColumn(func(_ int, c *writerColumn) error {
+				if c.columnBuffer.Size() >= int64(c.bufferSize) {
+					return c.flush()
+				}
+				return nil
+			})
+		}
+	}
+	return nil
+}
+
+// forEachColumn calls fn for each column of w, using up to w.concurrency
+// goroutines. The columns do not share state when the writer is concurrent,
+// fn may only mutate the column it receives.
+//
+// The returned error is the error of the first column which failed, in column
+// order, so it does not depend on the scheduling of the goroutines.
+func (w *writer) forEachColumn(fn func(int, *writerColumn) error) error {
+	concurrency := w.concurrency
+	if concurrency > len(w.columns) {
+		concurrency = len(w.columns)
+	}
+	if concurrency <= 1 {
+		for i, c := range w.columns {
+			if err := fn(i, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(w.columns))
+	next := int64(-1)
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+
+	for n := 0; n < concurrency; n++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(w.columns) {
+					return
+				}
+				errs[i] = fn(i, w.columns[i])
+			}
+		}()
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // The WriteValues method is intended to work in pair with WritePage to allow
 // programs to target writing values to specific columns of of the writer.
 func (w *writer) WriteValues(values []Value) (numValues int, err error) {
@@ -1017,7 +1392,8 @@ func (w *writer) WriteValues(values []Value) (numValues int, err error) {
 // One writerBuffers is used by each writer instance, the memory buffers here
 // are shared by all columns of the writer because serialization is not done
 // concurrently, which helps keep memory utilization low, both in the total
-// footprint and GC cost.
+// footprint and GC cost. Writers configured with a ColumnWriteConcurrency
+// greater than one allocate a writerBuffers for each column instead.
 //
 // The type also exposes helper methods to facilitate the generation of parquet
 // pages. A scratch space is used when serialization requires combining multiple
@@ -1133,19 +1509,40 @@ type writerColumn struct {
 		encoder  thrift.Encoder
 	}
 
-	filter         []byte
-	numRows        int64
-	bufferIndex    int32
-	bufferSize     int32
-	writePageStats bool
-	isCompressed   bool
-	encodings      []format.Encoding
+	filter             []byte
+	numRows            int64
+	bufferIndex        int32
+	bufferSize         int32
+	writePageStats     bool
+	writePageChecksums bool
+	isCompressed       bool
+	encodings          []format.Encoding
+	// Set when the column type defines no sort order, the min and max values
+	// of the column are then omitted from the statistics.
+	unordered bool
 
 	columnChunk *format.ColumnChunk
 	offsetIndex *format.OffsetIndex
+
+	// When the dictionary grows past maxDictionarySize, the column writes the
+	// rest of the column chunk with the plain encoding and values of type
+	// plainType; the dictionary encoding is restored when the column is reset.
+	maxDictionarySize  int64
+	plainType          Type
+	dictionaryEncoding encoding.Encoding
+	dictionaryFallback bool
+
+	// Column chunk of a parquet file copied verbatim to the next row group,
+	// see Rewrite.
+	copied *copiedColumnChunk
 }
 
 func (c *writerColumn) reset() {
+	if c.copied != nil {
+		c.columnChunk.MetaData = c.copied.metaData
+		c.offsetIndex.PageLocations = c.copied.pageLocations
+		c.copied = nil
+	}
 	if c.columnBuffer != nil {
 		c.columnBuffer.Reset()
 	}
@@ -1155,6 +1552,13 @@ func (c *writerColumn) reset() {
 	if c.dictionary != nil {
 		c.dictionary.Reset()
 	}
+	if c.dictionaryFallback {
+		c.dictionaryFallback = false
+		c.columnType = c.dictionary.Type()
+		c.encoding = c.dictionaryEncoding
+		c.isCompressed = isCompressed(c.compression) && c.dataPageType != format.DataPageV2
+		c.columnBuffer = c.newColumnBuffer()
+	}
 	for _, page := range c.pages {
 		c.pool.PutBuffer(page)
 	}
@@ -1188,13 +1592,27 @@ func (c *writerColumn) totalRowCount() int64 {
 }
 
 func (c *writerColumn) flush() (err error) {
-	if c.columnBuffer.Len() > 0 {
-		defer c.columnBuffer.Reset()
+	if c.columnBuffer != nil && c.columnBuffer.Len() > 0 {
 		_, err = c.writeDataPage(c.columnBuffer.Page())
+		c.columnBuffer.Reset()
+		if err == nil && c.dictionary != nil && !c.dictionaryFallback && c.dictionary.Page().Size() > c.maxDictionarySize {
+			c.fallbackToPlainEncoding()
+		}
 	}
 	return err
 }
 
+// fallbackToPlainEncoding configures c to write the next pages of the column
+// chunk with the plain encoding, leaving the dictionary unchanged so it can
+// still be used to write the dictionary page.
+func (c *writerColumn) fallbackToPlainEncoding() {
+	c.dictionaryFallback = true
+	c.columnType = c.plainType
+	c.encoding = &Plain
+	c.isCompressed = isCompressed(c.compression)
+	c.columnBuffer = c.newColumnBuffer()
+}
+
 func (c *writerColumn) flushFilterPages() error {
 	if c.columnFilter == nil {
 		return nil
@@ -1202,7 +1620,7 @@ func (c *writerColumn) flushFilterPages() error {
 
 	// If there is a dictionary, it contains all the values that we need to
 	// write to the filter.
-	if dict := c.dictionary; dict != nil {
+	if dict := c.dictionary; dict != nil && !c.dictionaryFallback {
 		// Need to always attempt to resize the filter, as the writer might
 		// be reused after resetting which would have reset the length of
 		// the filter to 0.
@@ -1213,6 +1631,9 @@ func (c *writerColumn) flushFilterPages() error {
 	// When the filter was already allocated, pages have been written to it as
 	// they were seen by the column writer.
 	if len(c.filter) > 0 {
+		if c.dictionaryFallback {
+			return c.writePageToFilter(c.dictionary.Page())
+		}
 		return nil
 	}
 
@@ -1229,6 +1650,14 @@ func (c *writerColumn) flushFilterPages() error {
 	// systems are getting OOM-Killed.
 	c.resizeBloomFilter(c.columnChunk.MetaData.NumValues)
 
+	// When the column stopped using its dictionary, it contains the values of
+	// the pages which were written before, the other pages are decoded.
+	if c.dictionaryFallback {
+		if err := c.writePageToFilter(c.dictionary.Page()); err != nil {
+			return err
+		}
+	}
+
 	column := &Column{
 		// Set all the fields required by the decodeDataPage* methods.
 		typ:                c.columnType,
@@ -1274,8 +1703,14 @@ func (c *writerColumn) flushFilterPages() error {
 
 		switch header.Type {
 		case format.DataPage:
+			if isDictionaryFormat(header.DataPageHeader.Encoding) {
+				continue
+			}
 			page, err = column.decodeDataPageV1(DataPageHeaderV1{header.DataPageHeader}, pbuf, nil, header.UncompressedPageSize)
 		case format.DataPageV2:
+			if isDictionaryFormat(header.DataPageHeaderV2.Encoding) {
+				continue
+			}
 			page, err = column.decodeDataPageV2(DataPageHeaderV2{header.DataPageHeaderV2}, pbuf, nil, header.UncompressedPageSize)
 		}
 		if page != nil {
@@ -1328,6 +1763,122 @@ func (c *writerColumn) writeRows(rows []Value) error {
 	return nil
 }
 
+// writeColumnChunk writes all the values of chunk to c.
+//
+// The page of a column buffer is encoded directly when it has the type of the
+// column, see writePage. Otherwise, the values of the pages are written in
+// batches holding complete rows so the column does not flush pages in the
+// middle of a row of repeated values.
+func (c *writerColumn) writeColumnChunk(chunk ColumnChunk) error {
+	if buffer, ok := chunk.(ColumnBuffer); ok {
+		if page := buffer.Page(); c.canWritePage(page) {
+			return c.writePage(page)
+		}
+	}
+
+	pages := chunk.Pages()
+	defer pages.Close()
+
+	buffer := make([]Value, defaultValueBufferSize)
+	n := 0
+
+	for {
+		page, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		values := page.Values()
+		for {
+			r, err := values.ReadValues(buffer[n:])
+			n += r
+
+			i := n
+			if c.maxRepetitionLevel > 0 {
+				// Retain the values of the last row, it may continue with the
+				// next values.
+				for i > 0 && buffer[i-1].repetitionLevel != 0 {
+					i--
+				}
+				if i > 0 {
+					i--
+				}
+			}
+
+			if i > 0 {
+				if err := c.writeRows(buffer[:i]); err != nil {
+					return err
+				}
+				n = copy(buffer, buffer[i:n])
+			} else if n == len(buffer) {
+				buffer = append(buffer, make([]Value, len(buffer))...)
+			}
+
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				Release(page)
+				return err
+			}
+		}
+
+		// The values retained for the next batch may reference the memory of
+		// the page, which is reused after being released.
+		for i := range buffer[:n] {
+			buffer[i] = buffer[i].Clone()
+		}
+		Release(page)
+	}
+
+	if n > 0 {
+		return c.writeRows(buffer[:n])
+	}
+	return nil
+}
+
+// canWritePage returns true if page can be encoded by c without decoding its
+// values, which requires the page to have the physical type of the column and
+// the column to not be dictionary encoded.
+func (c *writerColumn) canWritePage(page Page) bool {
+	if c.dictionary != nil || page.Dictionary() != nil {
+		return false
+	}
+	typ := page.Type()
+	return typ.Kind() == c.columnType.Kind() && typ.Length() == c.columnType.Length()
+}
+
+// writePage encodes page to data pages of c, splitting it in pages of about
+// the page buffer size of the column. The page must hold complete rows.
+func (c *writerColumn) writePage(page Page) error {
+	if err := c.flush(); err != nil {
+		return err
+	}
+
+	numRows := page.NumRows()
+	rowsPerPage := numRows
+	if size := page.Size(); size > int64(c.bufferSize) {
+		rowsPerPage = numRows * int64(c.bufferSize) / size
+		if rowsPerPage < 1 {
+			rowsPerPage = 1
+		}
+	}
+
+	for i := int64(0); i < numRows; i += rowsPerPage {
+		j := i + rowsPerPage
+		if j > numRows {
+			j = numRows
+		}
+		if _, err := c.writeDataPage(page.Slice(i, j)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *writerColumn) WriteValues(values []Value) (numValues int, err error) {
 	if c.columnBuffer == nil {
 		c.columnBuffer = c.newColumnBuffer()
@@ -1366,7 +1917,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		return 0, fmt.Errorf("encoding parquet data page: %w", err)
 	}
 	if c.dataPageType == format.DataPage {
-		buf.prependLevelsToDataPageV1(c.maxDefinitionLevel, c.maxDefinitionLevel)
+		buf.prependLevelsToDataPageV1(c.maxRepetitionLevel, c.maxDefinitionLevel)
 	}
 
 	uncompressedPageSize := buf.size()
@@ -1399,7 +1950,7 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 		Type:                 c.dataPageType,
 		UncompressedPageSize: int32(uncompressedPageSize),
 		CompressedPageSize:   int32(buf.size()),
-		CRC:                  int32(buf.crc32()),
+		CRC:                  c.pageChecksum(buf),
 	}
 
 	numRows := page.NumRows()
@@ -1459,6 +2010,15 @@ func (c *writerColumn) writeDataPage(page Page) (int64, error) {
 	return numValues, nil
 }
 
+// pageChecksum returns the checksum to store in the header of the page held in
+// buf, or zero if the column does not write page checksums.
+func (c *writerColumn) pageChecksum(buf *writerBuffers) int32 {
+	if !c.writePageChecksums {
+		return 0
+	}
+	return int32(buf.crc32())
+}
+
 func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (err error) {
 	buf := c.buffers
 	buf.reset()
@@ -1481,7 +2041,7 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) (e
 		Type:                 format.DictionaryPage,
 		UncompressedPageSize: int32(uncompressedPageSize),
 		CompressedPageSize:   int32(buf.size()),
-		CRC:                  int32(buf.crc32()),
+		CRC:                  c.pageChecksum(buf),
 		DictionaryPageHeader: &format.DictionaryPageHeader{
 			NumValues: int32(dict.Len()),
 			Encoding:  format.Plain,
@@ -1538,6 +2098,9 @@ func (c *writerColumn) writePageTo(size int64, writeTo func(io.Writer) (int64, e
 
 func (c *writerColumn) makePageStatistics(page Page) format.Statistics {
 	numNulls := page.NumNulls()
+	if c.unordered {
+		return format.Statistics{NullCount: numNulls}
+	}
 	minValue, maxValue, _ := page.Bounds()
 	minValueBytes := minValue.Bytes()
 	maxValueBytes := maxValue.Bytes()
@@ -1558,6 +2121,9 @@ func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHead
 		numNulls := page.NumNulls()
 		numValues := page.NumValues()
 		minValue, maxValue, pageHasBounds := page.Bounds()
+		if c.unordered {
+			minValue, maxValue, pageHasBounds = Value{}, Value{}, false
+		}
 		c.columnIndex.IndexPage(numValues, numNulls, minValue, maxValue)
 		c.columnChunk.MetaData.NumValues += numValues
 		c.columnChunk.MetaData.Statistics.NullCount += numNulls
@@ -2,14 +2,18 @@ package parquet_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"os"
 	"os/exec"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hexops/gotextdiff"
@@ -18,6 +22,9 @@ import (
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/gzip"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+	"github.com/parquet-go/parquet-go/format"
 )
 
 const (
@@ -985,6 +992,189 @@ func TestWriterGenerateBloomFilters(t *testing.T) {
 	}
 }
 
+func TestWriterBloomFilterTag(t *testing.T) {
+	type User struct {
+		Name   string `parquet:"name"`
+		UserID string `parquet:"user_id,bloom"`
+		Email  string `parquet:"email,bloom(0.001)"`
+		Nested struct {
+			Score int64 `parquet:"score,bloom"`
+		} `parquet:"nested"`
+	}
+
+	rows := make([]User, 1000)
+	for i := range rows {
+		rows[i].Name = fmt.Sprintf("name-%d", i)
+		rows[i].UserID = fmt.Sprintf("user-%d", i)
+		rows[i].Email = fmt.Sprintf("user-%d@example.com", i)
+		rows[i].Nested.Score = int64(i)
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	if columns[0].BloomFilter() != nil {
+		t.Error(`"name" column has a bloom filter even though none were declared`)
+	}
+
+	for i, column := range columns[1:] {
+		bloomFilter := column.BloomFilter()
+		if bloomFilter == nil {
+			t.Fatalf("column %d has no bloom filter despite being tagged", i+1)
+		}
+		for _, row := range rows {
+			value := [...]parquet.Value{
+				parquet.ValueOf(row.UserID),
+				parquet.ValueOf(row.Email),
+				parquet.ValueOf(row.Nested.Score),
+			}[i]
+			if ok, err := bloomFilter.Check(value); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatalf("bloom filter of column %d does not contain value %v", i+1, value)
+			}
+		}
+	}
+
+	// A lower false positive rate requires a larger filter.
+	if userIDSize, emailSize := columns[1].BloomFilter().Size(), columns[2].BloomFilter().Size(); userIDSize >= emailSize {
+		t.Errorf("bloom filter of email column should be larger than the user_id one: %d >= %d", emailSize, userIDSize)
+	}
+
+	// The default false positive rate is the 1% of the sizing table.
+	type Account struct {
+		Default string `parquet:"default,bloom"`
+		Rate    string `parquet:"rate,bloom(0.01)"`
+	}
+	accounts := make([]Account, len(rows))
+	for i, row := range rows {
+		accounts[i] = Account{Default: row.UserID, Rate: row.UserID}
+	}
+	buffer.Reset()
+	if err := parquet.Write(buffer, accounts); err != nil {
+		t.Fatal(err)
+	}
+	f, err = parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns = f.RowGroups()[0].ColumnChunks()
+	if defaultSize, rateSize := columns[0].BloomFilter().Size(), columns[1].BloomFilter().Size(); defaultSize != rateSize {
+		t.Errorf("bloom filter declared without a false positive rate should have the size of a 1%% rate: %d != %d", defaultSize, rateSize)
+	}
+}
+
+func TestWriterBloomFilterTagInvalid(t *testing.T) {
+	tests := []struct {
+		scenario string
+		model    interface{}
+	}{
+		{
+			scenario: "false positive rate out of range",
+			model: new(struct {
+				ID string `parquet:"id,bloom(2)"`
+			}),
+		},
+		{
+			scenario: "malformed false positive rate",
+			model: new(struct {
+				ID string `parquet:"id,bloom(x)"`
+			}),
+		},
+		{
+			scenario: "non-leaf column",
+			model: new(struct {
+				Group struct{ ID string } `parquet:"group,bloom"`
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic for an invalid bloom tag")
+				}
+			}()
+			parquet.SchemaOf(test.model)
+		})
+	}
+}
+
+func TestBloomFilterCheck(t *testing.T) {
+	type Row struct {
+		Bytes []byte   `parquet:"bytes,bloom"`
+		Fixed [16]byte `parquet:"fixed,bloom"`
+		Int32 int32    `parquet:"int32,bloom"`
+		Int64 int64    `parquet:"int64,bloom"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			Bytes: []byte(fmt.Sprintf("bytes-%d", i)),
+			Fixed: [16]byte{byte(i)},
+			Int32: int32(i),
+			Int64: int64(i),
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := parquet.Write(buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := f.RowGroups()[0].ColumnChunks()
+
+	for _, row := range rows {
+		values := []parquet.Value{
+			parquet.ValueOf(row.Bytes),
+			parquet.ValueOf(row.Fixed),
+			parquet.ValueOf(row.Int32),
+			parquet.ValueOf(row.Int64),
+		}
+		for i, value := range values {
+			if ok, err := columns[i].BloomFilter().Check(value); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatalf("bloom filter of column %d does not contain value %v", i, value)
+			}
+		}
+	}
+
+	mismatches := []struct {
+		column int
+		value  parquet.Value
+	}{
+		{column: 0, value: parquet.ValueOf(int64(1))},
+		{column: 1, value: parquet.ValueOf([4]byte{})},
+		{column: 2, value: parquet.ValueOf(int64(1))},
+		{column: 3, value: parquet.ValueOf(int32(1))},
+	}
+	for _, mismatch := range mismatches {
+		if _, err := columns[mismatch.column].BloomFilter().Check(mismatch.value); err == nil {
+			t.Errorf("expected an error checking bloom filter of column %d with value %v", mismatch.column, mismatch.value)
+		}
+	}
+
+	if ok, err := columns[0].BloomFilter().Check(parquet.NullValue()); err != nil {
+		t.Error(err)
+	} else if ok {
+		t.Error("null values must never be present in bloom filters")
+	}
+}
+
 func TestBloomFilterForDict(t *testing.T) {
 	type testStruct struct {
 		A string `parquet:"a,dict"`
@@ -1090,6 +1280,75 @@ func TestWriterResetWithBloomFilters(t *testing.T) {
 	}
 }
 
+func TestGenericWriterReset(t *testing.T) {
+	type Row struct {
+		ID   int64    `parquet:"id"`
+		Name string   `parquet:"name,dict"`
+		Tags []string `parquet:"tags"`
+	}
+
+	files := [][]Row{
+		{{ID: 1, Name: "a", Tags: []string{}}, {ID: 2, Name: "b", Tags: []string{"x"}}, {ID: 3, Name: "c", Tags: []string{}}},
+		{{ID: 4, Name: "d", Tags: []string{"y", "z"}}},
+		{{ID: 5, Name: "a", Tags: []string{}}, {ID: 6, Name: "e", Tags: []string{}}},
+	}
+
+	outputs := make([]*bytes.Buffer, len(files))
+	for i := range outputs {
+		outputs[i] = new(bytes.Buffer)
+	}
+
+	w := parquet.NewGenericWriter[Row](outputs[0],
+		parquet.MaxRowsPerRowGroup(2),
+		parquet.KeyValueMetadata("key", "value"),
+	)
+	schema := w.Schema()
+
+	// Rows written before resetting the writer are discarded.
+	if _, err := w.Write([]Row{{ID: -1}}); err != nil {
+		t.Fatal(err)
+	}
+	w.Reset(outputs[0])
+
+	for i, rows := range files {
+		if i > 0 {
+			w.Reset(outputs[i])
+		}
+		if _, err := w.Write(rows); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if w.Schema() != schema {
+		t.Error("the schema of the writer changed after resetting it")
+	}
+
+	for i, output := range outputs {
+		f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		if errs := parquet.Validate(f); len(errs) != 0 {
+			t.Errorf("file %d: %v", i, errs)
+		}
+		if value, ok := f.Lookup("key"); !ok || value != "value" {
+			t.Errorf("file %d: missing key/value metadata", i)
+		}
+		if numRowGroups, want := len(f.RowGroups()), (len(files[i])+1)/2; numRowGroups != want {
+			t.Errorf("file %d: wrong number of row groups: want=%d got=%d", i, want, numRowGroups)
+		}
+
+		rows, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+		if err != nil {
+			t.Fatalf("file %d: %v", i, err)
+		}
+		assertRowsEqual(t, files[i], rows)
+	}
+}
+
 func TestWriterMaxRowsPerRowGroup(t *testing.T) {
 	output := new(bytes.Buffer)
 	writer := parquet.NewWriter(output, parquet.MaxRowsPerRowGroup(10))
@@ -1119,6 +1378,208 @@ func TestWriterMaxRowsPerRowGroup(t *testing.T) {
 	}
 }
 
+func TestWriterMaxRowGroupBytes(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id"`
+		Payload []byte `parquet:"payload"`
+	}
+
+	const maxRowGroupBytes = 16 * 1024
+
+	rows := make([]Row, 2000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Payload: bytes.Repeat([]byte{byte(i)}, 1+(i*37)%100)}
+	}
+
+	bigRows := make([]Row, 5)
+	for i := range bigRows {
+		bigRows[i] = Row{ID: int64(i), Payload: bytes.Repeat([]byte{byte(i)}, 2*maxRowGroupBytes)}
+	}
+
+	writeRows := func(w *parquet.GenericWriter[Row], rows []Row) error {
+		_, err := w.Write(rows)
+		return err
+	}
+	writeRowGroup := func(w *parquet.GenericWriter[Row], rows []Row) error {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows); err != nil {
+			return err
+		}
+		_, err := w.WriteRowGroup(buffer)
+		return err
+	}
+
+	tests := []struct {
+		scenario string
+		rows     []Row
+		write    func(*parquet.GenericWriter[Row], []Row) error
+		maxRows  int64
+	}{
+		{"rows", rows, writeRows, 0},
+		{"row group", rows, writeRowGroup, 0},
+		{"rows larger than the target", bigRows, writeRows, 0},
+		{"with max rows per row group", rows, writeRows, 100},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := parquet.NewGenericWriter[Row](buf,
+				parquet.MaxRowGroupBytes(maxRowGroupBytes),
+				parquet.MaxRowsPerRowGroup(test.maxRows),
+				parquet.PageBufferSize(1024),
+			)
+			if err := test.write(w, test.rows); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rowGroups := f.Metadata().RowGroups
+			if len(rowGroups) < 2 {
+				t.Fatalf("expected multiple row groups, got %d", len(rowGroups))
+			}
+			if test.maxRows == 0 && len(test.rows) == len(bigRows) && len(rowGroups) != len(bigRows) {
+				t.Errorf("rows larger than the target must be written to their own row group: got %d row groups", len(rowGroups))
+			}
+
+			for i, rowGroup := range rowGroups {
+				if rowGroup.NumRows == 0 {
+					t.Errorf("row group %d is empty", i)
+				}
+				if rowGroup.NumRows > 1 && rowGroup.TotalCompressedSize > 2*maxRowGroupBytes {
+					t.Errorf("row group %d of %d rows is too large: %d bytes", i, rowGroup.NumRows, rowGroup.TotalCompressedSize)
+				}
+				if test.maxRows > 0 && rowGroup.NumRows > test.maxRows {
+					t.Errorf("row group %d has too many rows: %d", i, rowGroup.NumRows)
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertRowsEqual(t, test.rows, got)
+		})
+	}
+}
+
+func TestWriterAssertSorted(t *testing.T) {
+	type Row struct {
+		Key   string `parquet:"key"`
+		Value int64  `parquet:"value"`
+	}
+
+	newWriter := func(output io.Writer, assert bool) *parquet.GenericWriter[Row] {
+		return parquet.NewGenericWriter[Row](output,
+			parquet.AssertSorted(assert),
+			parquet.MaxRowsPerRowGroup(3),
+			parquet.SortingWriterConfig(
+				parquet.SortingColumns(
+					parquet.Ascending("key"),
+					parquet.Descending("value"),
+				),
+			),
+		)
+	}
+
+	sorted := []Row{
+		{"a", 3}, {"a", 2}, {"a", 2}, {"b", 9}, {"c", 1}, {"c", 0}, {"d", 5},
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		w := newWriter(buf, true)
+		for i := range sorted {
+			if _, err := w.Write(sorted[i : i+1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRowsEqual(t, sorted, got)
+	})
+
+	unsorted := []Row{
+		{"a", 3}, {"b", 1}, {"b", 1}, {"b", 2}, {"c", 0},
+	}
+
+	t.Run("write", func(t *testing.T) {
+		w := newWriter(io.Discard, true)
+		if _, err := w.Write(unsorted[:2]); err != nil {
+			t.Fatal(err)
+		}
+		n, err := w.Write(unsorted[2:])
+		if !errors.Is(err, parquet.ErrRowsNotSorted) {
+			t.Fatalf("expected ErrRowsNotSorted, got %v", err)
+		}
+		if n != 1 {
+			t.Errorf("wrong number of rows written: want=1 got=%d", n)
+		}
+		if !strings.Contains(err.Error(), "row 3 ") {
+			t.Errorf("the error does not name the index of the unsorted row: %v", err)
+		}
+	})
+
+	t.Run("write rows", func(t *testing.T) {
+		schema := parquet.SchemaOf(new(Row))
+		rows := make([]parquet.Row, len(unsorted))
+		for i := range unsorted {
+			rows[i] = schema.Deconstruct(nil, &unsorted[i])
+		}
+		w := newWriter(io.Discard, true)
+		n, err := w.WriteRows(rows)
+		if !errors.Is(err, parquet.ErrRowsNotSorted) {
+			t.Fatalf("expected ErrRowsNotSorted, got %v", err)
+		}
+		if n != 3 {
+			t.Errorf("wrong number of rows written: want=3 got=%d", n)
+		}
+	})
+
+	t.Run("write row group", func(t *testing.T) {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(unsorted); err != nil {
+			t.Fatal(err)
+		}
+		w := newWriter(io.Discard, true)
+		if _, err := w.WriteRowGroup(buffer); !errors.Is(err, parquet.ErrRowsNotSorted) {
+			t.Fatalf("expected ErrRowsNotSorted, got %v", err)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		w := newWriter(io.Discard, true)
+		if _, err := w.Write(sorted[len(sorted)-1:]); err != nil {
+			t.Fatal(err)
+		}
+		w.Reset(io.Discard)
+		if _, err := w.Write(sorted[:1]); err != nil {
+			t.Fatalf("rows of the previous file must not be compared after a reset: %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w := newWriter(io.Discard, false)
+		if _, err := w.Write(unsorted); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
 func TestSetKeyValueMetadata(t *testing.T) {
 	testKey := "test-key"
 	testValue := "test-value"
@@ -1203,3 +1664,1556 @@ func TestSetKeyValueMetadataOverwritesExisting(t *testing.T) {
 		t.Errorf("expected %q, got %q", testValue, value)
 	}
 }
+
+func TestWriterLz4RawMultiplePages(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,lz4raw"`
+		Name string `parquet:"name,lz4raw"`
+	}
+
+	rows := make([]Row, 10e3)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewGenericWriter[Row](buffer, parquet.PageBufferSize(1024))
+	if _, err := writer.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rowGroup := range f.Metadata().RowGroups {
+		for _, chunk := range rowGroup.Columns {
+			if codec := chunk.MetaData.Codec; codec != format.Lz4Raw {
+				t.Errorf("wrong compression codec for column %q: want=%v got=%v", chunk.MetaData.PathInSchema, format.Lz4Raw, codec)
+			}
+		}
+	}
+
+	for _, columnChunk := range f.RowGroups()[0].ColumnChunks() {
+		if numPages := columnChunk.OffsetIndex().NumPages(); numPages < 2 {
+			t.Errorf("column %d was expected to span multiple pages but has %d", columnChunk.Column(), numPages)
+		}
+	}
+
+	read, err := parquet.Read[Row](bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, read)
+}
+
+func TestGenericWriterWriteRowGroup(t *testing.T) {
+	type Row struct {
+		ID       int64    `parquet:"id"`
+		Name     string   `parquet:"name,dict"`
+		Tags     []string `parquet:"tags"`
+		Optional *int32   `parquet:"optional"`
+	}
+
+	makeRows := func(offset, count int) []Row {
+		rows := make([]Row, count)
+		for i := range rows {
+			id := offset + i
+			rows[i] = Row{ID: int64(id), Name: fmt.Sprintf("name-%d", id%7), Tags: []string{}}
+			for j := 0; j < id%4; j++ {
+				rows[i].Tags = append(rows[i].Tags, fmt.Sprintf("tag-%d-%d", id, j))
+			}
+			if id%3 == 0 {
+				v := int32(id)
+				rows[i].Optional = &v
+			}
+		}
+		return rows
+	}
+
+	tests := []struct {
+		scenario     string
+		options      []parquet.WriterOption
+		numRowGroups int
+	}{
+		{
+			scenario:     "column chunks",
+			options:      []parquet.WriterOption{parquet.PageBufferSize(512)},
+			numRowGroups: 3,
+		},
+		{
+			scenario: "rows",
+			options: []parquet.WriterOption{
+				parquet.PageBufferSize(512),
+				parquet.MaxRowsPerRowGroup(400),
+			},
+			numRowGroups: 9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](output, test.options...)
+
+			var want []Row
+			for i := 0; i < 3; i++ {
+				rows := makeRows(1000*i, 1000)
+				want = append(want, rows...)
+
+				buffer := parquet.NewGenericBuffer[Row]()
+				if _, err := buffer.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				n, err := writer.WriteRowGroup(buffer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != int64(len(rows)) {
+					t.Errorf("wrong number of rows written: want=%d got=%d", len(rows), n)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.RowGroups()); n != test.numRowGroups {
+				t.Errorf("wrong number of row groups: want=%d got=%d", test.numRowGroups, n)
+			}
+			if n := f.RowGroups()[0].ColumnChunks()[0].OffsetIndex().NumPages(); n < 2 {
+				t.Errorf("expected multiple pages per column chunk but got %d", n)
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(got))
+			}
+			for i := range want {
+				if !reflect.DeepEqual(want[i], got[i]) {
+					t.Fatalf("rows mismatch at index %d: want=%+v got=%+v", i, want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGenericWriterWriteRowGroupCopiesColumnChunks(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,snappy"`
+		Name string `parquet:"name,dict"`
+	}
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i%7)}
+	}
+
+	input := new(bytes.Buffer)
+	if err := parquet.Write(input, rows); err != nil {
+		t.Fatal(err)
+	}
+	src := openFile(t, input.Bytes())
+
+	firstPageCRC := func(f *parquet.File, column int) int32 {
+		pages := parquet.NewRawPageReader(f.RowGroups()[0].ColumnChunks()[column])
+		defer pages.Close()
+		header, _, err := pages.ReadRawPage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return header.CRC
+	}
+
+	// The writer does not write checksums, the pages which have checksums in
+	// the output were copied from the input file.
+	output := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](output, parquet.PageChecksums(false), parquet.Compression(&parquet.Zstd))
+	if _, err := w.WriteRowGroup(src.RowGroups()[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	dst := openFile(t, output.Bytes())
+
+	if crc := firstPageCRC(dst, 0); crc == 0 {
+		t.Error("the column chunk using the codec of the writer was not copied")
+	}
+	if crc := firstPageCRC(dst, 1); crc != 0 {
+		t.Errorf("the column chunk using another codec was copied: 0x%08X", crc)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+}
+
+func TestWriterByteStreamSplit(t *testing.T) {
+	type Row struct {
+		Float32 float32 `parquet:"float32,split,zstd"`
+		Float64 float64 `parquet:"float64,split,zstd"`
+		Option  float64 `parquet:"option,zstd"`
+	}
+
+	specials := []float64{
+		0,
+		math.Copysign(0, -1),
+		math.NaN(),
+		math.Float64frombits(0x7ff8000000000001), // NaN with payload
+		math.Inf(+1),
+		math.Inf(-1),
+		math.SmallestNonzeroFloat64,
+		-math.SmallestNonzeroFloat64,
+		math.MaxFloat64,
+		1.0 / 3,
+	}
+
+	rows := make([]Row, 0, 1000)
+	for i := 0; i < cap(rows); i++ {
+		f64 := specials[i%len(specials)]
+		f32 := float32(f64)
+		switch i % len(specials) {
+		case 6:
+			f32 = math.SmallestNonzeroFloat32
+		case 7:
+			f32 = math.Float32frombits(0x007fffff) // largest subnormal
+		}
+		if i >= len(specials) {
+			f64 += float64(i)
+			f32 += float32(i)
+		}
+		rows = append(rows, Row{Float32: f32, Float64: f64, Option: f64})
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.ColumnEncoding("option", &parquet.ByteStreamSplit))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, chunk := range f.Metadata().RowGroups[0].Columns {
+		found := false
+		for _, enc := range chunk.MetaData.Encoding {
+			found = found || enc == format.ByteStreamSplit
+		}
+		if !found {
+			t.Errorf("column %q is not encoded with BYTE_STREAM_SPLIT: %v", chunk.MetaData.PathInSchema, chunk.MetaData.Encoding)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+	}
+	for i := range rows {
+		if math.Float32bits(rows[i].Float32) != math.Float32bits(got[i].Float32) {
+			t.Errorf("float32 mismatch at row %d: want=%08x got=%08x", i, math.Float32bits(rows[i].Float32), math.Float32bits(got[i].Float32))
+		}
+		if math.Float64bits(rows[i].Float64) != math.Float64bits(got[i].Float64) {
+			t.Errorf("float64 mismatch at row %d: want=%016x got=%016x", i, math.Float64bits(rows[i].Float64), math.Float64bits(got[i].Float64))
+		}
+		if math.Float64bits(rows[i].Option) != math.Float64bits(got[i].Option) {
+			t.Errorf("option mismatch at row %d: want=%016x got=%016x", i, math.Float64bits(rows[i].Option), math.Float64bits(got[i].Option))
+		}
+	}
+}
+
+func TestWriterByteStreamSplitCompressionLevel(t *testing.T) {
+	type Row struct {
+		Split float64 `parquet:"split,split,zstd:7"`
+		Plain float64 `parquet:"plain,zstd:7"`
+	}
+
+	// Measurements of a slowly varying signal, of which the exponent and high
+	// bytes of the mantissa rarely change.
+	rows := make([]Row, 10000)
+	for i := range rows {
+		v := 1000 + 10*math.Sin(float64(i)/100)
+		rows[i] = Row{Split: v, Plain: v}
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	for _, column := range []string{"split", "plain"} {
+		leaf, _ := schema.Lookup(column)
+		if codec := leaf.Node.Compression(); codec.CompressionCodec() != format.Zstd {
+			t.Errorf("column %q is not compressed with zstd: %v", column, codec)
+		}
+		if level := leaf.Node.Compression().(*zstd.Codec).Level; level != zstd.LevelFromZstd(7) {
+			t.Errorf("wrong zstd level of column %q: %v", column, level)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := openFile(t, buf.Bytes())
+	encodings := parquet.ColumnChunkEncodings(f.RowGroups()[0].ColumnChunks()[0])
+	if len(encodings) != 1 || encodings[0].Encoding() != format.ByteStreamSplit {
+		t.Errorf("wrong encodings of the split column: %v", encodings)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		if math.Float64bits(got[i].Split) != math.Float64bits(rows[i].Split) {
+			t.Fatalf("split value mismatch at index %d: want=%v got=%v", i, rows[i].Split, got[i].Split)
+		}
+	}
+
+	stats := w.Stats()
+	split, plain := stats.Columns[0], stats.Columns[1]
+	t.Logf("compressed sizes: split+zstd:7=%d plain+zstd:7=%d", split.CompressedSize, plain.CompressedSize)
+	if split.CompressedSize >= plain.CompressedSize {
+		t.Errorf("expected BYTE_STREAM_SPLIT to improve the compression of zstd: split=%d plain=%d", split.CompressedSize, plain.CompressedSize)
+	}
+}
+
+func TestWriterColumnEncodingInvalid(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when applying BYTE_STREAM_SPLIT to a string column")
+		}
+	}()
+	parquet.NewGenericWriter[Row](new(bytes.Buffer), parquet.ColumnEncoding("name", &parquet.ByteStreamSplit))
+}
+
+func TestWriterCompressionLevel(t *testing.T) {
+	type Row struct {
+		Archive []byte `parquet:"archive,zstd:19"`
+		Fast    []byte `parquet:"fast,zstd:1"`
+		Text    string `parquet:"text"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	for _, test := range []struct {
+		column string
+		level  zstd.Level
+	}{
+		{"archive", zstd.SpeedBestCompression},
+		{"fast", zstd.SpeedFastest},
+	} {
+		leaf, _ := schema.Lookup(test.column)
+		codec, ok := leaf.Node.Compression().(*zstd.Codec)
+		if !ok {
+			t.Fatalf("%s: wrong compression codec: %v", test.column, leaf.Node.Compression())
+		}
+		if codec.Level != test.level {
+			t.Errorf("%s: wrong compression level: want=%v got=%v", test.column, test.level, codec.Level)
+		}
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{
+			Archive: bytes.Repeat([]byte("archive"), i),
+			Fast:    bytes.Repeat([]byte("fast"), i),
+			Text:    strings.Repeat("text", i),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.ColumnCompression("text", &parquet.Gzip, gzip.BestCompression))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []format.CompressionCodec{format.Zstd, format.Zstd, format.Gzip}
+	for i, chunk := range f.Metadata().RowGroups[0].Columns {
+		if chunk.MetaData.Codec != want[i] {
+			t.Errorf("column %d: wrong codec: want=%v got=%v", i, want[i], chunk.MetaData.Codec)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		if !bytes.Equal(rows[i].Archive, got[i].Archive) || !bytes.Equal(rows[i].Fast, got[i].Fast) || rows[i].Text != got[i].Text {
+			t.Fatalf("row %d mismatch", i)
+		}
+	}
+}
+
+func TestWriterBooleanRLE(t *testing.T) {
+	type PlainRow struct {
+		Flag bool `parquet:"flag,uncompressed"`
+	}
+	type RLERow struct {
+		Flag bool `parquet:"flag,rle,uncompressed"`
+	}
+
+	rows := make([]RLERow, 10000)
+	for i := range rows {
+		rows[i].Flag = i%100 == 42
+	}
+	plainRows := make([]PlainRow, len(rows))
+	for i, row := range rows {
+		plainRows[i].Flag = row.Flag
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			plain := new(bytes.Buffer)
+			if err := parquet.Write(plain, plainRows, parquet.DataPageVersion(version)); err != nil {
+				t.Fatal(err)
+			}
+			rle := new(bytes.Buffer)
+			if err := parquet.Write(rle, rows, parquet.DataPageVersion(version)); err != nil {
+				t.Fatal(err)
+			}
+
+			sizeOf := func(b []byte) int64 {
+				f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return f.Metadata().RowGroups[0].Columns[0].MetaData.TotalUncompressedSize
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(rle.Bytes()), int64(rle.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			metadata := f.Metadata().RowGroups[0].Columns[0].MetaData
+			hasRLE := false
+			for _, e := range metadata.Encoding {
+				hasRLE = hasRLE || e == format.RLE
+			}
+			if !hasRLE {
+				t.Errorf("the column is not RLE encoded: %v", metadata.Encoding)
+			}
+
+			if plainSize, rleSize := sizeOf(plain.Bytes()), sizeOf(rle.Bytes()); rleSize >= plainSize {
+				t.Errorf("the RLE encoded column is not smaller than the plain encoded one: %d >= %d", rleSize, plainSize)
+			}
+
+			got, err := parquet.Read[RLERow](bytes.NewReader(rle.Bytes()), int64(rle.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertRowsEqual(t, rows, got)
+		})
+	}
+
+	t.Run("optional", func(t *testing.T) {
+		type OptionalRow struct {
+			Flag *bool `parquet:"flag,optional,rle"`
+		}
+		rows := make([]OptionalRow, 100)
+		for i := range rows {
+			if i%3 != 0 {
+				rows[i].Flag = newBool(i%2 == 0)
+			}
+		}
+
+		buf := new(bytes.Buffer)
+		if err := parquet.Write(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The definition levels are always RLE encoded, the encoding of the
+		// values is only reported by the encoding stats of data pages.
+		metadata := f.Metadata().RowGroups[0].Columns[0].MetaData
+		if stats := metadata.EncodingStats; len(stats) != 1 || stats[0].Encoding != format.RLE {
+			t.Errorf("the optional column is not RLE encoded: %+v", stats)
+		}
+
+		got, err := parquet.Read[OptionalRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Error("the optional boolean values were not read back exactly")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic using the rle tag on a non-boolean field")
+			}
+		}()
+		parquet.SchemaOf(struct {
+			Value int64 `parquet:"value,rle"`
+		}{})
+	})
+}
+
+func TestWriterOptionalBoolean(t *testing.T) {
+	type Row struct {
+		Plain *bool `parquet:"plain,optional"`
+		RLE   *bool `parquet:"rle,optional,rle"`
+	}
+
+	values := []*bool{new(bool), nil, newBool(true), newBool(true), nil, new(bool), nil, newBool(true)}
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Plain: values[i%len(values)], RLE: values[(i/3)%len(values)]}
+	}
+	nullCountOf := func(column func(Row) *bool) (n int64) {
+		for _, row := range rows {
+			if column(row) == nil {
+				n++
+			}
+		}
+		return n
+	}
+	nullCounts := []int64{
+		nullCountOf(func(row Row) *bool { return row.Plain }),
+		nullCountOf(func(row Row) *bool { return row.RLE }),
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, rows)
+			},
+		},
+		{
+			scenario: "rows",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, parquet.SchemaOf(Row{}))
+				for _, row := range rows {
+					if err := writer.Write(row); err != nil {
+						return err
+					}
+				}
+				return writer.Close()
+			},
+		},
+		{
+			scenario: "buffer",
+			write: func(w io.Writer) error {
+				buffer := parquet.NewGenericBuffer[Row]()
+				if _, err := buffer.Write(rows); err != nil {
+					return err
+				}
+				writer := parquet.NewGenericWriter[Row](w)
+				if _, err := writer.WriteRowGroup(buffer); err != nil {
+					return err
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, column := range f.Metadata().RowGroups[0].Columns {
+				if n := column.MetaData.Statistics.NullCount; n != nullCounts[i] {
+					t.Errorf("%s: wrong null count: want=%d got=%v", column.MetaData.PathInSchema, nullCounts[i], n)
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Error("the optional boolean values were not read back exactly")
+			}
+		})
+	}
+}
+
+func newBool(b bool) *bool { return &b }
+
+func newPointer[T any](v T) *T { return &v }
+
+func TestWriterOptionalScalarPointers(t *testing.T) {
+	type Row struct {
+		Bool      *bool          `parquet:"bool"`
+		Int       *int           `parquet:"int"`
+		Int8      *int8          `parquet:"int8"`
+		Int16     *int16         `parquet:"int16"`
+		Int32     *int32         `parquet:"int32"`
+		Int64     *int64         `parquet:"int64"`
+		Uint      *uint          `parquet:"uint"`
+		Uint8     *uint8         `parquet:"uint8"`
+		Uint16    *uint16        `parquet:"uint16"`
+		Uint32    *uint32        `parquet:"uint32"`
+		Uint64    *uint64        `parquet:"uint64"`
+		Float32   *float32       `parquet:"float32"`
+		Float64   *float64       `parquet:"float64"`
+		String    *string        `parquet:"string"`
+		Bytes     *[]byte        `parquet:"bytes"`
+		Array     *[4]byte       `parquet:"array"`
+		UUID      *uuid.UUID     `parquet:"uuid"`
+		Time      *time.Time     `parquet:"time"`
+		Duration  *time.Duration `parquet:"duration"`
+		Timestamp *int64         `parquet:"timestamp,timestamp"`
+		Millis    *time.Time     `parquet:"millis,timestamp(millisecond)"`
+		Date      *int32         `parquet:"date,date"`
+		Decimal   *int64         `parquet:"decimal,decimal(2:10)"`
+		Enum      *string        `parquet:"enum,enum"`
+		JSON      *string        `parquet:"json,json"`
+		Dict      *string        `parquet:"dict,dict"`
+		Delta     *int64         `parquet:"delta,delta"`
+		Split     *float64       `parquet:"split,split,zstd"`
+		Optional  *int32         `parquet:"optional,optional"`
+	}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	epoch := time.Unix(0, 0).UTC()
+	rows := []Row{
+		{
+			Bool: newPointer(true), Int: newPointer(-1), Int8: newPointer(int8(-2)), Int16: newPointer(int16(-3)),
+			Int32: newPointer(int32(-4)), Int64: newPointer(int64(-5)), Uint: newPointer(uint(6)), Uint8: newPointer(uint8(7)),
+			Uint16: newPointer(uint16(8)), Uint32: newPointer(uint32(9)), Uint64: newPointer(uint64(10)),
+			Float32: newPointer(float32(1.5)), Float64: newPointer(2.5), String: newPointer("string"),
+			Bytes: newPointer([]byte("bytes")), Array: newPointer([4]byte{1, 2, 3, 4}), UUID: newPointer(uuid.New()),
+			Time: &now, Duration: newPointer(time.Second), Timestamp: newPointer(int64(1000)), Millis: &now,
+			Date: newPointer(int32(19000)), Decimal: newPointer(int64(1234)), Enum: newPointer("enum"),
+			JSON: newPointer(`{"a":1}`), Dict: newPointer("dict"), Delta: newPointer(int64(42)),
+			Split: newPointer(3.5), Optional: newPointer(int32(11)),
+		},
+		{},
+		{
+			Bool: newPointer(false), Int: newPointer(0), Int8: newPointer(int8(0)), Int16: newPointer(int16(0)),
+			Int32: newPointer(int32(0)), Int64: newPointer(int64(0)), Uint: newPointer(uint(0)), Uint8: newPointer(uint8(0)),
+			Uint16: newPointer(uint16(0)), Uint32: newPointer(uint32(0)), Uint64: newPointer(uint64(0)),
+			Float32: newPointer(float32(0)), Float64: newPointer(0.0), String: newPointer(""),
+			Bytes: newPointer([]byte{}), Array: newPointer([4]byte{}), UUID: newPointer(uuid.UUID{}),
+			Time: &epoch, Duration: newPointer(time.Duration(0)), Timestamp: newPointer(int64(0)), Millis: &epoch,
+			Date: newPointer(int32(0)), Decimal: newPointer(int64(0)), Enum: newPointer(""),
+			JSON: newPointer(""), Dict: newPointer(""), Delta: newPointer(int64(0)),
+			Split: newPointer(0.0), Optional: newPointer(int32(0)),
+		},
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	for _, field := range schema.Fields() {
+		if !field.Optional() {
+			t.Errorf("pointer field %q is not an optional column", field.Name())
+		}
+	}
+
+	for _, test := range []struct {
+		scenario string
+		write    func(io.Writer) error
+	}{
+		{
+			scenario: "generic",
+			write: func(w io.Writer) error {
+				return parquet.Write(w, rows)
+			},
+		},
+		{
+			scenario: "rows",
+			write: func(w io.Writer) error {
+				writer := parquet.NewWriter(w, schema)
+				for _, row := range rows {
+					if err := writer.Write(row); err != nil {
+						return err
+					}
+				}
+				return writer.Close()
+			},
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := test.write(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			f := openFile(t, buf.Bytes())
+			for _, column := range f.Metadata().RowGroups[0].Columns {
+				if n := column.MetaData.Statistics.NullCount; n != 1 {
+					t.Errorf("%s: wrong null count: want=1 got=%v", column.MetaData.PathInSchema, n)
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range rows {
+				want, got := reflect.ValueOf(rows[i]), reflect.ValueOf(got[i])
+				for j := 0; j < want.NumField(); j++ {
+					if !reflect.DeepEqual(want.Field(j).Interface(), got.Field(j).Interface()) {
+						t.Errorf("row %d: %s: want=%v got=%v", i, want.Type().Field(j).Name, want.Field(j).Elem(), got.Field(j).Elem())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriterGzipMultiplePages(t *testing.T) {
+	type Row struct {
+		ID      int64  `parquet:"id,gzip"`
+		Payload string `parquet:"payload,gzip:9"`
+		Text    string `parquet:"text,optional"`
+	}
+
+	rows := make([]Row, 2000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:      int64(i),
+			Payload: strings.Repeat(fmt.Sprintf("payload-%d;", i), 10),
+		}
+		if i%3 != 0 {
+			rows[i].Text = fmt.Sprintf("text-%d", i)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf,
+		parquet.PageBufferSize(4096),
+		parquet.ColumnCompression("text", &parquet.Gzip, gzip.BestSpeed),
+	)
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, chunk := range f.Metadata().RowGroups[0].Columns {
+		if chunk.MetaData.Codec != format.Gzip {
+			t.Errorf("column %d: wrong codec: want=%v got=%v", i, format.Gzip, chunk.MetaData.Codec)
+		}
+	}
+	for i, offsetIndex := range f.OffsetIndexes() {
+		if numPages := len(offsetIndex.PageLocations); numPages < 2 {
+			t.Errorf("column %d: expected multiple pages, got %d", i, numPages)
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+}
+
+func TestWriterCompressionLevelInvalid(t *testing.T) {
+	for _, option := range []parquet.WriterOption{
+		parquet.ColumnCompression("text", &parquet.Zstd, 23),
+		parquet.ColumnCompression("text", &parquet.Gzip, 10),
+		parquet.ColumnCompression("text", &parquet.Brotli, -1),
+		parquet.ColumnCompression("text", &parquet.Snappy, 1),
+	} {
+		if _, err := parquet.NewWriterConfig(option); err == nil {
+			t.Errorf("expected an error configuring an invalid compression level")
+		}
+	}
+
+	for _, model := range []any{
+		struct {
+			Text string `parquet:"text,zstd:0"`
+		}{},
+		struct {
+			Text string `parquet:"text,lz4:10"`
+		}{},
+		struct {
+			Text string `parquet:"text,snappy:1"`
+		}{},
+		struct {
+			Text string `parquet:"text,zstd:max"`
+		}{},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic creating a schema of %T", model)
+				}
+			}()
+			parquet.SchemaOf(model)
+		}()
+	}
+}
+
+func TestWriterBrotliMultiplePages(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,brotli"`
+		Text string `parquet:"text,brotli:11"`
+		Fast string `parquet:"fast,brotli:0"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 2000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:   int64(i),
+			Text: strings.Repeat(strconv.Itoa(prng.Intn(100)), 1+prng.Intn(20)),
+			Fast: strconv.Itoa(prng.Int()),
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(1024))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, column := range f.Metadata().RowGroups[0].Columns {
+		if codec := column.MetaData.Codec; codec != format.Brotli {
+			t.Errorf("column %d: wrong compression codec: %s", i, codec)
+		}
+	}
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		if offsets := chunk.OffsetIndex(); offsets.NumPages() < 2 {
+			t.Errorf("column %d: expected multiple pages, got %d", i, offsets.NumPages())
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, got) {
+		t.Fatal("rows mismatch after brotli round trip")
+	}
+}
+
+func TestWriterColumnWriteConcurrency(t *testing.T) {
+	type Row struct {
+		ID     int64             `parquet:"id,delta"`
+		Name   string            `parquet:"name,dict,zstd"`
+		Score  float64           `parquet:"score,snappy"`
+		Email  *string           `parquet:"email,optional,gzip"`
+		Tags   []string          `parquet:"tags,list"`
+		Labels map[string]string `parquet:"labels"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 10_000)
+	for i := range rows {
+		rows[i] = Row{
+			ID:     int64(i),
+			Name:   fmt.Sprintf("name-%d", prng.Intn(100)),
+			Score:  prng.Float64(),
+			Tags:   []string{"a", "b", "c"}[:1+prng.Intn(3)],
+			Labels: map[string]string{"k": strconv.Itoa(i)},
+		}
+		if i%3 != 0 {
+			email := fmt.Sprintf("user-%d@example.com", i)
+			rows[i].Email = &email
+		}
+	}
+
+	write := func(concurrency int) []byte {
+		buffer := parquet.NewGenericBuffer[Row]()
+		if _, err := buffer.Write(rows[len(rows)/2:]); err != nil {
+			t.Fatal(err)
+		}
+
+		output := new(bytes.Buffer)
+		writer := parquet.NewGenericWriter[Row](output,
+			parquet.ColumnWriteConcurrency(concurrency),
+			parquet.PageBufferSize(1024),
+			parquet.MaxRowsPerRowGroup(2000),
+			parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+		)
+		if _, err := writer.Write(rows[:len(rows)/2]); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.WriteRowGroup(buffer); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return output.Bytes()
+	}
+
+	want := write(1)
+	for _, concurrency := range []int{2, 4, 16} {
+		got := write(concurrency)
+		if !bytes.Equal(got, want) {
+			t.Errorf("concurrency=%d: output differs from the sequential writer (%d != %d bytes)", concurrency, len(got), len(want))
+		}
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(want), int64(len(want)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), len(got))
+	}
+	for i := range rows {
+		if !reflect.DeepEqual(got[i], rows[i]) {
+			t.Fatalf("row %d mismatch:\nwant: %+v\ngot:  %+v", i, rows[i], got[i])
+		}
+	}
+
+	if _, err := parquet.NewWriterConfig(parquet.ColumnWriteConcurrency(-1)); err == nil {
+		t.Error("expected an error configuring a negative column write concurrency")
+	}
+}
+
+func TestWriterMaxDictionarySize(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name,dict,snappy"`
+	}
+
+	rows := make([]Row, 3000)
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprintf("name-%04d", i)}
+	}
+
+	for _, version := range []int{v1, v2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			buffer := parquet.NewGenericBuffer[Row]()
+			if _, err := buffer.Write(rows[2000:]); err != nil {
+				t.Fatal(err)
+			}
+
+			output := new(bytes.Buffer)
+			writer := parquet.NewGenericWriter[Row](output,
+				parquet.DataPageVersion(version),
+				parquet.MaxDictionarySize(1024),
+				parquet.PageBufferSize(512),
+				parquet.MaxRowsPerRowGroup(1000),
+				parquet.BloomFilters(parquet.SplitBlockFilter(10, "name")),
+			)
+			if _, err := writer.Write(rows[:2000]); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := writer.WriteRowGroup(buffer); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(f.RowGroups()); n != 3 {
+				t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+			}
+
+			for i, rowGroup := range f.RowGroups() {
+				chunk := rowGroup.ColumnChunks()[0]
+				pages := parquet.NewRawPageReader(chunk)
+				var encodings []format.Encoding
+				for {
+					header, _, err := pages.ReadRawPage()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					if header.PageType() != format.DictionaryPage {
+						encodings = append(encodings, header.Encoding())
+					}
+				}
+				pages.Close()
+
+				if encodings[0] != format.RLEDictionary {
+					t.Errorf("row group %d: first page is not dictionary encoded: %s", i, encodings[0])
+				}
+				if last := encodings[len(encodings)-1]; last != format.Plain {
+					t.Errorf("row group %d: last page did not fall back to the plain encoding: %s", i, last)
+				}
+
+				for j := i * 1000; j < (i+1)*1000; j++ {
+					if ok, err := chunk.BloomFilter().Check(parquet.ValueOf(rows[j].Name)); err != nil {
+						t.Fatal(err)
+					} else if !ok {
+						t.Fatalf("row group %d: value %q is missing from the bloom filter", i, rows[j].Name)
+					}
+				}
+			}
+
+			got, err := parquet.Read[Row](bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Error("rows mismatch after falling back to the plain encoding")
+			}
+		})
+	}
+}
+
+func TestWriterCreatedBy(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	tests := []struct {
+		scenario  string
+		options   []parquet.WriterOption
+		createdBy string
+	}{
+		{
+			scenario:  "default",
+			createdBy: "github.com/parquet-go/parquet-go",
+		},
+		{
+			scenario:  "verbatim",
+			options:   []parquet.WriterOption{parquet.CreatedBy("my-service 1.2.3")},
+			createdBy: "my-service 1.2.3",
+		},
+		{
+			scenario:  "version and build",
+			options:   []parquet.WriterOption{parquet.CreatedBy("my-service", "1.2.3", "abcdef")},
+			createdBy: "my-service version 1.2.3(build abcdef)",
+		},
+		{
+			scenario:  "created by version",
+			options:   []parquet.WriterOption{parquet.CreatedByVersion("my-service", "1.2.3", "abcdef")},
+			createdBy: "my-service version 1.2.3(build abcdef)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			if err := parquet.Write(output, []Row{{ID: 1}}, test.options...); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			createdBy := f.Metadata().CreatedBy
+			ok := createdBy == test.createdBy
+			if test.options == nil {
+				// The default value depends on the build information of the
+				// program, only the module path is known in advance.
+				ok = strings.HasPrefix(createdBy, test.createdBy)
+			}
+			if !ok {
+				t.Errorf("wrong created by metadata: want=%q got=%q", test.createdBy, createdBy)
+			}
+		})
+	}
+}
+
+func TestWriterDataPageVersion(t *testing.T) {
+	type Point struct {
+		X float64 `parquet:"x"`
+		Y float64 `parquet:"y"`
+	}
+	type Row struct {
+		ID     int64   `parquet:"id"`
+		Name   *string `parquet:"name,optional"`
+		Tags   []int32 `parquet:"tags"`
+		Points []Point `parquet:"points"`
+	}
+
+	rows := make([]Row, 500)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Tags: make([]int32, i%4), Points: []Point{}}
+		if i%3 != 0 {
+			name := fmt.Sprintf("name-%d", i)
+			rows[i].Name = &name
+		}
+		for j := range rows[i].Tags {
+			rows[i].Tags[j] = int32(i + j)
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Points = append(rows[i].Points, Point{X: float64(i), Y: float64(j)})
+		}
+	}
+
+	for _, version := range []int{v1, v2} {
+		for _, codec := range []compress.Codec{&parquet.Uncompressed, &parquet.Snappy} {
+			t.Run(fmt.Sprintf("v%d/%s", version, codec), func(t *testing.T) {
+				buf := new(bytes.Buffer)
+				w := parquet.NewGenericWriter[Row](buf,
+					parquet.DataPageVersion(version),
+					parquet.Compression(codec),
+					parquet.PageBufferSize(256),
+				)
+				if _, err := w.Write(rows); err != nil {
+					t.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					t.Fatal(err)
+				}
+
+				f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want := format.DataPageV2
+				if version == v1 {
+					want = format.DataPage
+				}
+				for _, chunk := range f.RowGroups()[0].ColumnChunks() {
+					pages := parquet.NewRawPageReader(chunk)
+					numPages := 0
+					for {
+						header, _, err := pages.ReadRawPage()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							t.Fatal(err)
+						}
+						if got := header.PageType(); got != format.DictionaryPage {
+							if got != want {
+								t.Errorf("column %d: wrong page type: want=%s got=%s", chunk.Column(), want, got)
+							}
+							numPages++
+						}
+					}
+					pages.Close()
+					if numPages < 2 {
+						t.Errorf("column %d: expected multiple pages, got %d", chunk.Column(), numPages)
+					}
+				}
+
+				got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				assertRowsEqual(t, rows, got)
+			})
+		}
+	}
+}
+
+func TestWriterDeltaLengthByteArray(t *testing.T) {
+	type Row struct {
+		Name  string `parquet:"name,delta_length"`
+		Bytes []byte `parquet:"bytes,delta_length"`
+	}
+
+	schema := parquet.SchemaOf(Row{})
+	for _, column := range []string{"name", "bytes"} {
+		leaf, _ := schema.Lookup(column)
+		if enc := leaf.Node.Encoding(); enc == nil || enc.Encoding() != format.DeltaLengthByteArray {
+			t.Errorf("wrong encoding of column %q: %v", column, enc)
+		}
+	}
+
+	rows := []Row{
+		{Name: "", Bytes: []byte{}},
+		{Name: "a", Bytes: []byte("a")},
+		{Name: "", Bytes: []byte{}},
+		{Name: strings.Repeat("long value ", 100), Bytes: bytes.Repeat([]byte{1, 2, 3}, 500)},
+		{Name: "medium value", Bytes: []byte("medium value")},
+		{Name: "", Bytes: []byte{}},
+	}
+
+	for _, version := range []int{1, 2} {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, parquet.DataPageVersion(version)); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, column := range f.Metadata().RowGroups[0].Columns {
+				if encodings := column.MetaData.Encoding; len(encodings) != 1 || encodings[0] != format.DeltaLengthByteArray {
+					t.Errorf("wrong encodings of column %d: %v", i, encodings)
+				}
+			}
+			got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertRowsEqual(t, rows, got)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic using the delta_length tag on an int64 field")
+			}
+		}()
+		type Invalid struct {
+			Value int64 `parquet:"value,delta_length"`
+		}
+		parquet.SchemaOf(Invalid{})
+	})
+}
+
+func TestWriterDeltaByteArray(t *testing.T) {
+	type PlainRow struct {
+		URL string `parquet:"url,plain,uncompressed"`
+	}
+	type Row struct {
+		URL string `parquet:"url,delta_byte_array,uncompressed"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i].URL = fmt.Sprintf("https://example.com/api/v1/users/%06d/profile", i)
+	}
+	plainRows := make([]PlainRow, len(rows))
+	for i, row := range rows {
+		plainRows[i].URL = row.URL
+	}
+
+	sizeOf := func(b []byte) int64 {
+		f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f.Metadata().RowGroups[0].Columns[0].MetaData.TotalUncompressedSize
+	}
+
+	plain := new(bytes.Buffer)
+	if err := parquet.Write(plain, plainRows); err != nil {
+		t.Fatal(err)
+	}
+	delta := new(bytes.Buffer)
+	if err := parquet.Write(delta, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(delta.Bytes()), int64(delta.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encodings := f.Metadata().RowGroups[0].Columns[0].MetaData.Encoding; len(encodings) != 1 || encodings[0] != format.DeltaByteArray {
+		t.Errorf("wrong encodings of the column: %v", encodings)
+	}
+	if plainSize, deltaSize := sizeOf(plain.Bytes()), sizeOf(delta.Bytes()); deltaSize*2 >= plainSize {
+		t.Errorf("the delta encoded column is not less than half the size of the plain encoded one: %d >= %d/2", deltaSize, plainSize)
+	}
+
+	got, err := parquet.Read[Row](bytes.NewReader(delta.Bytes()), int64(delta.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRowsEqual(t, rows, got)
+}
+
+func TestWriterFloatStatisticsIgnoreNaN(t *testing.T) {
+	type Row struct {
+		Float32    float32  `parquet:"float32"`
+		Float64    float64  `parquet:"float64"`
+		Dictionary float64  `parquet:"dictionary,dict"`
+		Optional   *float64 `parquet:"optional,optional"`
+	}
+	nan := math.NaN()
+	makeRow := func(v float64) Row {
+		return Row{Float32: float32(v), Float64: v, Dictionary: v, Optional: &v}
+	}
+
+	tests := []struct {
+		scenario string
+		rows     []Row
+		min, max float64
+		noBounds bool
+	}{
+		{
+			scenario: "leading NaN",
+			rows:     []Row{makeRow(nan), makeRow(1), makeRow(nan), makeRow(-2), makeRow(0)},
+			min:      -2,
+			max:      1,
+		},
+		{
+			scenario: "trailing NaN",
+			rows:     []Row{makeRow(3), makeRow(4), makeRow(nan)},
+			min:      3,
+			max:      4,
+		},
+		{
+			scenario: "only NaN",
+			rows:     []Row{makeRow(nan), makeRow(nan)},
+			noBounds: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			for _, version := range []int{1, 2} {
+				buf := new(bytes.Buffer)
+				if err := parquet.Write(buf, test.rows, parquet.DataPageVersion(version), parquet.DataPageStatistics(true)); err != nil {
+					t.Fatal(err)
+				}
+				f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+					stats := f.Metadata().RowGroups[0].Columns[i].MetaData.Statistics
+					min, max, ok := parquet.ColumnChunkMinMax(chunk)
+					if test.noBounds {
+						if ok || stats.MinValue != nil || stats.MaxValue != nil {
+							t.Errorf("v%d column %d: unexpected statistics of a column holding only NaN values: min=%v max=%v", version, i, min, max)
+						}
+						continue
+					}
+					if !ok {
+						t.Fatalf("v%d column %d: missing min and max statistics", version, i)
+					}
+					want := [2]parquet.Value{parquet.ValueOf(test.min), parquet.ValueOf(test.max)}
+					if chunk.Type().Kind() == parquet.Float {
+						want = [2]parquet.Value{parquet.ValueOf(float32(test.min)), parquet.ValueOf(float32(test.max))}
+					}
+					if !parquet.Equal(min, want[0]) || !parquet.Equal(max, want[1]) {
+						t.Errorf("v%d column %d: wrong bounds: want=[%v,%v] got=[%v,%v]", version, i, want[0], want[1], min, max)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriterFloatColumnIndexNaNPages(t *testing.T) {
+	type Row struct {
+		Float32    float32 `parquet:"float32"`
+		Float64    float64 `parquet:"float64"`
+		Dictionary float64 `parquet:"dictionary,dict"`
+	}
+	nan := math.NaN()
+	rows := []Row{
+		{Float32: 1, Float64: 1, Dictionary: 1},
+		{Float32: float32(nan), Float64: nan, Dictionary: nan},
+		{Float32: 2, Float64: 2, Dictionary: 2},
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.PageBufferSize(1))
+	for i := range rows {
+		// The page buffer size writes every value to a page of its own.
+		if _, err := w.Write(rows[i : i+1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, chunk := range f.RowGroups()[0].ColumnChunks() {
+		columnIndex := f.ColumnIndexes()[i]
+		if n := len(columnIndex.NullPages); n != len(rows) {
+			t.Fatalf("column %d: wrong number of pages: want=%d got=%d", i, len(rows), n)
+		}
+		if columnIndex.NullPages[1] {
+			t.Errorf("column %d: page holding only NaN values is marked as a null page", i)
+		}
+		if columnIndex.BoundaryOrder != format.Unordered {
+			t.Errorf("column %d: wrong boundary order: want=%s got=%s", i, format.Unordered, columnIndex.BoundaryOrder)
+		}
+
+		index := chunk.ColumnIndex()
+		for _, v := range []parquet.Value{index.MinValue(1), index.MaxValue(1)} {
+			f := v.Double()
+			if v.Kind() == parquet.Float {
+				f = float64(v.Float())
+			}
+			if !math.IsNaN(f) {
+				t.Errorf("column %d: wrong bounds of page holding only NaN values: %v", i, v)
+			}
+		}
+	}
+
+	for _, column := range []string{"float32", "float64", "dictionary"} {
+		value := parquet.ValueOf(2.0)
+		if column == "float32" {
+			value = parquet.ValueOf(float32(2))
+		}
+		ranges, err := f.Search(column, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranges) == 0 || ranges[len(ranges)-1].End != 3 {
+			t.Errorf("%s: searching %v did not match the last row: %+v", column, value, ranges)
+		}
+	}
+}
+
+func TestGenericWriterStats(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,zstd"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: strings.Repeat("parquet", 10)}
+	}
+
+	buf := new(bytes.Buffer)
+	w := parquet.NewGenericWriter[Row](buf, parquet.MaxRowsPerRowGroup(30))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := w.Stats()
+	if stats.NumRows != 100 {
+		t.Errorf("wrong number of rows: want=100 got=%d", stats.NumRows)
+	}
+	if stats.FileSize != int64(buf.Len()) {
+		t.Errorf("wrong file size: want=%d got=%d", buf.Len(), stats.FileSize)
+	}
+	if ratio := stats.CompressionRatio(); ratio <= 1 {
+		t.Errorf("expected repeated values to compress, got a ratio of %g", ratio)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := f.Metadata()
+	if stats.NumRowGroups != len(metadata.RowGroups) {
+		t.Errorf("wrong number of row groups: want=%d got=%d", len(metadata.RowGroups), stats.NumRowGroups)
+	}
+
+	want := make([]parquet.ColumnStats, 2)
+	var uncompressedSize, compressedSize int64
+	for _, rowGroup := range metadata.RowGroups {
+		for i, column := range rowGroup.Columns {
+			want[i].Path = column.MetaData.PathInSchema
+			want[i].NumValues += column.MetaData.NumValues
+			want[i].UncompressedSize += column.MetaData.TotalUncompressedSize
+			want[i].CompressedSize += column.MetaData.TotalCompressedSize
+			uncompressedSize += column.MetaData.TotalUncompressedSize
+			compressedSize += column.MetaData.TotalCompressedSize
+		}
+	}
+	if stats.UncompressedSize != uncompressedSize || stats.CompressedSize != compressedSize {
+		t.Errorf("wrong sizes: want=%d/%d got=%d/%d", uncompressedSize, compressedSize, stats.UncompressedSize, stats.CompressedSize)
+	}
+	if !reflect.DeepEqual(stats.Columns, want) {
+		t.Errorf("wrong column stats:\nwant: %+v\ngot:  %+v", want, stats.Columns)
+	}
+
+	// The column paths are owned by the caller.
+	stats.Columns[0].Path[0] = "changed"
+	if path := w.Stats().Columns[0].Path; path[0] != "id" {
+		t.Errorf("modifying the stats changed the column path of the writer: %q", path)
+	}
+
+	w.Reset(new(bytes.Buffer))
+	if stats := w.Stats(); stats.NumRows != 0 || len(stats.Columns) != 2 {
+		t.Errorf("stats were not reset: %+v", stats)
+	}
+}
+
+func TestWriterColumnIndexTruncateLength(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+	rows := []Row{
+		{Name: strings.Repeat("a", 40)},
+		{Name: strings.Repeat("b", 40)},
+	}
+
+	tests := []struct {
+		scenario string
+		options  []parquet.WriterOption
+		length   int
+	}{
+		{scenario: "default", length: parquet.DefaultColumnIndexSizeLimit},
+		{scenario: "truncated", options: []parquet.WriterOption{parquet.ColumnIndexTruncateLength(8)}, length: 8},
+		{scenario: "untruncated", options: []parquet.WriterOption{parquet.ColumnIndexTruncateLength(0)}, length: 40},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := parquet.Write(buf, rows, test.options...); err != nil {
+				t.Fatal(err)
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			columnIndex := f.ColumnIndexes()[0]
+			if n := len(columnIndex.MinValues[0]); n != test.length {
+				t.Errorf("wrong length of the min value: want=%d got=%d", test.length, n)
+			}
+			if n := len(columnIndex.MaxValues[0]); n != test.length {
+				t.Errorf("wrong length of the max value: want=%d got=%d", test.length, n)
+			}
+			if min := string(columnIndex.MinValues[0]); !strings.HasPrefix(rows[0].Name, min) {
+				t.Errorf("min value is not a prefix of the smallest value: %q", min)
+			}
+			if max := string(columnIndex.MaxValues[0]); max < rows[1].Name[:test.length] {
+				t.Errorf("max value is smaller than the largest value: %q", max)
+			}
+		})
+	}
+}